@@ -0,0 +1,200 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"github.com/ShopOnGO/review-service/configs"
+	"github.com/ShopOnGO/review-service/pkg/db"
+)
+
+// Коды завершения процесса.
+const (
+	exitOK    = 0
+	exitError = 1
+	exitUsage = 2
+)
+
+var (
+	// errUsage — неверные аргументы команды: печатается справка по команде.
+	errUsage = errors.New("usage error")
+	// errFlags — ошибка разбора флагов: FlagSet уже напечатал её вместе со справкой.
+	errFlags = errors.New("invalid flags")
+)
+
+type command struct {
+	name    string
+	args    string
+	summary string
+	// noConfig — команде не нужна конфигурация, флаг -config не добавляется
+	noConfig bool
+	// run получает аргументы после имени команды. errUsage и errFlags завершают
+	// процесс с exitUsage, остальные ошибки — с exitError.
+	run func(fs *flag.FlagSet, args []string) error
+}
+
+// configPath — значение флага -config текущей команды.
+var configPath string
+
+var commands = []*command{
+	serveCmd,
+	configCmd,
+	migrateCmd,
+	reconcileCmd,
+	purgeCmd,
+	seedCmd,
+	exportCmd,
+	eraseCmd,
+	tokenCmd,
+	versionCmd,
+}
+
+func progName() string {
+	return filepath.Base(os.Args[0])
+}
+
+func run(args []string) int {
+	if len(args) == 0 {
+		printUsage()
+		return exitUsage
+	}
+
+	name := args[0]
+	if name == "help" || name == "-h" || name == "-help" || name == "--help" {
+		if len(args) > 1 {
+			if cmd := findCommand(args[1]); cmd != nil {
+				newFlagSet(cmd).Usage()
+				return exitOK
+			}
+		}
+		printUsage()
+		return exitOK
+	}
+
+	cmd := findCommand(name)
+	if cmd == nil {
+		fmt.Fprintf(os.Stderr, "%s: unknown command %q\n\n", progName(), name)
+		printUsage()
+		return exitUsage
+	}
+
+	fs := newFlagSet(cmd)
+	err := cmd.run(fs, args[1:])
+	switch {
+	case err == nil:
+		return exitOK
+	case errors.Is(err, flag.ErrHelp):
+		return exitOK
+	case errors.Is(err, errFlags):
+		return exitUsage
+	case errors.Is(err, errUsage):
+		if err != errUsage {
+			msg := strings.TrimPrefix(err.Error(), errUsage.Error()+": ")
+			fmt.Fprintf(os.Stderr, "%s %s: %s\n", progName(), cmd.name, msg)
+		}
+		fs.Usage()
+		return exitUsage
+	default:
+		fmt.Fprintf(os.Stderr, "%s %s: %v\n", progName(), cmd.name, err)
+		return exitError
+	}
+}
+
+func findCommand(name string) *command {
+	for _, cmd := range commands {
+		if cmd.name == name {
+			return cmd
+		}
+	}
+	return nil
+}
+
+func newFlagSet(cmd *command) *flag.FlagSet {
+	fs := flag.NewFlagSet(cmd.name, flag.ContinueOnError)
+	if !cmd.noConfig {
+		fs.StringVar(&configPath, "config", "", "path to the YAML config (default $CONFIG_PATH or "+configs.DefaultConfigPath+")")
+	}
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s %s %s\n\n%s\n", progName(), cmd.name, cmd.args, cmd.summary)
+		if hasFlags(fs) {
+			fmt.Fprintln(os.Stderr, "\nFlags:")
+			fs.PrintDefaults()
+		}
+	}
+	return fs
+}
+
+func parseFlags(fs *flag.FlagSet, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return errFlags
+	}
+	return nil
+}
+
+func hasFlags(fs *flag.FlagSet) bool {
+	has := false
+	fs.VisitAll(func(*flag.Flag) { has = true })
+	return has
+}
+
+func printUsage() {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Usage: %s <command> [flags]\n\nCommands:\n", progName())
+	for _, cmd := range commands {
+		fmt.Fprintf(&b, "  %-10s %s\n", cmd.name, firstLine(cmd.summary))
+	}
+	fmt.Fprintf(&b, "\nRun '%s help <command>' for details.\n", progName())
+	fmt.Fprint(os.Stderr, b.String())
+}
+
+func firstLine(s string) string {
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		return s[:i]
+	}
+	return s
+}
+
+// openDB загружает конфиг и подключается к базе — общий старт для команд, работающих с данными.
+func openDB() (*configs.Config, *db.Db, error) {
+	conf, err := configs.Load(configPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	database, err := db.NewDB(conf)
+	if err != nil {
+		return nil, nil, err
+	}
+	return conf, database, nil
+}
+
+// interruptContext отменяется по SIGINT или SIGTERM, чтобы разовые команды прерывали запросы к базе.
+func interruptContext() (context.Context, context.CancelFunc) {
+	return signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+}
+
+// writeJSON пишет результат команды в файл path или в stdout, если path пустой.
+func writeJSON(path string, v interface{}) error {
+	w := os.Stdout
+	if path != "" {
+		f, err := os.Create(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
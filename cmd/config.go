@@ -0,0 +1,32 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/ShopOnGO/review-service/configs"
+)
+
+var configCmd = &command{
+	name: "config",
+	args: "[flags]",
+	summary: `Validate and print the effective configuration with secrets redacted.
+Values come from defaults, then the YAML file, then environment variables.`,
+	run: runConfig,
+}
+
+func runConfig(fs *flag.FlagSet, args []string) error {
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return errUsage
+	}
+
+	conf, err := configs.Load(configPath)
+	if err != nil {
+		return err
+	}
+	fmt.Print(conf)
+	return nil
+}
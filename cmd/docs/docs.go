@@ -24,13 +24,185 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/reviews-service/questions/{id}": {
+        "/healthz": {
             "get": {
-                "description": "Возвращает вопрос по его уникальному идентификатору",
+                "description": "Отвечает 200, пока процесс обслуживает HTTP. Зависимости не проверяются",
                 "tags": [
-                    "Вопросы"
+                    "Служебные"
                 ],
-                "summary": "Получить вопрос по ID",
+                "summary": "Проверка живости",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/gin.H"
+                        }
+                    }
+                }
+            }
+        },
+        "/readyz": {
+            "get": {
+                "description": "Проверяет базу, версию схемы и Kafka-консьюмер. Во время остановки всегда отвечает 503",
+                "tags": [
+                    "Служебные"
+                ],
+                "summary": "Проверка готовности",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_health.Report"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/internal_health.Report"
+                        }
+                    }
+                }
+            }
+        },
+        "/reviews-service/admin/gdpr/erase": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Обезличивает (mode=anonymize) или физически удаляет (mode=delete) данные пользователя или гостя с пересчётом рейтингов и лайков",
+                "tags": [
+                    "Администрирование"
+                ],
+                "summary": "Удаление данных пользователя",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID пользователя",
+                        "name": "user_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID гостя",
+                        "name": "guest_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "anonymize или delete",
+                        "name": "mode",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_gdpr.ErasureResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/gin.H"
+                        }
+                    },
+                    "401": {
+                        "description": "Нужен токен",
+                        "schema": {
+                            "$ref": "#/definitions/gin.H"
+                        }
+                    },
+                    "403": {
+                        "description": "Нет доступа к данным субъекта",
+                        "schema": {
+                            "$ref": "#/definitions/gin.H"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка удаления данных",
+                        "schema": {
+                            "$ref": "#/definitions/gin.H"
+                        }
+                    }
+                }
+            }
+        },
+        "/reviews-service/admin/gdpr/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает все отзывы, вопросы, голоса и лайки пользователя или гостя в JSON, включая удалённые",
+                "tags": [
+                    "Администрирование"
+                ],
+                "summary": "Выгрузка данных пользователя",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID пользователя",
+                        "name": "user_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID гостя",
+                        "name": "guest_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_gdpr.Export"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный субъект",
+                        "schema": {
+                            "$ref": "#/definitions/gin.H"
+                        }
+                    },
+                    "401": {
+                        "description": "Нужен токен",
+                        "schema": {
+                            "$ref": "#/definitions/gin.H"
+                        }
+                    },
+                    "403": {
+                        "description": "Нет доступа к данным субъекта",
+                        "schema": {
+                            "$ref": "#/definitions/gin.H"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка выгрузки данных",
+                        "schema": {
+                            "$ref": "#/definitions/gin.H"
+                        }
+                    }
+                }
+            }
+        },
+        "/reviews-service/admin/questions/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Снимает пометку об удалении с вопроса вместе с ответом и лайками",
+                "tags": [
+                    "Администрирование"
+                ],
+                "summary": "Восстановить удалённый вопрос",
                 "parameters": [
                     {
                         "type": "integer",
@@ -53,75 +225,1249 @@ const docTemplate = `{
                             "$ref": "#/definitions/gin.H"
                         }
                     },
+                    "401": {
+                        "description": "Нужен токен",
+                        "schema": {
+                            "$ref": "#/definitions/gin.H"
+                        }
+                    },
+                    "403": {
+                        "description": "Нужна роль модератора",
+                        "schema": {
+                            "$ref": "#/definitions/gin.H"
+                        }
+                    },
                     "404": {
                         "description": "Вопрос не найден",
                         "schema": {
                             "$ref": "#/definitions/gin.H"
                         }
+                    },
+                    "409": {
+                        "description": "Вопрос не удалён",
+                        "schema": {
+                            "$ref": "#/definitions/gin.H"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка восстановления вопроса",
+                        "schema": {
+                            "$ref": "#/definitions/gin.H"
+                        }
+                    }
+                }
+            }
+        },
+        "/reviews-service/admin/reviews/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Снимает пометку об удалении с отзыва. Опубликованный отзыв снова учитывается в рейтинге товара",
+                "tags": [
+                    "Администрирование"
+                ],
+                "summary": "Восстановить удалённый отзыв",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID отзыва",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_review.Review"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный ID",
+                        "schema": {
+                            "$ref": "#/definitions/gin.H"
+                        }
+                    },
+                    "401": {
+                        "description": "Нужен токен",
+                        "schema": {
+                            "$ref": "#/definitions/gin.H"
+                        }
+                    },
+                    "403": {
+                        "description": "Нужна роль модератора",
+                        "schema": {
+                            "$ref": "#/definitions/gin.H"
+                        }
+                    },
+                    "404": {
+                        "description": "Отзыв не найден",
+                        "schema": {
+                            "$ref": "#/definitions/gin.H"
+                        }
+                    },
+                    "409": {
+                        "description": "Отзыв не удалён",
+                        "schema": {
+                            "$ref": "#/definitions/gin.H"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка восстановления отзыва",
+                        "schema": {
+                            "$ref": "#/definitions/gin.H"
+                        }
+                    }
+                }
+            }
+        },
+        "/reviews-service/questions/search": {
+            "get": {
+                "description": "Полнотекстовый поиск (русский и английский) по тексту вопросов и ответов товара. Результаты отсортированы по релевантности, совпадения в сниппетах выделены тегом \u003cb\u003e",
+                "tags": [
+                    "Вопросы"
+                ],
+                "summary": "Поиск по вопросам товара",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID товара",
+                        "name": "product_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Поисковый запрос",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Количество результатов (не более 50)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Смещение",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag из предыдущего ответа",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last-Modified из предыдущего ответа",
+                        "name": "If-Modified-Since",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/internal_question.QuestionSearchResult"
+                            }
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Версия ответа"
+                            },
+                            "Last-Modified": {
+                                "type": "string",
+                                "description": "Время последнего изменения"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Не изменилось с прошлого запроса"
+                    },
+                    "400": {
+                        "description": "Некорректные параметры поиска",
+                        "schema": {
+                            "$ref": "#/definitions/gin.H"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка поиска вопросов",
+                        "schema": {
+                            "$ref": "#/definitions/gin.H"
+                        }
+                    }
+                }
+            }
+        },
+        "/reviews-service/questions/user/{user_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает вопросы пользователя со статусами, новые первыми. Удалённые вопросы возвращаются, только если viewer_id совпадает с автором",
+                "tags": [
+                    "Вопросы"
+                ],
+                "summary": "Вопросы пользователя",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID пользователя",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID пользователя, запрашивающего список; при запросе с токеном берётся из токена",
+                        "name": "viewer_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Количество вопросов",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Смещение",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag из предыдущего ответа",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/internal_question.UserQuestion"
+                            }
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Версия ответа"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Не изменилось с прошлого запроса"
+                    },
+                    "400": {
+                        "description": "Некорректный ID пользователя",
+                        "schema": {
+                            "$ref": "#/definitions/gin.H"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка получения вопросов",
+                        "schema": {
+                            "$ref": "#/definitions/gin.H"
+                        }
+                    }
+                }
+            }
+        },
+        "/reviews-service/questions/{id}": {
+            "get": {
+                "description": "Возвращает вопрос по его уникальному идентификатору",
+                "tags": [
+                    "Вопросы"
+                ],
+                "summary": "Получить вопрос по ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID вопроса",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag из предыдущего ответа",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last-Modified из предыдущего ответа",
+                        "name": "If-Modified-Since",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_question.Question"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Версия ответа"
+                            },
+                            "Last-Modified": {
+                                "type": "string",
+                                "description": "Время последнего изменения"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Не изменилось с прошлого запроса"
+                    },
+                    "400": {
+                        "description": "Некорректный ID",
+                        "schema": {
+                            "$ref": "#/definitions/gin.H"
+                        }
+                    },
+                    "404": {
+                        "description": "Вопрос не найден",
+                        "schema": {
+                            "$ref": "#/definitions/gin.H"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка получения вопроса",
+                        "schema": {
+                            "$ref": "#/definitions/gin.H"
+                        }
+                    }
+                }
+            }
+        },
+        "/reviews-service/reviews/highlights": {
+            "get": {
+                "description": "Возвращает лучший положительный и лучший критический отзывы товара (с учётом закрепления модератором)",
+                "tags": [
+                    "Отзывы"
+                ],
+                "summary": "Выделенные отзывы товара",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID товара",
+                        "name": "product_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag из предыдущего ответа",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last-Modified из предыдущего ответа",
+                        "name": "If-Modified-Since",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_review.ReviewHighlights"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Версия ответа"
+                            },
+                            "Last-Modified": {
+                                "type": "string",
+                                "description": "Время последнего изменения"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Не изменилось с прошлого запроса"
+                    },
+                    "400": {
+                        "description": "Некорректный ID товара",
+                        "schema": {
+                            "$ref": "#/definitions/gin.H"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка получения отзывов",
+                        "schema": {
+                            "$ref": "#/definitions/gin.H"
+                        }
+                    }
+                }
+            }
+        },
+        "/reviews-service/reviews/pending": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает гостевые отзывы, ожидающие модерации, старые первыми",
+                "tags": [
+                    "Отзывы"
+                ],
+                "summary": "Очередь модерации",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Количество отзывов",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Смещение",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/internal_review.Review"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Нужен токен",
+                        "schema": {
+                            "$ref": "#/definitions/gin.H"
+                        }
+                    },
+                    "403": {
+                        "description": "Нужна роль модератора",
+                        "schema": {
+                            "$ref": "#/definitions/gin.H"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка получения отзывов",
+                        "schema": {
+                            "$ref": "#/definitions/gin.H"
+                        }
+                    }
+                }
+            }
+        },
+        "/reviews-service/reviews/ratings": {
+            "get": {
+                "description": "Возвращает количество отзывов, среднюю оценку и гистограмму оценок для списка товаров (не более 100 за запрос)",
+                "tags": [
+                    "Отзывы"
+                ],
+                "summary": "Сводки оценок для нескольких товаров",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID товаров через запятую",
+                        "name": "product_ids",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag из предыдущего ответа",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last-Modified из предыдущего ответа",
+                        "name": "If-Modified-Since",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/internal_review.RatingSummary"
+                            }
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Версия ответа"
+                            },
+                            "Last-Modified": {
+                                "type": "string",
+                                "description": "Время последнего изменения"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Не изменилось с прошлого запроса"
+                    },
+                    "400": {
+                        "description": "Некорректный список товаров",
+                        "schema": {
+                            "$ref": "#/definitions/gin.H"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка получения сводок оценок",
+                        "schema": {
+                            "$ref": "#/definitions/gin.H"
+                        }
+                    }
+                }
+            }
+        },
+        "/reviews-service/reviews/search": {
+            "get": {
+                "description": "Полнотекстовый поиск (русский и английский) по тексту отзывов товара. Результаты отсортированы по релевантности, совпадения в snippet выделены тегом \u003cb\u003e",
+                "tags": [
+                    "Отзывы"
+                ],
+                "summary": "Поиск по отзывам товара",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID товара",
+                        "name": "product_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Поисковый запрос",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Количество результатов (не более 50)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Смещение",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag из предыдущего ответа",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last-Modified из предыдущего ответа",
+                        "name": "If-Modified-Since",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/internal_review.ReviewSearchResult"
+                            }
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Версия ответа"
+                            },
+                            "Last-Modified": {
+                                "type": "string",
+                                "description": "Время последнего изменения"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Не изменилось с прошлого запроса"
+                    },
+                    "400": {
+                        "description": "Некорректные параметры поиска",
+                        "schema": {
+                            "$ref": "#/definitions/gin.H"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка поиска отзывов",
+                        "schema": {
+                            "$ref": "#/definitions/gin.H"
+                        }
+                    }
+                }
+            }
+        },
+        "/reviews-service/reviews/user/{user_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает отзывы пользователя новые первыми: state — published, pending, rejected или deleted. Удалённые отзывы возвращаются, только если viewer_id совпадает с автором",
+                "tags": [
+                    "Отзывы"
+                ],
+                "summary": "Отзывы пользователя",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID пользователя",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID пользователя, запрашивающего список; при запросе с токеном берётся из токена",
+                        "name": "viewer_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Количество отзывов",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Смещение",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag из предыдущего ответа",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/internal_review.UserReview"
+                            }
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Версия ответа"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Не изменилось с прошлого запроса"
+                    },
+                    "400": {
+                        "description": "Некорректный ID пользователя",
+                        "schema": {
+                            "$ref": "#/definitions/gin.H"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка получения отзывов",
+                        "schema": {
+                            "$ref": "#/definitions/gin.H"
+                        }
+                    }
+                }
+            }
+        },
+        "/reviews-service/reviews/{id}": {
+            "get": {
+                "description": "Возвращает отзыв по его уникальному идентификатору. Отзыв на модерации или отклонённый видят только автор с токеном пользователя, модератор и API-шлюз; остальным, в том числе гостю без токена, возвращается 404",
+                "tags": [
+                    "Отзывы"
+                ],
+                "summary": "Получить отзыв по ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID отзыва",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag из предыдущего ответа",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last-Modified из предыдущего ответа",
+                        "name": "If-Modified-Since",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_review.Review"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Версия ответа"
+                            },
+                            "Last-Modified": {
+                                "type": "string",
+                                "description": "Время последнего изменения"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Не изменилось с прошлого запроса"
+                    },
+                    "400": {
+                        "description": "Некорректный ID",
+                        "schema": {
+                            "$ref": "#/definitions/gin.H"
+                        }
+                    },
+                    "404": {
+                        "description": "Отзыв не найден",
+                        "schema": {
+                            "$ref": "#/definitions/gin.H"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка получения отзыва",
+                        "schema": {
+                            "$ref": "#/definitions/gin.H"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "gin.H": {
+            "type": "object",
+            "additionalProperties": {}
+        },
+        "github_com_ShopOnGO_review-service_internal_question.GuestMerge": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "guest_id": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "likes_dropped": {
+                    "type": "integer"
+                },
+                "likes_moved": {
+                    "type": "integer"
+                },
+                "questions_moved": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_ShopOnGO_review-service_internal_question.Question": {
+            "type": "object",
+            "properties": {
+                "answer_text": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "guest_id": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "likes_count": {
+                    "type": "integer"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "question_text": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_ShopOnGO_review-service_internal_question.QuestionLike": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "guest_id": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "question_id": {
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_ShopOnGO_review-service_internal_review.Review": {
+            "type": "object",
+            "properties": {
+                "comment": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "dislikes_count": {
+                    "type": "integer"
+                },
+                "guest_id": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "likes_count": {
+                    "type": "integer"
+                },
+                "product_variant_id": {
+                    "type": "integer"
+                },
+                "rating": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_ShopOnGO_review-service_internal_review.ReviewVote": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "helpful": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "review_id": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "gorm.DeletedAt": {
+            "type": "object",
+            "properties": {
+                "time": {
+                    "type": "string"
+                },
+                "valid": {
+                    "description": "Valid is true if Time is not NULL",
+                    "type": "boolean"
+                }
+            }
+        },
+        "internal_gdpr.ErasureResult": {
+            "type": "object",
+            "properties": {
+                "mode": {
+                    "type": "string"
+                },
+                "product_ids": {
+                    "description": "товары, чьи отзывы или счётчики изменились",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "question_likes_removed": {
+                    "type": "integer"
+                },
+                "questions": {
+                    "type": "integer"
+                },
+                "review_votes_removed": {
+                    "type": "integer"
+                },
+                "reviews": {
+                    "type": "integer"
+                },
+                "subject": {
+                    "$ref": "#/definitions/internal_gdpr.Subject"
+                }
+            }
+        },
+        "internal_gdpr.Export": {
+            "type": "object",
+            "properties": {
+                "exported_at": {
+                    "type": "string"
+                },
+                "guest_merges": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_ShopOnGO_review-service_internal_question.GuestMerge"
+                    }
+                },
+                "question_likes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_ShopOnGO_review-service_internal_question.QuestionLike"
+                    }
+                },
+                "questions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_ShopOnGO_review-service_internal_question.Question"
+                    }
+                },
+                "review_votes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_ShopOnGO_review-service_internal_review.ReviewVote"
+                    }
+                },
+                "reviews": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_ShopOnGO_review-service_internal_review.Review"
+                    }
+                },
+                "subject": {
+                    "$ref": "#/definitions/internal_gdpr.Subject"
+                }
+            }
+        },
+        "internal_gdpr.Subject": {
+            "type": "object",
+            "properties": {
+                "guest_id": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_health.Report": {
+            "type": "object",
+            "properties": {
+                "checks": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
                     }
+                },
+                "ready": {
+                    "type": "boolean"
+                },
+                "status": {
+                    "type": "string"
                 }
             }
         },
-        "/reviews-service/reviews/{id}": {
-            "get": {
-                "description": "Возвращает отзыв по его уникальному идентификатору",
-                "tags": [
-                    "Отзывы"
-                ],
-                "summary": "Получить отзыв по ID",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "ID отзыва",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+        "internal_question.Question": {
+            "type": "object",
+            "properties": {
+                "answer_text": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "guest_id": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
                     }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/internal_review.Review"
-                        }
-                    },
-                    "400": {
-                        "description": "Некорректный ID",
-                        "schema": {
-                            "$ref": "#/definitions/gin.H"
-                        }
-                    },
-                    "404": {
-                        "description": "Отзыв не найден",
-                        "schema": {
-                            "$ref": "#/definitions/gin.H"
-                        }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "likes_count": {
+                    "type": "integer"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "question_text": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_question.QuestionSearchResult": {
+            "type": "object",
+            "properties": {
+                "answer_snippet": {
+                    "type": "string"
+                },
+                "answer_text": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "guest_id": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
                     }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "likes_count": {
+                    "type": "integer"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "question_snippet": {
+                    "type": "string"
+                },
+                "question_text": {
+                    "type": "string"
+                },
+                "rank": {
+                    "type": "number"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
                 }
             }
-        }
-    },
-    "definitions": {
-        "gin.H": {
+        },
+        "internal_question.UserQuestion": {
             "type": "object",
-            "additionalProperties": {}
+            "properties": {
+                "answer_text": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "guest_id": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "likes_count": {
+                    "type": "integer"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "question_text": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
         },
-        "gorm.DeletedAt": {
+        "internal_review.RatingSummary": {
             "type": "object",
             "properties": {
-                "time": {
+                "average": {
+                    "type": "number"
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "histogram": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "product_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_review.Review": {
+            "type": "object",
+            "properties": {
+                "comment": {
                     "type": "string"
                 },
-                "valid": {
-                    "description": "Valid is true if Time is not NULL",
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "dislikes_count": {
+                    "type": "integer"
+                },
+                "guest_id": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "likes_count": {
+                    "type": "integer"
+                },
+                "product_variant_id": {
+                    "type": "integer"
+                },
+                "rating": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_review.ReviewHighlights": {
+            "type": "object",
+            "properties": {
+                "critical_pinned": {
+                    "type": "boolean"
+                },
+                "positive_pinned": {
                     "type": "boolean"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "top_critical": {
+                    "$ref": "#/definitions/internal_review.Review"
+                },
+                "top_positive": {
+                    "$ref": "#/definitions/internal_review.Review"
                 }
             }
         },
-        "internal_question.Question": {
+        "internal_review.ReviewSearchResult": {
             "type": "object",
             "properties": {
-                "answer_text": {
+                "comment": {
                     "type": "string"
                 },
                 "createdAt": {
@@ -130,6 +1476,9 @@ const docTemplate = `{
                 "deletedAt": {
                     "$ref": "#/definitions/gorm.DeletedAt"
                 },
+                "dislikes_count": {
+                    "type": "integer"
+                },
                 "guest_id": {
                     "type": "array",
                     "items": {
@@ -145,7 +1494,16 @@ const docTemplate = `{
                 "product_variant_id": {
                     "type": "integer"
                 },
-                "question_text": {
+                "rank": {
+                    "type": "number"
+                },
+                "rating": {
+                    "type": "integer"
+                },
+                "snippet": {
+                    "type": "string"
+                },
+                "status": {
                     "type": "string"
                 },
                 "updatedAt": {
@@ -156,7 +1514,7 @@ const docTemplate = `{
                 }
             }
         },
-        "internal_review.Review": {
+        "internal_review.UserReview": {
             "type": "object",
             "properties": {
                 "comment": {
@@ -168,6 +1526,15 @@ const docTemplate = `{
                 "deletedAt": {
                     "$ref": "#/definitions/gorm.DeletedAt"
                 },
+                "dislikes_count": {
+                    "type": "integer"
+                },
+                "guest_id": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
                 "id": {
                     "type": "integer"
                 },
@@ -180,6 +1547,12 @@ const docTemplate = `{
                 "rating": {
                     "type": "integer"
                 },
+                "state": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
                 "updatedAt": {
                     "type": "string"
                 },
@@ -188,6 +1561,14 @@ const docTemplate = `{
                 }
             }
         }
+    },
+    "securityDefinitions": {
+        "BearerAuth": {
+            "description": "JWT сервиса авторизации ShopOnGO в виде \"Bearer \u003ctoken\u003e\"",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
+        }
     }
 }`
 
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/ShopOnGO/review-service/internal/gdpr"
+	"github.com/ShopOnGO/review-service/internal/review"
+)
+
+var exportCmd = &command{
+	name: "export",
+	args: "-user-id N | -guest-id ID [-out FILE]",
+	summary: `Export a user's or guest's data as JSON.
+Includes reviews, questions, review votes, question likes and guest merges,
+deleted rows included.`,
+	run: runExport,
+}
+
+var eraseCmd = &command{
+	name: "erase",
+	args: "-user-id N | -guest-id ID [-mode anonymize|delete]",
+	summary: `Erase a user's or guest's content.
+anonymize removes authorship and keeps the content; delete removes it and
+adjusts rating aggregates and like counters.`,
+	run: runErase,
+}
+
+func subjectFlags(fs *flag.FlagSet) (*uint, *string) {
+	userID := fs.Uint("user-id", 0, "user ID")
+	guestID := fs.String("guest-id", "", "guest ID")
+	return userID, guestID
+}
+
+func newGdprService() (*gdpr.GdprService, error) {
+	_, database, err := openDB()
+	if err != nil {
+		return nil, err
+	}
+	reviewSvc := review.NewReviewService(review.NewReviewRepository(database), review.ModerationRules{}, nil, nil)
+	return gdpr.NewGdprService(gdpr.NewGdprRepository(database), reviewSvc), nil
+}
+
+func runExport(fs *flag.FlagSet, args []string) error {
+	userID, guestID := subjectFlags(fs)
+	out := fs.String("out", "", "write the export to this file instead of stdout")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 || (*userID == 0) == (*guestID == "") {
+		return errUsage
+	}
+
+	gdprSvc, err := newGdprService()
+	if err != nil {
+		return err
+	}
+	ctx, stop := interruptContext()
+	defer stop()
+
+	export, err := gdprSvc.Export(ctx, gdpr.Subject{UserID: *userID, GuestID: *guestID})
+	if err != nil {
+		return err
+	}
+	return writeJSON(*out, export)
+}
+
+func runErase(fs *flag.FlagSet, args []string) error {
+	userID, guestID := subjectFlags(fs)
+	mode := fs.String("mode", gdpr.ModeAnonymize, "erasure mode: anonymize or delete")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 || (*userID == 0) == (*guestID == "") {
+		return errUsage
+	}
+	if *mode != gdpr.ModeAnonymize && *mode != gdpr.ModeDelete {
+		return fmt.Errorf("%w: unknown mode %q", errUsage, *mode)
+	}
+
+	gdprSvc, err := newGdprService()
+	if err != nil {
+		return err
+	}
+	ctx, stop := interruptContext()
+	defer stop()
+
+	result, err := gdprSvc.Erase(ctx, gdpr.Subject{UserID: *userID, GuestID: *guestID}, *mode)
+	if err != nil {
+		return err
+	}
+	return writeJSON("", result)
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/ShopOnGO/review-service/migrations"
+)
+
+var migrateCmd = &command{
+	name: "migrate",
+	args: "up | down | status | to N",
+	summary: `Manage the database schema version.
+  up       apply all pending migrations
+  down     roll back the latest applied migration
+  to N     migrate up or down to version N (0 rolls back everything)
+  status   list embedded migrations and when they were applied`,
+	run: runMigrate,
+}
+
+func runMigrate(fs *flag.FlagSet, args []string) error {
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		return errUsage
+	}
+
+	action := fs.Arg(0)
+	var target int
+	switch action {
+	case "up", "down", "status":
+		if fs.NArg() != 1 {
+			return errUsage
+		}
+	case "to":
+		if fs.NArg() != 2 {
+			return errUsage
+		}
+		version, err := strconv.Atoi(fs.Arg(1))
+		if err != nil || version < 0 {
+			return fmt.Errorf("%w: invalid version %q", errUsage, fs.Arg(1))
+		}
+		target = version
+	default:
+		return errUsage
+	}
+
+	_, database, err := openDB()
+	if err != nil {
+		return err
+	}
+	ctx, stop := interruptContext()
+	defer stop()
+
+	migrator, err := migrations.NewMigrator(ctx, database.DB)
+	if err != nil {
+		return err
+	}
+
+	switch action {
+	case "up":
+		return migrator.Up(ctx)
+	case "down":
+		return migrator.Down(ctx)
+	case "to":
+		return migrator.To(ctx, target)
+	default:
+		return printMigrationStatus(ctx, migrator)
+	}
+}
+
+func printMigrationStatus(ctx context.Context, migrator *migrations.Migrator) error {
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		return err
+	}
+	version, err := migrator.Version(ctx)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("schema version: %d (latest: %d)\n\n", version, migrator.Latest())
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+	for _, s := range statuses {
+		appliedAt := "pending"
+		if s.AppliedAt != nil {
+			appliedAt = s.AppliedAt.Format(time.RFC3339)
+		}
+		fmt.Fprintf(w, "%04d\t%s\t%s\n", s.Version, s.Name, appliedAt)
+	}
+	return w.Flush()
+}
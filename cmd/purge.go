@@ -0,0 +1,50 @@
+package main
+
+import (
+	"flag"
+
+	"github.com/ShopOnGO/review-service/internal/purge"
+)
+
+var purgeCmd = &command{
+	name: "purge",
+	args: "[flags]",
+	summary: `Hard-delete expired soft-deleted reviews and questions.
+Rows soft-deleted longer than the retention period are removed in batches
+together with their votes, highlights and likes.`,
+	run: runPurge,
+}
+
+func runPurge(fs *flag.FlagSet, args []string) error {
+	dryRun := fs.Bool("dry-run", false, "only count rows that would be deleted")
+	retentionDays := fs.Int("retention-days", 0, "retention period in days (default PURGE_RETENTION_DAYS)")
+	batchSize := fs.Int("batch-size", 0, "rows deleted per transaction (default PURGE_BATCH_SIZE)")
+	out := fs.String("out", "", "write the JSON result to this file instead of stdout")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return errUsage
+	}
+
+	conf, database, err := openDB()
+	if err != nil {
+		return err
+	}
+	if *retentionDays > 0 {
+		conf.Purge.RetentionDays = *retentionDays
+	}
+	if *batchSize > 0 {
+		conf.Purge.BatchSize = *batchSize
+	}
+
+	ctx, stop := interruptContext()
+	defer stop()
+
+	purgeSvc := purge.NewPurgeService(purge.NewPurgeRepository(database), conf.Purge)
+	result, err := purgeSvc.Run(ctx, *dryRun)
+	if err != nil {
+		return err
+	}
+	return writeJSON(*out, result)
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"flag"
+
+	"github.com/ShopOnGO/review-service/internal/reconcile"
+)
+
+var reconcileCmd = &command{
+	name: "reconcile",
+	args: "[flags]",
+	summary: `Recompute denormalized counters from source rows and fix drift.
+Product rating aggregates are recomputed by default; -likes also recomputes
+review and question like counters from per-user votes.`,
+	run: runReconcile,
+}
+
+func runReconcile(fs *flag.FlagSet, args []string) error {
+	dryRun := fs.Bool("dry-run", false, "only count rows that drifted, change nothing")
+	ratings := fs.Bool("ratings", true, "recompute product review_count, rating_sum and rating")
+	likes := fs.Bool("likes", false, "recompute like counters from votes (drops likes given before per-user votes existed)")
+	out := fs.String("out", "", "write the JSON result to this file instead of stdout")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return errUsage
+	}
+
+	_, database, err := openDB()
+	if err != nil {
+		return err
+	}
+	reconcileSvc := reconcile.NewReconcileService(reconcile.NewReconcileRepository(database))
+
+	ctx, stop := interruptContext()
+	defer stop()
+
+	result, err := reconcileSvc.Run(ctx, reconcile.Options{
+		DryRun:  *dryRun,
+		Ratings: *ratings,
+		Likes:   *likes,
+	})
+	if err != nil {
+		return err
+	}
+	return writeJSON(*out, result)
+}
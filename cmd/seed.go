@@ -0,0 +1,101 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math/rand"
+
+	"github.com/ShopOnGO/review-service/internal/question"
+	"github.com/ShopOnGO/review-service/internal/review"
+)
+
+var seedCmd = &command{
+	name: "seed",
+	args: "[flags]",
+	summary: `Fill the database with sample reviews and questions for local development.
+Reviews go through the regular create path, so product rating aggregates stay consistent.`,
+	run: runSeed,
+}
+
+var seedComments = []string{
+	"Отличный товар, пользуюсь каждый день.",
+	"Качество соответствует цене, доставка быстрая.",
+	"Размер маловат, пришлось менять.",
+	"Сломался через неделю, не рекомендую.",
+	"Great value for the money, would buy again.",
+	"Цвет немного отличается от фото, но в целом доволен.",
+	"Упаковка была повреждена, сам товар цел.",
+	"Works as described.",
+}
+
+var seedQuestions = []string{
+	"Подходит ли для детей?",
+	"Есть ли гарантия?",
+	"Какой размер выбрать при росте 180 см?",
+	"Is it waterproof?",
+	"Можно ли стирать в машинке?",
+}
+
+var seedAnswers = []string{
+	"Да, подходит.",
+	"Гарантия производителя — 12 месяцев.",
+	"Рекомендуем размер L.",
+	"Yes, up to 30 meters.",
+}
+
+func runSeed(fs *flag.FlagSet, args []string) error {
+	firstProduct := fs.Uint("first-product-id", 1, "ID of the first product to seed")
+	products := fs.Int("products", 10, "number of consecutive products to seed")
+	reviewsPerProduct := fs.Int("reviews", 5, "reviews per product")
+	questionsPerProduct := fs.Int("questions", 3, "questions per product")
+	randSeed := fs.Int64("rand-seed", 1, "random seed, the same seed produces the same data")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 || *firstProduct == 0 || *products <= 0 || *reviewsPerProduct < 0 || *questionsPerProduct < 0 {
+		return errUsage
+	}
+
+	_, database, err := openDB()
+	if err != nil {
+		return err
+	}
+	reviewSvc := review.NewReviewService(review.NewReviewRepository(database), review.ModerationRules{}, nil, nil)
+	questionSvc := question.NewQuestionService(question.NewQuestionRepository(database), nil)
+	rnd := rand.New(rand.NewSource(*randSeed))
+
+	ctx, stop := interruptContext()
+	defer stop()
+
+	var reviewsCreated, questionsCreated int
+	for p := 0; p < *products; p++ {
+		productID := *firstProduct + uint(p)
+
+		for i := 0; i < *reviewsPerProduct; i++ {
+			userID := uint(rnd.Intn(1000) + 1)
+			rating := int16(rnd.Intn(5) + 1)
+			if _, err := reviewSvc.AddReview(ctx, productID, &userID, nil, rating, seedComments[rnd.Intn(len(seedComments))]); err != nil {
+				return fmt.Errorf("seed review for product %d: %w", productID, err)
+			}
+			reviewsCreated++
+		}
+
+		for i := 0; i < *questionsPerProduct; i++ {
+			userID := uint(rnd.Intn(1000) + 1)
+			created, err := questionSvc.AddQuestion(ctx, productID, seedQuestions[rnd.Intn(len(seedQuestions))], &userID, nil)
+			if err != nil {
+				return fmt.Errorf("seed question for product %d: %w", productID, err)
+			}
+			if rnd.Intn(2) == 0 {
+				if _, err := questionSvc.AnswerQuestion(ctx, created.ID, seedAnswers[rnd.Intn(len(seedAnswers))], false); err != nil {
+					return fmt.Errorf("seed answer for question %d: %w", created.ID, err)
+				}
+			}
+			questionsCreated++
+		}
+	}
+
+	fmt.Printf("seeded %d reviews and %d questions for products %d..%d\n",
+		reviewsCreated, questionsCreated, *firstProduct, *firstProduct+uint(*products)-1)
+	return nil
+}
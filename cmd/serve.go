@@ -0,0 +1,124 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ShopOnGO/ShopOnGO/pkg/logger"
+	"github.com/ShopOnGO/review-service/configs"
+	"github.com/ShopOnGO/review-service/internal/app"
+	"github.com/ShopOnGO/review-service/internal/tracing"
+	"github.com/ShopOnGO/review-service/migrations"
+)
+
+var serveCmd = &command{
+	name: "serve",
+	args: "[flags]",
+	summary: `Start the service: HTTP API, gRPC API and Kafka consumer.
+Use -components to run only some of them, e.g. a separate Kafka consumer process.`,
+	run: runServe,
+}
+
+const (
+	componentHTTP  = "http"
+	componentGRPC  = "grpc"
+	componentKafka = "kafka"
+)
+
+func runServe(fs *flag.FlagSet, args []string) error {
+	componentsFlag := fs.String("components", "http,grpc,kafka", "comma-separated components to run: http, grpc, kafka")
+	migrate := fs.Bool("migrate", false, "apply pending migrations before start")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return errUsage
+	}
+
+	components, err := parseComponents(*componentsFlag)
+	if err != nil {
+		return err
+	}
+
+	conf, database, err := openDB()
+	if err != nil {
+		return err
+	}
+	if components[componentKafka] {
+		if err := conf.Kafka.Validate(); err != nil {
+			return err
+		}
+	}
+	if components[componentHTTP] || components[componentGRPC] {
+		if err := conf.Auth.Validate(); err != nil {
+			return err
+		}
+		if !conf.Auth.Enabled {
+			logger.Warn("Token verification is disabled (auth.enabled: false): API callers are trusted with any author ID")
+		}
+	}
+	logger.Infof("Effective config:\n%s", conf)
+	if conf.Features.Metrics {
+		if err := database.RegisterMetrics(); err != nil {
+			return fmt.Errorf("register DB metrics: %w", err)
+		}
+	}
+	ctx, stop := interruptContext()
+	defer stop()
+
+	shutdownTracing, err := tracing.Init(ctx, conf.Tracing, version)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracing(ctx); err != nil {
+			logger.Errorf("Tracing shutdown error: %v", err)
+		}
+	}()
+	if conf.Tracing.Exporter != configs.TracingExporterNone {
+		if err := database.EnableTracing(); err != nil {
+			return fmt.Errorf("enable DB tracing: %w", err)
+		}
+	}
+	if *migrate {
+		logger.Info("🚀 Starting migrations...")
+		if err := migrations.RunMigrations(ctx, database.DB); err != nil {
+			return fmt.Errorf("migrations: %w", err)
+		}
+	}
+	services := app.InitServices(conf, database)
+
+	err = app.Serve(ctx, services, app.Components{
+		HTTP:  components[componentHTTP],
+		GRPC:  components[componentGRPC],
+		Kafka: components[componentKafka],
+	})
+	if closeErr := database.Close(); closeErr != nil {
+		logger.Errorf("Closing database: %v", closeErr)
+	}
+	logger.Info("All is stopping")
+	return err
+}
+
+func parseComponents(raw string) (map[string]bool, error) {
+	components := make(map[string]bool)
+	for _, name := range strings.Split(raw, ",") {
+		name = strings.TrimSpace(name)
+		switch name {
+		case componentHTTP, componentGRPC, componentKafka:
+			components[name] = true
+		case "":
+		default:
+			return nil, fmt.Errorf("%w: unknown component %q", errUsage, name)
+		}
+	}
+	if len(components) == 0 {
+		return nil, fmt.Errorf("%w: no components to run", errUsage)
+	}
+	return components, nil
+}
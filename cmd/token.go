@@ -0,0 +1,47 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/ShopOnGO/ShopOnGO/pkg/jwt"
+	"github.com/ShopOnGO/review-service/configs"
+)
+
+var tokenCmd = &command{
+	name: "token",
+	args: "[-user N] [-role ROLE] [-ttl DURATION]",
+	summary: `Issue a JWT signed with the first auth secret, for local testing.
+The token has the same claims as tokens of the ShopOnGO auth service:
+user_id, role and exp.`,
+	run: runToken,
+}
+
+func runToken(fs *flag.FlagSet, args []string) error {
+	userID := fs.Uint("user", 0, "user ID (may be 0 for admin and service roles)")
+	role := fs.String("role", "buyer", "role, e.g. buyer, admin or service")
+	ttl := fs.Duration("ttl", time.Hour, "token lifetime")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 || *ttl <= 0 || *role == "" {
+		return errUsage
+	}
+
+	conf, err := configs.Load(configPath)
+	if err != nil {
+		return err
+	}
+	if len(conf.Auth.Secrets) == 0 {
+		return errors.New("auth.secrets is empty: nothing to sign the token with")
+	}
+
+	token, err := jwt.NewJWT(conf.Auth.Secrets[0]).Create(jwt.JWTData{UserID: *userID, Role: *role}, *ttl)
+	if err != nil {
+		return err
+	}
+	fmt.Println(token)
+	return nil
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"runtime"
+)
+
+// Заполняются при сборке: go build -ldflags "-X main.version=... -X main.commit=... -X main.buildDate=..."
+var (
+	version   = "dev"
+	commit    = "unknown"
+	buildDate = "unknown"
+)
+
+var versionCmd = &command{
+	name:     "version",
+	summary:  "Print the build version.",
+	noConfig: true,
+	run:      runVersion,
+}
+
+func runVersion(fs *flag.FlagSet, args []string) error {
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return errUsage
+	}
+
+	fmt.Printf("review-service %s (commit %s, built %s, %s)\n", version, commit, buildDate, runtime.Version())
+	return nil
+}
@@ -1,47 +1,337 @@
 package configs
 
 import (
+	"errors"
+	"fmt"
+	"net/netip"
 	"os"
-	"strings"
+	"time"
 
 	"github.com/ShopOnGO/ShopOnGO/pkg/logger"
 	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
 )
 
+// DefaultConfigPath — YAML-файл конфигурации, который читается, если CONFIG_PATH не задан.
+// Отсутствие файла по умолчанию не ошибка: тогда используются значения по умолчанию и окружение.
+const DefaultConfigPath = "config.yaml"
+
+// Config собирается в три слоя: значения по умолчанию, YAML-файл, переменные окружения
+// (тег env). Каждый следующий слой перекрывает предыдущий.
 type Config struct {
-	Db DbConfig
-	Kafka KafkaConfig
+	HTTP      HTTPConfig      `yaml:"http"`
+	GRPC      GRPCConfig      `yaml:"grpc"`
+	Db        DbConfig        `yaml:"db"`
+	Kafka     KafkaConfig     `yaml:"kafka"`
+	Features  FeaturesConfig  `yaml:"features"`
+	Reviews   ReviewsConfig   `yaml:"reviews"`
+	Purge     PurgeConfig     `yaml:"purge"`
+	Tracing   TracingConfig   `yaml:"tracing"`
+	Shutdown  ShutdownConfig  `yaml:"shutdown"`
+	Auth      AuthConfig      `yaml:"auth"`
+	Redis     RedisConfig     `yaml:"redis"`
+	RateLimit RateLimitConfig `yaml:"rate_limit"`
+	Cache     CacheConfig     `yaml:"cache"`
+}
+
+type HTTPConfig struct {
+	Addr              string        `yaml:"addr" env:"HTTP_ADDR"`
+	ReadHeaderTimeout time.Duration `yaml:"read_header_timeout" env:"HTTP_READ_HEADER_TIMEOUT"`
+	ReadTimeout       time.Duration `yaml:"read_timeout" env:"HTTP_READ_TIMEOUT"`
+	WriteTimeout      time.Duration `yaml:"write_timeout" env:"HTTP_WRITE_TIMEOUT"`
+	IdleTimeout       time.Duration `yaml:"idle_timeout" env:"HTTP_IDLE_TIMEOUT"`
+	// CacheMaxAge — max-age в Cache-Control публичных отзывов и вопросов; 0 — браузеры и CDN
+	// проверяют ETag при каждом запросе
+	CacheMaxAge time.Duration `yaml:"cache_max_age" env:"HTTP_CACHE_MAX_AGE"`
+	// CacheStaleWhileRevalidate — сколько после max-age CDN может отдавать ответ, обновляя его в фоне
+	CacheStaleWhileRevalidate time.Duration `yaml:"cache_stale_while_revalidate" env:"HTTP_CACHE_STALE_WHILE_REVALIDATE"`
+}
+
+type GRPCConfig struct {
+	Addr string `yaml:"addr" env:"GRPC_ADDR"`
+	// ConnectionTimeout — время на установку соединения и handshake
+	ConnectionTimeout time.Duration `yaml:"connection_timeout" env:"GRPC_CONNECTION_TIMEOUT"`
 }
 
 type DbConfig struct {
-	Dsn string
+	Dsn             string        `yaml:"dsn" env:"DSN" secret:"true"`
+	MaxOpenConns    int           `yaml:"max_open_conns" env:"DB_MAX_OPEN_CONNS"`
+	MaxIdleConns    int           `yaml:"max_idle_conns" env:"DB_MAX_IDLE_CONNS"`
+	ConnMaxLifetime time.Duration `yaml:"conn_max_lifetime" env:"DB_CONN_MAX_LIFETIME"`
+	ConnMaxIdleTime time.Duration `yaml:"conn_max_idle_time" env:"DB_CONN_MAX_IDLE_TIME"`
+	// StatementTimeout — statement_timeout сессии; 0 — настройка сервера
+	StatementTimeout time.Duration `yaml:"statement_timeout" env:"DB_STATEMENT_TIMEOUT"`
+	// StatementCacheCapacity — размер кэша подготовленных выражений на соединение; 0 — без подготовки (PgBouncer)
+	StatementCacheCapacity int `yaml:"statement_cache_capacity" env:"DB_STATEMENT_CACHE_CAPACITY"`
+	// ConnectTimeout — сколько ждать доступности базы при старте
+	ConnectTimeout time.Duration `yaml:"connect_timeout" env:"DB_CONNECT_TIMEOUT"`
 }
 
 type KafkaConfig struct {
-	Brokers []string
-	Topic   string
-	GroupID string
-	ClientID string
+	Brokers  []string `yaml:"brokers" env:"KAFKA_BROKERS"`
+	Topic    string   `yaml:"topic" env:"KAFKA_TOPIC"`
+	GroupID  string   `yaml:"group_id" env:"KAFKA_GROUP_ID"`
+	ClientID string   `yaml:"client_id" env:"KAFKA_CLIENT_ID"`
+	// HandlerTimeout — сколько может обрабатываться одно сообщение, включая запросы к базе
+	HandlerTimeout time.Duration `yaml:"handler_timeout" env:"KAFKA_HANDLER_TIMEOUT"`
+}
+
+// FeaturesConfig — переключатели необязательных частей API.
+type FeaturesConfig struct {
+	// GuestReviews — принимать отзывы от незарегистрированных пользователей
+	GuestReviews bool `yaml:"guest_reviews" env:"FEATURE_GUEST_REVIEWS"`
+	// Metrics — метрики Prometheus на GET /metrics: HTTP, gRPC, Kafka и запросы к базе
+	Metrics bool `yaml:"metrics" env:"FEATURE_METRICS"`
+	// GdprAPI — административный HTTP API выгрузки и удаления данных пользователя
+	GdprAPI bool `yaml:"gdpr_api" env:"FEATURE_GDPR_API"`
+}
+
+// ReviewsConfig — правила модерации отзывов.
+type ReviewsConfig struct {
+	// гостевые отзывы публикуются только после одобрения модератором
+	ModerateGuestReviews bool `yaml:"moderate_guest_reviews" env:"MODERATE_GUEST_REVIEWS"`
+	// длина комментария в символах; 0 — без ограничения
+	MinCommentLength int `yaml:"min_comment_length" env:"REVIEW_MIN_COMMENT_LENGTH"`
+	MaxCommentLength int `yaml:"max_comment_length" env:"REVIEW_MAX_COMMENT_LENGTH"`
+	// отзывы, содержащие эти слова (без учёта регистра), отправляются на модерацию
+	StopWords []string `yaml:"stop_words" env:"REVIEW_STOP_WORDS"`
+}
+
+// PurgeConfig — политика хранения мягко удалённых отзывов и вопросов.
+type PurgeConfig struct {
+	// фоновая очистка в процессе сервиса; разовый запуск — командой purge
+	Enabled       bool          `yaml:"enabled" env:"PURGE_ENABLED"`
+	RetentionDays int           `yaml:"retention_days" env:"PURGE_RETENTION_DAYS"`
+	Interval      time.Duration `yaml:"interval" env:"PURGE_INTERVAL"`
+	BatchSize     int           `yaml:"batch_size" env:"PURGE_BATCH_SIZE"`
+}
+
+// Экспортёры трассировок.
+const (
+	TracingExporterNone   = "none"
+	TracingExporterStdout = "stdout"
+	TracingExporterOTLP   = "otlp"
+)
+
+// TracingConfig — трассировка OpenTelemetry.
+type TracingConfig struct {
+	// Exporter — none, stdout или otlp (OTLP/gRPC)
+	Exporter string `yaml:"exporter" env:"TRACING_EXPORTER"`
+	// Endpoint — адрес коллектора для otlp, например otel-collector:4317
+	Endpoint string `yaml:"endpoint" env:"TRACING_ENDPOINT"`
+	// Insecure — подключаться к коллектору без TLS
+	Insecure bool `yaml:"insecure" env:"TRACING_INSECURE"`
+	// SampleRatio — доля трассировок, которые начинаются в сервисе; решение родителя из входящего контекста соблюдается
+	SampleRatio float64 `yaml:"sample_ratio" env:"TRACING_SAMPLE_RATIO"`
+	ServiceName string  `yaml:"service_name" env:"TRACING_SERVICE_NAME"`
+}
+
+// ShutdownConfig — сроки остановки. Сначала /readyz начинает отвечать 503, через DrainDelay
+// перестают приниматься HTTP- и gRPC-запросы, затем дочитываются сообщения Kafka и
+// останавливаются фоновые задачи. Каждому шагу отводится свой таймаут, после него работа прерывается.
+type ShutdownConfig struct {
+	// DrainDelay — пауза, за которую балансировщик успевает увидеть, что экземпляр не готов
+	DrainDelay   time.Duration `yaml:"drain_delay" env:"SHUTDOWN_DRAIN_DELAY"`
+	HTTPTimeout  time.Duration `yaml:"http_timeout" env:"SHUTDOWN_HTTP_TIMEOUT"`
+	GRPCTimeout  time.Duration `yaml:"grpc_timeout" env:"SHUTDOWN_GRPC_TIMEOUT"`
+	KafkaTimeout time.Duration `yaml:"kafka_timeout" env:"SHUTDOWN_KAFKA_TIMEOUT"`
+	JobsTimeout  time.Duration `yaml:"jobs_timeout" env:"SHUTDOWN_JOBS_TIMEOUT"`
+}
+
+// AuthConfig — проверка JWT, которые выпускает ShopOnGO: HS256, claims user_id, role и exp.
+type AuthConfig struct {
+	// Enabled — проверять токены HTTP- и gRPC-запросов; без проверки сервис доверяет ID автора из запроса
+	Enabled bool `yaml:"enabled" env:"AUTH_ENABLED"`
+	// Secrets — ключи HMAC: токен принимается, если подписан любым из них, что позволяет менять ключ
+	// без простоя. Первым ключом подписывает команда token
+	Secrets []string `yaml:"secrets" env:"AUTH_SECRETS" secret:"true"`
+	// AdminRoles — роли модераторов: административные методы и правка чужих отзывов и вопросов
+	AdminRoles []string `yaml:"admin_roles" env:"AUTH_ADMIN_ROLES"`
+	// ServiceRoles — роли внутренних сервисов (API-шлюз), которые пишут от имени автора из запроса, в том числе гостя
+	ServiceRoles []string `yaml:"service_roles" env:"AUTH_SERVICE_ROLES"`
+	// Leeway — допустимое расхождение часов при проверке exp
+	Leeway time.Duration `yaml:"leeway" env:"AUTH_LEEWAY"`
+}
+
+// RedisConfig — общий Redis экземпляров сервиса: счётчики rate_limit и кэш с backend: redis.
+type RedisConfig struct {
+	Addr     string `yaml:"addr" env:"REDIS_ADDR"`
+	Password string `yaml:"password" env:"REDIS_PASSWORD" secret:"true"`
+	DB       int    `yaml:"db" env:"REDIS_DB"`
+}
+
+// Хранилища счётчиков ограничения частоты запросов и кэша.
+const (
+	RateLimitBackendMemory = "memory"
+	RateLimitBackendRedis  = "redis"
+
+	CacheBackendMemory = "memory"
+	CacheBackendRedis  = "redis"
+)
+
+// RateLimitConfig — ограничение частоты создания отзывов, вопросов и лайков. Лимиты считаются
+// отдельно для пользователя, гостя и IP-адреса клиента; превышение любого из них отклоняет запрос.
+type RateLimitConfig struct {
+	Enabled bool `yaml:"enabled" env:"RATE_LIMIT_ENABLED"`
+	// Backend — memory (счётчики своего процесса) или redis (общие для всех экземпляров, см. redis)
+	Backend   string        `yaml:"backend" env:"RATE_LIMIT_BACKEND"`
+	Reviews   RateLimitRule `yaml:"reviews" env:"RATE_LIMIT_REVIEWS_"`
+	Questions RateLimitRule `yaml:"questions" env:"RATE_LIMIT_QUESTIONS_"`
+	// Likes — лайки и дизлайки отзывов, лайки вопросов
+	Likes RateLimitRule `yaml:"likes" env:"RATE_LIMIT_LIKES_"`
+	// TrustedProxies — подсети (CIDR) прокси и шлюзов, чьим X-Forwarded-For / X-Real-IP можно верить
+	// при подсчёте лимита per_ip. От остальных адресов заголовки игнорируются, иначе клиент
+	// обходил бы лимит, подставляя случайный адрес. Пусто — заголовкам не верят никому.
+	TrustedProxies []string `yaml:"trusted_proxies" env:"RATE_LIMIT_TRUSTED_PROXIES"`
 }
 
-func LoadConfig() *Config {
-	err := godotenv.Load()
-	if err != nil {
-		logger.Error("Error loading .env file, using default config", err.Error())
+// TrustedProxyPrefixes разбирает TrustedProxies.
+func (c RateLimitConfig) TrustedProxyPrefixes() ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(c.TrustedProxies))
+	for _, cidr := range c.TrustedProxies {
+		prefix, err := netip.ParsePrefix(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a CIDR (use /32 for a single address)", cidr)
+		}
+		prefixes = append(prefixes, prefix.Masked())
 	}
+	return prefixes, nil
+}
+
+// RateLimitRule — сколько действий разрешено за окно Window; 0 — без ограничения.
+type RateLimitRule struct {
+	PerUser  int           `yaml:"per_user" env:"PER_USER"`
+	PerGuest int           `yaml:"per_guest" env:"PER_GUEST"`
+	PerIP    int           `yaml:"per_ip" env:"PER_IP"`
+	Window   time.Duration `yaml:"window" env:"WINDOW"`
+}
 
-	brokersRaw := os.Getenv("KAFKA_BROKERS")
-	brokers := strings.Split(brokersRaw, ",")
+// CacheConfig — кэш первых страниц отзывов товара и сводок оценок. Записи товара удаляются
+// при любом изменении его отзывов; в backend: memory другие экземпляры видят изменение
+// только по истечении TTL.
+type CacheConfig struct {
+	Enabled bool `yaml:"enabled" env:"CACHE_ENABLED"`
+	// Backend — memory (LRU в процессе) или redis (общий кэш, см. redis)
+	Backend string        `yaml:"backend" env:"CACHE_BACKEND"`
+	TTL     time.Duration `yaml:"ttl" env:"CACHE_TTL"`
+	// MaxEntries — размер LRU для backend: memory
+	MaxEntries int `yaml:"max_entries" env:"CACHE_MAX_ENTRIES"`
+	// ListingSize — сколько первых отзывов товара кэшируется для каждой сортировки;
+	// страницы дальше идут в базу
+	ListingSize int `yaml:"listing_size" env:"CACHE_LISTING_SIZE"`
+}
 
+// Default возвращает конфигурацию по умолчанию.
+func Default() *Config {
 	return &Config{
+		HTTP: HTTPConfig{
+			Addr:                      ":8080",
+			ReadHeaderTimeout:         5 * time.Second,
+			ReadTimeout:               15 * time.Second,
+			WriteTimeout:              15 * time.Second,
+			IdleTimeout:               60 * time.Second,
+			CacheMaxAge:               10 * time.Second,
+			CacheStaleWhileRevalidate: 60 * time.Second,
+		},
+		GRPC: GRPCConfig{
+			Addr:              ":50052",
+			ConnectionTimeout: 10 * time.Second,
+		},
 		Db: DbConfig{
-			Dsn: os.Getenv("DSN"),
+			MaxOpenConns:           25,
+			MaxIdleConns:           10,
+			ConnMaxLifetime:        30 * time.Minute,
+			ConnMaxIdleTime:        5 * time.Minute,
+			StatementTimeout:       30 * time.Second,
+			StatementCacheCapacity: 512,
+			ConnectTimeout:         time.Minute,
 		},
 		Kafka: KafkaConfig{
-			Brokers: brokers,
-			Topic:   os.Getenv("KAFKA_TOPIC"),
-			GroupID: os.Getenv("KAFKA_GROUP_ID"),
-			ClientID: os.Getenv("KAFKA_CLIENT_ID"),
+			HandlerTimeout: 30 * time.Second,
+		},
+		Features: FeaturesConfig{
+			GuestReviews: true,
+			Metrics:      true,
+			GdprAPI:      true,
+		},
+		Reviews: ReviewsConfig{
+			MaxCommentLength: 5000,
+		},
+		Purge: PurgeConfig{
+			RetentionDays: 90,
+			Interval:      24 * time.Hour,
+			BatchSize:     500,
+		},
+		Tracing: TracingConfig{
+			Exporter:    TracingExporterNone,
+			SampleRatio: 1,
+			ServiceName: "review-service",
+		},
+		Shutdown: ShutdownConfig{
+			HTTPTimeout:  10 * time.Second,
+			GRPCTimeout:  10 * time.Second,
+			KafkaTimeout: 30 * time.Second,
+			JobsTimeout:  10 * time.Second,
+		},
+		Auth: AuthConfig{
+			Enabled:      true,
+			AdminRoles:   []string{"admin", "moderator"},
+			ServiceRoles: []string{"service"},
+			Leeway:       30 * time.Second,
+		},
+		Redis: RedisConfig{
+			Addr: "redis:6379",
 		},
+		RateLimit: RateLimitConfig{
+			Enabled:   true,
+			Backend:   RateLimitBackendMemory,
+			Reviews:   RateLimitRule{PerUser: 10, PerGuest: 3, PerIP: 30, Window: time.Hour},
+			Questions: RateLimitRule{PerUser: 20, PerGuest: 5, PerIP: 60, Window: time.Hour},
+			Likes:     RateLimitRule{PerUser: 60, PerGuest: 20, PerIP: 300, Window: time.Minute},
+		},
+		Cache: CacheConfig{
+			Enabled:     true,
+			Backend:     CacheBackendMemory,
+			TTL:         30 * time.Second,
+			MaxEntries:  10000,
+			ListingSize: 50,
+		},
+	}
+}
+
+// Load читает конфигурацию: значения по умолчанию, затем YAML-файл path (или CONFIG_PATH,
+// или DefaultConfigPath), затем переменные окружения, включая .env. Результат проверяется Validate.
+func Load(path string) (*Config, error) {
+	if err := godotenv.Load(); err != nil && !errors.Is(err, os.ErrNotExist) {
+		logger.Errorf("Error loading .env file: %v", err)
+	}
+
+	explicit := path != ""
+	if !explicit {
+		path = os.Getenv("CONFIG_PATH")
+		explicit = path != ""
+	}
+	if !explicit {
+		path = DefaultConfigPath
+	}
+
+	conf := Default()
+	data, err := os.ReadFile(path)
+	switch {
+	case err == nil:
+		if err := yaml.Unmarshal(data, conf); err != nil {
+			return nil, fmt.Errorf("parse %s: %w", path, err)
+		}
+	case errors.Is(err, os.ErrNotExist) && !explicit:
+	default:
+		return nil, fmt.Errorf("read config: %w", err)
+	}
+
+	if err := applyEnv(conf); err != nil {
+		return nil, err
+	}
+	if err := conf.Validate(); err != nil {
+		return nil, err
 	}
+	return conf, nil
 }
@@ -0,0 +1,264 @@
+package configs
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// validConfig — конфигурация по умолчанию, которая проходит Validate.
+func validConfig() *Config {
+	conf := Default()
+	conf.Db.Dsn = "postgres://localhost/reviews"
+	return conf
+}
+
+func TestApplyEnv(t *testing.T) {
+	tests := []struct {
+		name  string
+		env   map[string]string
+		check func(t *testing.T, conf *Config)
+	}{
+		{
+			name: "scalars",
+			env:  map[string]string{"HTTP_ADDR": ":9090", "DB_MAX_OPEN_CONNS": "7", "AUTH_ENABLED": "false", "TRACING_SAMPLE_RATIO": "0.25"},
+			check: func(t *testing.T, conf *Config) {
+				if conf.HTTP.Addr != ":9090" || conf.Db.MaxOpenConns != 7 || conf.Auth.Enabled || conf.Tracing.SampleRatio != 0.25 {
+					t.Errorf("got addr=%q max_open=%d auth=%v ratio=%v", conf.HTTP.Addr, conf.Db.MaxOpenConns, conf.Auth.Enabled, conf.Tracing.SampleRatio)
+				}
+			},
+		},
+		{
+			name: "duration",
+			env:  map[string]string{"PURGE_INTERVAL": "2h"},
+			check: func(t *testing.T, conf *Config) {
+				if conf.Purge.Interval != 2*time.Hour {
+					t.Errorf("got interval %v", conf.Purge.Interval)
+				}
+			},
+		},
+		{
+			name: "duration in a nested struct",
+			env:  map[string]string{"CACHE_TTL": "2m"},
+			check: func(t *testing.T, conf *Config) {
+				if conf.Cache.TTL != 2*time.Minute {
+					t.Errorf("got ttl %v", conf.Cache.TTL)
+				}
+			},
+		},
+		{
+			name: "list drops empty items",
+			env:  map[string]string{"KAFKA_BROKERS": " a:9092, ,b:9092,"},
+			check: func(t *testing.T, conf *Config) {
+				if !slices.Equal(conf.Kafka.Brokers, []string{"a:9092", "b:9092"}) {
+					t.Errorf("got brokers %q", conf.Kafka.Brokers)
+				}
+			},
+		},
+		{
+			name: "trusted proxies",
+			env:  map[string]string{"RATE_LIMIT_TRUSTED_PROXIES": "10.0.0.0/8,192.168.0.0/16"},
+			check: func(t *testing.T, conf *Config) {
+				if !slices.Equal(conf.RateLimit.TrustedProxies, []string{"10.0.0.0/8", "192.168.0.0/16"}) {
+					t.Errorf("got trusted proxies %q", conf.RateLimit.TrustedProxies)
+				}
+			},
+		},
+		{
+			name: "nested struct prefix",
+			env:  map[string]string{"RATE_LIMIT_REVIEWS_PER_USER": "3", "RATE_LIMIT_LIKES_WINDOW": "10s"},
+			check: func(t *testing.T, conf *Config) {
+				if conf.RateLimit.Reviews.PerUser != 3 || conf.RateLimit.Likes.Window != 10*time.Second {
+					t.Errorf("got reviews.per_user=%d likes.window=%v", conf.RateLimit.Reviews.PerUser, conf.RateLimit.Likes.Window)
+				}
+				if conf.RateLimit.Questions.PerUser != Default().RateLimit.Questions.PerUser {
+					t.Errorf("questions rule changed: %+v", conf.RateLimit.Questions)
+				}
+			},
+		},
+		{
+			name: "blank value is ignored",
+			env:  map[string]string{"HTTP_ADDR": "  "},
+			check: func(t *testing.T, conf *Config) {
+				if conf.HTTP.Addr != Default().HTTP.Addr {
+					t.Errorf("got addr %q", conf.HTTP.Addr)
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+			conf := Default()
+			if err := applyEnv(conf); err != nil {
+				t.Fatal(err)
+			}
+			tt.check(t, conf)
+		})
+	}
+}
+
+func TestApplyEnvErrors(t *testing.T) {
+	t.Setenv("DB_MAX_OPEN_CONNS", "many")
+	t.Setenv("PURGE_INTERVAL", "soon")
+
+	err := applyEnv(Default())
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	for _, key := range []string{"DB_MAX_OPEN_CONNS", "PURGE_INTERVAL"} {
+		if !strings.Contains(err.Error(), key) {
+			t.Errorf("error %q does not mention %s", err, key)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	if err := validConfig().Validate(); err != nil {
+		t.Fatalf("default config is invalid: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(c *Config)
+		wantErr string
+	}{
+		{"missing dsn", func(c *Config) { c.Db.Dsn = "" }, "db.dsn"},
+		{"bad address", func(c *Config) { c.HTTP.Addr = "8080" }, "http.addr"},
+		{"idle above open", func(c *Config) { c.Db.MaxOpenConns, c.Db.MaxIdleConns = 5, 10 }, "db.max_idle_conns"},
+		{"comment bounds", func(c *Config) { c.Reviews.MinCommentLength, c.Reviews.MaxCommentLength = 100, 10 }, "reviews.min_comment_length"},
+		{"unknown exporter", func(c *Config) { c.Tracing.Exporter = "zipkin" }, "tracing.exporter"},
+		{"otlp without endpoint", func(c *Config) { c.Tracing.Exporter, c.Tracing.Endpoint = TracingExporterOTLP, "" }, "tracing.endpoint"},
+		{"rate limit window", func(c *Config) { c.RateLimit.Likes.Window = 0 }, "rate_limit.likes.window"},
+		{"trusted proxy is not a cidr", func(c *Config) { c.RateLimit.TrustedProxies = []string{"10.0.0.0/8", "10.1.2.3"} }, "rate_limit.trusted_proxies"},
+		{"trusted proxies", func(c *Config) { c.RateLimit.TrustedProxies = []string{"10.0.0.0/8", "fd00::/8"} }, ""},
+		{"redis rate limit without addr", func(c *Config) { c.RateLimit.Backend, c.Redis.Addr = RateLimitBackendRedis, "" }, "redis.addr"},
+		{"unknown cache backend", func(c *Config) { c.Cache.Backend = "memcached" }, "cache.backend"},
+		{"disabled cache is not checked", func(c *Config) { c.Cache.Enabled, c.Cache.TTL = false, 0 }, ""},
+		{"negative http cache max-age", func(c *Config) { c.HTTP.CacheMaxAge = -time.Second }, "http cache"},
+		{"negative timeout", func(c *Config) { c.HTTP.ReadTimeout = -time.Second }, "http timeouts"},
+		{"purge disabled without interval", func(c *Config) { c.Purge.Enabled, c.Purge.Interval = false, 0 }, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := validConfig()
+			tt.modify(conf)
+			err := conf.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("got %v, want error about %s", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateReportsAllErrors(t *testing.T) {
+	conf := validConfig()
+	conf.Db.Dsn = ""
+	conf.Purge.BatchSize = 0
+
+	err := conf.Validate()
+	if err == nil || !strings.Contains(err.Error(), "db.dsn") || !strings.Contains(err.Error(), "purge.batch_size") {
+		t.Fatalf("got %v, want both errors", err)
+	}
+}
+
+func TestKafkaConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(c *KafkaConfig)
+		wantErr string
+	}{
+		{"complete", func(c *KafkaConfig) {}, ""},
+		{"no brokers", func(c *KafkaConfig) { c.Brokers = nil }, "kafka.brokers"},
+		{"no topic", func(c *KafkaConfig) { c.Topic = "" }, "kafka.topic"},
+		{"no group", func(c *KafkaConfig) { c.GroupID = "" }, "kafka.group_id"},
+		{"no handler timeout", func(c *KafkaConfig) { c.HandlerTimeout = 0 }, "kafka.handler_timeout"},
+	}
+	for _, tt := range tests {
+		conf := Default().Kafka
+		conf.Brokers, conf.Topic, conf.GroupID = []string{"kafka:9092"}, "reviews", "review-service"
+		tt.modify(&conf)
+		err := conf.Validate()
+		if tt.wantErr == "" && err != nil || tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+			t.Errorf("%s: got %v, want error about %q", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func TestAuthConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		conf    AuthConfig
+		wantErr bool
+	}{
+		{"disabled without secrets", AuthConfig{}, false},
+		{"enabled with secret", AuthConfig{Enabled: true, Secrets: []string{"k"}}, false},
+		{"enabled without secrets", AuthConfig{Enabled: true}, true},
+		{"empty secret", AuthConfig{Enabled: true, Secrets: []string{"k", ""}}, true},
+	}
+	for _, tt := range tests {
+		if err := tt.conf.Validate(); (err != nil) != tt.wantErr {
+			t.Errorf("%s: got %v, want error %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func TestRedacted(t *testing.T) {
+	conf := validConfig()
+	conf.Kafka.Brokers = []string{"kafka:9092"}
+	conf.Auth.Secrets = []string{"first", "second"}
+	conf.Redis.Password = ""
+
+	redactedConf := conf.Redacted()
+	if redactedConf.Db.Dsn != redacted {
+		t.Errorf("dsn = %q", redactedConf.Db.Dsn)
+	}
+	if !slices.Equal(redactedConf.Auth.Secrets, []string{redacted, redacted}) {
+		t.Errorf("secrets = %q", redactedConf.Auth.Secrets)
+	}
+	if redactedConf.Redis.Password != "" {
+		t.Errorf("empty password should stay empty, got %q", redactedConf.Redis.Password)
+	}
+	if redactedConf.HTTP.Addr != conf.HTTP.Addr || !slices.Equal(redactedConf.Kafka.Brokers, conf.Kafka.Brokers) {
+		t.Errorf("non-secret fields changed: %q %q", redactedConf.HTTP.Addr, redactedConf.Kafka.Brokers)
+	}
+
+	if conf.Db.Dsn == redacted || conf.Auth.Secrets[0] != "first" {
+		t.Error("Redacted modified the original config")
+	}
+	if out := conf.String(); strings.Contains(out, "localhost/reviews") || strings.Contains(out, "first") {
+		t.Error("String leaks a secret")
+	}
+
+	conf.Db.Dsn = ""
+	if got := conf.Redacted().Db.Dsn; got != "" {
+		t.Errorf("empty secret should stay empty, got %q", got)
+	}
+}
+
+func TestSplitList(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want []string
+	}{
+		{"", nil},
+		{" , ", nil},
+		{"a", []string{"a"}},
+		{"a,,b", []string{"a", "b"}},
+		{" a , b ", []string{"a", "b"}},
+	}
+	for _, tt := range tests {
+		if got := SplitList(tt.raw); !slices.Equal(got, tt.want) {
+			t.Errorf("SplitList(%q) = %q, want %q", tt.raw, got, tt.want)
+		}
+	}
+}
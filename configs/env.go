@@ -0,0 +1,112 @@
+package configs
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// applyEnv перекрывает поля conf значениями переменных окружения из тега env.
+// Пустая переменная считается незаданной. Списки задаются через запятую, пустые элементы отбрасываются.
+// Тег env у поля-структуры — префикс переменных её полей: RATE_LIMIT_REVIEWS_ + PER_USER.
+func applyEnv(conf *Config) error {
+	var errs []error
+	walkEnv(reflect.ValueOf(conf).Elem(), "", func(key string, v reflect.Value) {
+		raw, ok := os.LookupEnv(key)
+		if !ok || strings.TrimSpace(raw) == "" {
+			return
+		}
+		if err := setFromString(v, strings.TrimSpace(raw)); err != nil {
+			errs = append(errs, fmt.Errorf("%s=%q: %w", key, raw, err))
+		}
+	})
+	return errors.Join(errs...)
+}
+
+// walkEnv обходит поля с тегом env, собирая имя переменной из префиксов вложенных структур.
+func walkEnv(v reflect.Value, prefix string, fn func(key string, v reflect.Value)) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field, fv := t.Field(i), v.Field(i)
+		key := field.Tag.Get("env")
+		if field.Type.Kind() == reflect.Struct {
+			walkEnv(fv, prefix+key, fn)
+			continue
+		}
+		if key != "" {
+			fn(prefix+key, fv)
+		}
+	}
+}
+
+// walkFields обходит листовые поля вложенных структур конфигурации.
+func walkFields(v reflect.Value, fn func(reflect.StructField, reflect.Value)) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field, fv := t.Field(i), v.Field(i)
+		if field.Type.Kind() == reflect.Struct {
+			walkFields(fv, fn)
+			continue
+		}
+		fn(field, fv)
+	}
+}
+
+func setFromString(v reflect.Value, raw string) error {
+	if v.Type() == durationType {
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(raw)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int:
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(n))
+	case reflect.Float64:
+		f, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	case reflect.Slice:
+		if v.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("unsupported list type %s", v.Type())
+		}
+		v.Set(reflect.ValueOf(SplitList(raw)))
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type())
+	}
+	return nil
+}
+
+// SplitList разбирает список через запятую без пустых элементов: "" → nil, "a,,b" → [a b].
+func SplitList(raw string) []string {
+	var items []string
+	for _, item := range strings.Split(raw, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,43 @@
+package configs
+
+import (
+	"reflect"
+
+	"gopkg.in/yaml.v3"
+)
+
+const redacted = "[REDACTED]"
+
+// Redacted возвращает копию конфигурации, в которой заполненные секретные поля (тег secret) скрыты.
+func (c *Config) Redacted() *Config {
+	clone := *c
+	clone.Kafka.Brokers = append([]string(nil), c.Kafka.Brokers...)
+	clone.Reviews.StopWords = append([]string(nil), c.Reviews.StopWords...)
+	clone.Auth.Secrets = append([]string(nil), c.Auth.Secrets...)
+	clone.Auth.AdminRoles = append([]string(nil), c.Auth.AdminRoles...)
+	clone.Auth.ServiceRoles = append([]string(nil), c.Auth.ServiceRoles...)
+
+	walkFields(reflect.ValueOf(&clone).Elem(), func(field reflect.StructField, v reflect.Value) {
+		if field.Tag.Get("secret") != "true" {
+			return
+		}
+		switch {
+		case v.Kind() == reflect.String && v.String() != "":
+			v.SetString(redacted)
+		case v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.String:
+			for i := 0; i < v.Len(); i++ {
+				v.Index(i).SetString(redacted)
+			}
+		}
+	})
+	return &clone
+}
+
+// String возвращает конфигурацию в YAML со скрытыми секретами — для логов и команды config.
+func (c *Config) String() string {
+	out, err := yaml.Marshal(c.Redacted())
+	if err != nil {
+		return err.Error()
+	}
+	return string(out)
+}
@@ -0,0 +1,149 @@
+package configs
+
+import (
+	"errors"
+	"fmt"
+	"net"
+)
+
+// Validate проверяет конфигурацию целиком и возвращает все найденные ошибки сразу.
+// Настройки Kafka и ключи JWT проверяются отдельно (KafkaConfig.Validate, AuthConfig.Validate) —
+// только там, где нужен консьюмер или API.
+func (c *Config) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...interface{}) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	if err := validateAddr(c.HTTP.Addr); err != nil {
+		errs = append(errs, fmt.Errorf("http.addr: %w", err))
+	}
+	if err := validateAddr(c.GRPC.Addr); err != nil {
+		errs = append(errs, fmt.Errorf("grpc.addr: %w", err))
+	}
+	check(c.HTTP.ReadHeaderTimeout >= 0 && c.HTTP.ReadTimeout >= 0 && c.HTTP.WriteTimeout >= 0 && c.HTTP.IdleTimeout >= 0,
+		"http timeouts must not be negative")
+	check(c.HTTP.CacheMaxAge >= 0 && c.HTTP.CacheStaleWhileRevalidate >= 0,
+		"http cache durations must not be negative")
+	check(c.GRPC.ConnectionTimeout >= 0, "grpc.connection_timeout must not be negative")
+
+	check(c.Db.Dsn != "", "db.dsn (DSN) is required")
+	check(c.Db.MaxOpenConns >= 0, "db.max_open_conns must not be negative")
+	check(c.Db.MaxIdleConns >= 0, "db.max_idle_conns must not be negative")
+	check(c.Db.MaxOpenConns == 0 || c.Db.MaxIdleConns <= c.Db.MaxOpenConns,
+		"db.max_idle_conns (%d) must not exceed db.max_open_conns (%d)", c.Db.MaxIdleConns, c.Db.MaxOpenConns)
+	check(c.Db.ConnMaxLifetime >= 0 && c.Db.ConnMaxIdleTime >= 0, "db connection lifetimes must not be negative")
+	check(c.Db.StatementTimeout >= 0, "db.statement_timeout must not be negative")
+	check(c.Db.StatementCacheCapacity >= 0, "db.statement_cache_capacity must not be negative")
+	check(c.Db.ConnectTimeout > 0, "db.connect_timeout must be positive")
+
+	check(c.Reviews.MinCommentLength >= 0, "reviews.min_comment_length must not be negative")
+	check(c.Reviews.MaxCommentLength >= 0, "reviews.max_comment_length must not be negative")
+	check(c.Reviews.MaxCommentLength == 0 || c.Reviews.MinCommentLength <= c.Reviews.MaxCommentLength,
+		"reviews.min_comment_length (%d) exceeds reviews.max_comment_length (%d)", c.Reviews.MinCommentLength, c.Reviews.MaxCommentLength)
+
+	check(c.Purge.RetentionDays > 0, "purge.retention_days must be positive")
+	check(c.Purge.BatchSize > 0, "purge.batch_size must be positive")
+	check(!c.Purge.Enabled || c.Purge.Interval > 0, "purge.interval must be positive when purge is enabled")
+
+	switch c.Tracing.Exporter {
+	case TracingExporterNone, TracingExporterStdout:
+	case TracingExporterOTLP:
+		check(c.Tracing.Endpoint != "", "tracing.endpoint (TRACING_ENDPOINT) is required for the otlp exporter")
+	default:
+		errs = append(errs, fmt.Errorf("tracing.exporter: unknown exporter %q", c.Tracing.Exporter))
+	}
+	check(c.Tracing.SampleRatio >= 0 && c.Tracing.SampleRatio <= 1, "tracing.sample_ratio must be between 0 and 1")
+	check(c.Tracing.Exporter == TracingExporterNone || c.Tracing.ServiceName != "", "tracing.service_name is required")
+
+	check(c.Shutdown.DrainDelay >= 0, "shutdown.drain_delay must not be negative")
+	check(c.Shutdown.HTTPTimeout > 0 && c.Shutdown.GRPCTimeout > 0 && c.Shutdown.KafkaTimeout > 0 && c.Shutdown.JobsTimeout > 0,
+		"shutdown timeouts must be positive")
+
+	check(c.Auth.Leeway >= 0, "auth.leeway must not be negative")
+
+	check(c.Redis.DB >= 0, "redis.db must not be negative")
+	switch c.RateLimit.Backend {
+	case RateLimitBackendMemory:
+	case RateLimitBackendRedis:
+		check(!c.RateLimit.Enabled || c.Redis.Addr != "", "redis.addr (REDIS_ADDR) is required for the redis rate limit backend")
+	default:
+		errs = append(errs, fmt.Errorf("rate_limit.backend: unknown backend %q", c.RateLimit.Backend))
+	}
+	for name, rule := range map[string]RateLimitRule{
+		"reviews":   c.RateLimit.Reviews,
+		"questions": c.RateLimit.Questions,
+		"likes":     c.RateLimit.Likes,
+	} {
+		check(rule.PerUser >= 0 && rule.PerGuest >= 0 && rule.PerIP >= 0, "rate_limit.%s limits must not be negative", name)
+		check(rule.Window > 0, "rate_limit.%s.window must be positive", name)
+	}
+	if _, err := c.RateLimit.TrustedProxyPrefixes(); err != nil {
+		errs = append(errs, fmt.Errorf("rate_limit.trusted_proxies: %w", err))
+	}
+
+	switch c.Cache.Backend {
+	case CacheBackendMemory:
+		check(!c.Cache.Enabled || c.Cache.MaxEntries > 0, "cache.max_entries must be positive")
+	case CacheBackendRedis:
+		check(!c.Cache.Enabled || c.Redis.Addr != "", "redis.addr (REDIS_ADDR) is required for the redis cache backend")
+	default:
+		errs = append(errs, fmt.Errorf("cache.backend: unknown backend %q", c.Cache.Backend))
+	}
+	check(!c.Cache.Enabled || c.Cache.TTL > 0, "cache.ttl must be positive")
+	check(!c.Cache.Enabled || c.Cache.ListingSize > 0, "cache.listing_size must be positive")
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid config: %w", errors.Join(errs...))
+	}
+	return nil
+}
+
+// Validate проверяет, что задано всё необходимое для подключения консьюмера.
+func (k KafkaConfig) Validate() error {
+	var errs []error
+	if len(k.Brokers) == 0 {
+		errs = append(errs, errors.New("kafka.brokers (KAFKA_BROKERS) is required"))
+	}
+	if k.Topic == "" {
+		errs = append(errs, errors.New("kafka.topic (KAFKA_TOPIC) is required"))
+	}
+	if k.GroupID == "" {
+		errs = append(errs, errors.New("kafka.group_id (KAFKA_GROUP_ID) is required"))
+	}
+	if k.HandlerTimeout <= 0 {
+		errs = append(errs, errors.New("kafka.handler_timeout must be positive"))
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid kafka config: %w", errors.Join(errs...))
+	}
+	return nil
+}
+
+// Validate проверяет, что при включённой проверке токенов задан хотя бы один непустой ключ.
+func (a AuthConfig) Validate() error {
+	if !a.Enabled {
+		return nil
+	}
+	if len(a.Secrets) == 0 {
+		return errors.New("invalid auth config: auth.secrets (AUTH_SECRETS) is required when auth is enabled")
+	}
+	for i, secret := range a.Secrets {
+		if secret == "" {
+			return fmt.Errorf("invalid auth config: auth.secrets[%d] is empty", i)
+		}
+	}
+	return nil
+}
+
+func validateAddr(addr string) error {
+	if addr == "" {
+		return errors.New("is required")
+	}
+	if _, port, err := net.SplitHostPort(addr); err != nil || port == "" {
+		return fmt.Errorf("%q is not a host:port address", addr)
+	}
+	return nil
+}
@@ -2,133 +2,158 @@ package app
 
 import (
 	"context"
-	"net"
-	"net/http"
-	"os"
-	"os/signal"
-	"sync"
-	"syscall"
+	"sync/atomic"
 	"time"
 
-	"github.com/gin-gonic/gin"
-	"github.com/segmentio/kafka-go"
-	"google.golang.org/grpc"
+	"github.com/go-redis/redis/v8"
+	grpchealth "google.golang.org/grpc/health"
 
 	"github.com/ShopOnGO/review-service/configs"
+	"github.com/ShopOnGO/review-service/internal/auth"
+	"github.com/ShopOnGO/review-service/internal/cache"
+	"github.com/ShopOnGO/review-service/internal/gdpr"
+	"github.com/ShopOnGO/review-service/internal/health"
+	"github.com/ShopOnGO/review-service/internal/lifecycle"
+	"github.com/ShopOnGO/review-service/internal/purge"
 	"github.com/ShopOnGO/review-service/internal/question"
+	"github.com/ShopOnGO/review-service/internal/ratelimit"
 	"github.com/ShopOnGO/review-service/internal/review"
 	"github.com/ShopOnGO/review-service/migrations"
 	"github.com/ShopOnGO/review-service/pkg/db"
 
-	"github.com/ShopOnGO/ShopOnGO/pkg/kafkaService"
 	"github.com/ShopOnGO/ShopOnGO/pkg/logger"
-	pb "github.com/ShopOnGO/review-proto/pkg/service"
 )
 
-var (
-	httpSrv *http.Server
+const (
+	healthCheckTimeout = 2 * time.Second
+	grpcHealthInterval = 5 * time.Second
 )
 
 type App struct {
 	conf         *configs.Config
 	reviewSvc    *review.ReviewService
 	questionSvc  *question.QuestionService
-	kafkaConsumer *kafkaService.KafkaService
+	gdprSvc      *gdpr.GdprService
+	purgeSvc     *purge.PurgeService
+	verifier     *auth.Verifier
+	limiter      *ratelimit.Limiter
+	kafkaRunning atomic.Bool
+	health       *health.Checker
+	grpcHealth   *grpchealth.Server
 }
 
-
-func InitServices() *App {
-	migrations.CheckForMigrations()
-	conf := configs.LoadConfig()
-	database := db.NewDB(conf)
-
+// InitServices собирает сервисы приложения. Kafka-консьюмер создаётся только в RunKafkaConsumer,
+// чтобы процессы без Kafka не ждали брокер при старте.
+func InitServices(conf *configs.Config, database *db.Db) *App {
 	reviewRepo := review.NewReviewRepository(database)
 	questionRepo := question.NewQuestionRepository(database)
-
-	reviewSvc := review.NewReviewService(reviewRepo)
-	questionSvc := question.NewQuestionService(questionRepo)
-
-	kafkaConsumer := kafkaService.NewConsumer(
-		conf.Kafka.Brokers,
-		conf.Kafka.Topic,
-		conf.Kafka.GroupID,
-		conf.Kafka.ClientID,
-	)
-
-	return &App{
-		conf:          conf,
-		reviewSvc:     reviewSvc,
-		questionSvc:   questionSvc,
-		kafkaConsumer: kafkaConsumer,
+	gdprRepo := gdpr.NewGdprRepository(database)
+
+	// один клиент Redis на процесс, если его использует rate_limit или cache
+	var redisClient *redis.Client
+	if (conf.RateLimit.Enabled && conf.RateLimit.Backend == configs.RateLimitBackendRedis) ||
+		(conf.Cache.Enabled && conf.Cache.Backend == configs.CacheBackendRedis) {
+		logger.Infof("Using Redis at %s", conf.Redis.Addr)
+		redisClient = redis.NewClient(&redis.Options{
+			Addr:     conf.Redis.Addr,
+			Password: conf.Redis.Password,
+			DB:       conf.Redis.DB,
+		})
 	}
-}
 
-func RunHTTPServer(app *App) {
-	router := gin.Default()
-	review.NewReviewHandler(router, app.reviewSvc)
-	question.NewQuestionHandler(router, app.questionSvc)
-
-	httpSrv = &http.Server{
-		Addr:    ":8080",
-		Handler: router,
+	var limiter *ratelimit.Limiter
+	if conf.RateLimit.Enabled {
+		limiter = ratelimit.NewLimiter(newRateLimitStore(conf.RateLimit, redisClient), conf.RateLimit)
 	}
-	logger.Info("HTTP server listening on :8080")
-	if err := httpSrv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-		logger.Infof("HTTP server error: %v\n", err)
+	var listingCache *review.ListingCache
+	if conf.Cache.Enabled {
+		listingCache = review.NewListingCache(cache.New(newCacheBackend(conf.Cache, redisClient), conf.Cache.TTL), conf.Cache.ListingSize)
 	}
-}
 
+	reviewSvc := review.NewReviewService(reviewRepo, review.ModerationRules{
+		AllowGuestReviews:    conf.Features.GuestReviews,
+		ModerateGuestReviews: conf.Reviews.ModerateGuestReviews,
+		MinCommentLength:     conf.Reviews.MinCommentLength,
+		MaxCommentLength:     conf.Reviews.MaxCommentLength,
+		StopWords:            conf.Reviews.StopWords,
+	}, limiter, listingCache)
+	questionSvc := question.NewQuestionService(questionRepo, limiter)
+	gdprSvc := gdpr.NewGdprService(gdprRepo, reviewSvc)
+	purgeSvc := purge.NewPurgeService(purge.NewPurgeRepository(database), conf.Purge)
+
+	checker := health.NewChecker(healthCheckTimeout)
+	checker.Register("database", health.DBCheck(database.DB))
+	checker.Register("schema", func(ctx context.Context) error {
+		return migrations.CheckVersion(ctx, database.DB)
+	})
 
-func RunGRPCServer(app *App, wg *sync.WaitGroup) *grpc.Server {
-	defer wg.Done()
-	listener, err := net.Listen("tcp", ":50052")
-	if err != nil {
-		logger.Infof("TCP listener error: %v\n", err)
-		return nil
+	var verifier *auth.Verifier
+	if conf.Auth.Enabled {
+		verifier = auth.NewVerifier(conf.Auth)
 	}
 
-	grpcServer := grpc.NewServer()
-	pb.RegisterReviewServiceServer(grpcServer, review.NewGrpcReviewService(app.reviewSvc))
-	pb.RegisterQuestionServiceServer(grpcServer, question.NewGrpcQuestionService(app.questionSvc))
-
-	logger.Info("gRPC server listening on :50052")
-	if err := grpcServer.Serve(listener); err != nil {
-		logger.Infof("gRPC server error: %v\n", err)
+	return &App{
+		conf:        conf,
+		reviewSvc:   reviewSvc,
+		questionSvc: questionSvc,
+		gdprSvc:     gdprSvc,
+		purgeSvc:    purgeSvc,
+		verifier:    verifier,
+		limiter:     limiter,
+		health:      checker,
+		grpcHealth:  grpchealth.NewServer(),
 	}
-	return grpcServer
 }
 
-
-func RunKafkaConsumer(ctx context.Context, app *App) {
-	defer app.kafkaConsumer.Close()
-
-	dispatcher := kafkaService.NewDispatcher()
-	dispatcher.Register("review", func(msg kafka.Message) error {
-		return review.HandleReviewEvent(msg.Value, string(msg.Key), app.reviewSvc)
-	})
-	dispatcher.Register("question", func(msg kafka.Message) error {
-		return question.HandleQuestionEvent(msg.Value, string(msg.Key), app.questionSvc)
-	})
-
-	logger.Info("Kafka consumer started")
-	app.kafkaConsumer.Consume(ctx, dispatcher.Dispatch)
+// newRateLimitStore выбирает хранилище счётчиков по rate_limit.backend.
+func newRateLimitStore(conf configs.RateLimitConfig, redisClient *redis.Client) ratelimit.Store {
+	if conf.Backend == configs.RateLimitBackendRedis {
+		return ratelimit.NewRedisStore(redisClient)
+	}
+	return ratelimit.NewMemoryStore()
 }
 
+// newCacheBackend выбирает хранилище кэша по cache.backend.
+func newCacheBackend(conf configs.CacheConfig, redisClient *redis.Client) cache.Backend {
+	if conf.Backend == configs.CacheBackendRedis {
+		return cache.NewRedis(redisClient)
+	}
+	return cache.NewLRU(conf.MaxEntries)
+}
 
-func WaitForShutdown(cancel context.CancelFunc) {
-	sigs := make(chan os.Signal, 1)
-	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
+// Components — какие части сервиса запускать в этом процессе.
+type Components struct {
+	HTTP  bool
+	GRPC  bool
+	Kafka bool
+}
 
-	<-sigs
-	logger.Info("Shutdown signal received")
-	cancel()
+// Serve запускает выбранные компоненты и фоновую очистку и блокируется до отмены ctx (сигнал
+// остановки) или падения одного из компонентов. Остановка идёт в порядке, обратном запуску:
+// HTTP и gRPC перестают принимать запросы, консьюмер дообрабатывает начатое сообщение,
+// затем останавливается очистка.
+func Serve(ctx context.Context, app *App, components Components) error {
+	shutdown := app.conf.Shutdown
+	manager := lifecycle.NewManager()
+	if app.conf.Purge.Enabled {
+		manager.Add(newPurgeJob(app), shutdown.JobsTimeout)
+	}
+	if components.Kafka {
+		manager.Add(newKafkaConsumer(app), shutdown.KafkaTimeout)
+	}
+	if components.GRPC {
+		manager.Add(newGRPCServer(app), shutdown.GRPCTimeout)
+	}
+	if components.HTTP {
+		manager.Add(newHTTPServer(app), shutdown.HTTPTimeout)
+	}
 
-	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 1*time.Second)
-	defer shutdownCancel()
-	if httpSrv != nil {
-		logger.Info("Shutting down HTTP server...")
-		if err := httpSrv.Shutdown(shutdownCtx); err != nil {
-			logger.Infof("HTTP shutdown error: %v\n", err)
+	manager.BeforeStop(func() {
+		app.health.SetShuttingDown()
+		if shutdown.DrainDelay > 0 {
+			logger.Infof("Not ready, waiting %s before stopping", shutdown.DrainDelay)
+			time.Sleep(shutdown.DrainDelay)
 		}
-	}
+	})
+	return manager.Run(ctx)
 }
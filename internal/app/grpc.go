@@ -0,0 +1,101 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"net"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc/filters"
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/ShopOnGO/review-service/internal/apperr"
+	"github.com/ShopOnGO/review-service/internal/auth"
+	"github.com/ShopOnGO/review-service/internal/health"
+	"github.com/ShopOnGO/review-service/internal/metrics"
+	"github.com/ShopOnGO/review-service/internal/question"
+	"github.com/ShopOnGO/review-service/internal/ratelimit"
+	"github.com/ShopOnGO/review-service/internal/review"
+
+	"github.com/ShopOnGO/ShopOnGO/pkg/logger"
+	pb "github.com/ShopOnGO/review-proto/pkg/service"
+)
+
+type grpcServer struct {
+	app *App
+	srv *grpc.Server
+}
+
+func newGRPCServer(app *App) *grpcServer {
+	opts := []grpc.ServerOption{
+		grpc.ConnectionTimeout(app.conf.GRPC.ConnectionTimeout),
+		grpc.StatsHandler(otelgrpc.NewServerHandler(otelgrpc.WithFilter(filters.Not(filters.HealthCheck())))),
+	}
+	if app.conf.Features.Metrics {
+		opts = append(opts,
+			grpc.ChainUnaryInterceptor(metrics.UnaryServerInterceptor()),
+			grpc.ChainStreamInterceptor(metrics.StreamServerInterceptor()),
+		)
+	}
+	// Ошибки сервисов переводятся в статусы gRPC внутри метрик, чтобы в метки попадал итоговый код.
+	opts = append(opts,
+		grpc.ChainUnaryInterceptor(apperr.UnaryServerInterceptor()),
+		grpc.ChainStreamInterceptor(apperr.StreamServerInterceptor()),
+	)
+	if app.verifier != nil {
+		opts = append(opts,
+			grpc.ChainUnaryInterceptor(auth.UnaryServerInterceptor(app.verifier)),
+			grpc.ChainStreamInterceptor(auth.StreamServerInterceptor(app.verifier)),
+		)
+	}
+	if app.limiter != nil {
+		// подсети проверены в Config.Validate
+		trustedProxies, _ := app.conf.RateLimit.TrustedProxyPrefixes()
+		opts = append(opts,
+			grpc.ChainUnaryInterceptor(ratelimit.UnaryServerInterceptor(trustedProxies)),
+			grpc.ChainStreamInterceptor(ratelimit.StreamServerInterceptor(trustedProxies)),
+		)
+	}
+	srv := grpc.NewServer(opts...)
+	pb.RegisterReviewServiceServer(srv, review.NewGrpcReviewService(app.reviewSvc))
+	pb.RegisterQuestionServiceServer(srv, question.NewGrpcQuestionService(app.questionSvc))
+	healthpb.RegisterHealthServer(srv, app.grpcHealth)
+	return &grpcServer{app: app, srv: srv}
+}
+
+func (s *grpcServer) Name() string {
+	return "gRPC server"
+}
+
+func (s *grpcServer) Run() error {
+	listener, err := net.Listen("tcp", s.app.conf.GRPC.Addr)
+	if err != nil {
+		return err
+	}
+	go health.WatchGRPC(s.app.health, s.app.grpcHealth, grpcHealthInterval,
+		pb.ReviewService_ServiceDesc.ServiceName, pb.QuestionService_ServiceDesc.ServiceName)
+
+	logger.Infof("gRPC server listening on %s", s.app.conf.GRPC.Addr)
+	if err := s.srv.Serve(listener); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+		return err
+	}
+	return nil
+}
+
+// Stop дожидается завершения текущих вызовов (GracefulStop); по истечении ctx обрывает их.
+func (s *grpcServer) Stop(ctx context.Context) error {
+	stopped := make(chan struct{})
+	go func() {
+		s.srv.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		s.srv.Stop()
+		return ctx.Err()
+	}
+}
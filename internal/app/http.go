@@ -0,0 +1,97 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+
+	"github.com/ShopOnGO/review-service/internal/auth"
+	"github.com/ShopOnGO/review-service/internal/gdpr"
+	"github.com/ShopOnGO/review-service/internal/health"
+	"github.com/ShopOnGO/review-service/internal/httpcache"
+	"github.com/ShopOnGO/review-service/internal/metrics"
+	"github.com/ShopOnGO/review-service/internal/question"
+	"github.com/ShopOnGO/review-service/internal/ratelimit"
+	"github.com/ShopOnGO/review-service/internal/review"
+
+	"github.com/ShopOnGO/ShopOnGO/pkg/logger"
+)
+
+type httpServer struct {
+	srv *http.Server
+}
+
+func newHTTPServer(app *App) *httpServer {
+	router := gin.Default()
+	// X-Forwarded-For / X-Real-IP учитываются только от доверенных прокси, иначе клиент
+	// подменял бы свой адрес для лимита per_ip
+	if err := router.SetTrustedProxies(app.conf.RateLimit.TrustedProxies); err != nil {
+		logger.Errorf("Некорректный rate_limit.trusted_proxies: %v", err)
+	}
+	router.Use(otelgin.Middleware(app.conf.Tracing.ServiceName, otelgin.WithGinFilter(tracedRoute)))
+	if app.conf.Features.Metrics {
+		router.Use(metrics.GinMiddleware())
+		router.GET("/metrics", gin.WrapH(promhttp.Handler()))
+	}
+	health.NewHealthHandler(router, app.health)
+	if app.verifier != nil {
+		router.Use(auth.GinMiddleware(app.verifier))
+	}
+	if app.limiter != nil {
+		router.Use(ratelimit.GinMiddleware())
+	}
+	cachePolicy := httpcache.Policy{
+		MaxAge:               app.conf.HTTP.CacheMaxAge,
+		StaleWhileRevalidate: app.conf.HTTP.CacheStaleWhileRevalidate,
+	}
+	review.NewReviewHandler(router, app.reviewSvc, cachePolicy)
+	question.NewQuestionHandler(router, app.questionSvc, cachePolicy)
+	if app.conf.Features.GdprAPI {
+		gdpr.NewGdprHandler(router, app.gdprSvc)
+	}
+
+	httpConf := app.conf.HTTP
+	return &httpServer{srv: &http.Server{
+		Addr:              httpConf.Addr,
+		Handler:           router,
+		ReadHeaderTimeout: httpConf.ReadHeaderTimeout,
+		ReadTimeout:       httpConf.ReadTimeout,
+		WriteTimeout:      httpConf.WriteTimeout,
+		IdleTimeout:       httpConf.IdleTimeout,
+	}}
+}
+
+// tracedRoute исключает из трассировки служебные маршруты, которые опрашиваются постоянно.
+func tracedRoute(c *gin.Context) bool {
+	switch c.FullPath() {
+	case "/metrics", "/healthz", "/readyz":
+		return false
+	}
+	return true
+}
+
+func (s *httpServer) Name() string {
+	return "HTTP server"
+}
+
+func (s *httpServer) Run() error {
+	logger.Infof("HTTP server listening on %s", s.srv.Addr)
+	if err := s.srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// Stop перестаёт принимать соединения и ждёт завершения текущих запросов; по истечении ctx
+// оставшиеся соединения закрываются.
+func (s *httpServer) Stop(ctx context.Context) error {
+	if err := s.srv.Shutdown(ctx); err != nil {
+		s.srv.Close()
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+
+	"github.com/ShopOnGO/review-service/internal/health"
+	"github.com/ShopOnGO/review-service/internal/metrics"
+	"github.com/ShopOnGO/review-service/internal/question"
+	"github.com/ShopOnGO/review-service/internal/ratelimit"
+	"github.com/ShopOnGO/review-service/internal/review"
+	"github.com/ShopOnGO/review-service/internal/tracing"
+
+	"github.com/ShopOnGO/ShopOnGO/pkg/kafkaService"
+	"github.com/ShopOnGO/ShopOnGO/pkg/logger"
+)
+
+// fetchRetryDelay — пауза после ошибки чтения, чтобы не крутить цикл при недоступном брокере.
+const fetchRetryDelay = time.Second
+
+// clientIPHeader — заголовок сообщения с IP пользователя, от имени которого шлюз публикует событие;
+// по нему считается лимит rate_limit per_ip.
+const clientIPHeader = "client-ip"
+
+// kafkaConsumer читает топик сам, а не через KafkaService.Consume: смещение фиксируется только
+// после обработки сообщения. При остановке начатое сообщение дообрабатывается и фиксируется,
+// а непрочитанные достаются следующему экземпляру в группе.
+type kafkaConsumer struct {
+	app      *App
+	handlers map[string]func(context.Context, kafka.Message) error
+
+	// fetchCtx отменяется в Stop: новые сообщения больше не читаются
+	fetchCtx  context.Context
+	stopFetch context.CancelFunc
+	// workCtx отменяется, только если Stop не дождался обработки
+	workCtx context.Context
+	abort   context.CancelFunc
+	done    chan struct{}
+}
+
+func newKafkaConsumer(app *App) *kafkaConsumer {
+	app.health.Register("kafka", health.KafkaCheck(&app.kafkaRunning, app.conf.Kafka.Brokers))
+
+	k := &kafkaConsumer{
+		app:  app,
+		done: make(chan struct{}),
+		handlers: map[string]func(context.Context, kafka.Message) error{
+			"review": func(ctx context.Context, msg kafka.Message) error {
+				return review.HandleReviewEvent(ctx, msg.Value, string(msg.Key), app.reviewSvc)
+			},
+			"question": func(ctx context.Context, msg kafka.Message) error {
+				return question.HandleQuestionEvent(ctx, msg.Value, string(msg.Key), app.questionSvc)
+			},
+		},
+	}
+	k.fetchCtx, k.stopFetch = context.WithCancel(context.Background())
+	k.workCtx, k.abort = context.WithCancel(context.Background())
+	return k
+}
+
+func (k *kafkaConsumer) Name() string {
+	return "Kafka consumer"
+}
+
+func (k *kafkaConsumer) Run() error {
+	defer close(k.done)
+
+	conf := k.app.conf.Kafka
+	consumer := kafkaService.NewConsumer(conf.Brokers, conf.Topic, conf.GroupID, conf.ClientID)
+	defer consumer.Close()
+
+	logger.Info("Kafka consumer started")
+	k.app.kafkaRunning.Store(true)
+	defer k.app.kafkaRunning.Store(false)
+
+	for {
+		msg, err := consumer.Reader.FetchMessage(k.fetchCtx)
+		if err != nil {
+			if k.fetchCtx.Err() != nil {
+				return nil
+			}
+			logger.Errorf("Kafka fetch error: %v", err)
+			select {
+			case <-k.fetchCtx.Done():
+				return nil
+			case <-time.After(fetchRetryDelay):
+			}
+			continue
+		}
+
+		// Сообщение с ошибкой обработки всё равно фиксируется: повтор не исправит некорректное
+		// событие, события сверх rate_limit отбрасываются, а расхождения агрегатов устраняет команда reconcile.
+		if err := k.handle(msg); err != nil {
+			logger.Errorf("Error handling Kafka message %s[%d]@%d: %v", msg.Topic, msg.Partition, msg.Offset, err)
+		}
+		if err := consumer.Reader.CommitMessages(k.workCtx, msg); err != nil {
+			if k.workCtx.Err() != nil {
+				return nil
+			}
+			logger.Errorf("Kafka commit error: %v", err)
+		}
+	}
+}
+
+// handle обрабатывает сообщение в спане, продолженном из его заголовков. Обработка ограничена
+// kafka.handler_timeout, чтобы зависший запрос к базе не останавливал чтение топика.
+func (k *kafkaConsumer) handle(msg kafka.Message) error {
+	metrics.ObserveKafkaLag(msg)
+	ctx, span := tracing.StartKafkaSpan(k.workCtx, msg)
+	ctx, cancel := context.WithTimeout(ctx, k.app.conf.Kafka.HandlerTimeout)
+	defer cancel()
+	for _, h := range msg.Headers {
+		if h.Key == clientIPHeader {
+			ctx = ratelimit.WithClientIP(ctx, string(h.Value))
+		}
+	}
+
+	var err error
+	if handler, ok := k.handlers[string(msg.Key)]; ok {
+		err = handler(ctx, msg)
+	} else {
+		err = fmt.Errorf("no handler for key %q", msg.Key)
+	}
+	tracing.EndSpan(span, err)
+	return err
+}
+
+// Stop прекращает чтение и ждёт, пока текущее сообщение будет обработано и зафиксировано;
+// по истечении ctx обработка прерывается.
+func (k *kafkaConsumer) Stop(ctx context.Context) error {
+	k.stopFetch()
+	select {
+	case <-k.done:
+		return nil
+	case <-ctx.Done():
+		k.abort()
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,37 @@
+package app
+
+import "context"
+
+// purgeJob периодически удаляет устаревшие мягко удалённые строки.
+type purgeJob struct {
+	app    *App
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func newPurgeJob(app *App) *purgeJob {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &purgeJob{app: app, ctx: ctx, cancel: cancel, done: make(chan struct{})}
+}
+
+func (j *purgeJob) Name() string {
+	return "purge job"
+}
+
+func (j *purgeJob) Run() error {
+	defer close(j.done)
+	j.app.purgeSvc.Start(j.ctx, j.app.conf.Purge.Interval)
+	return nil
+}
+
+// Stop прерывает текущий проход очистки: пакет удаления откатывается целиком.
+func (j *purgeJob) Stop(ctx context.Context) error {
+	j.cancel()
+	select {
+	case <-j.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
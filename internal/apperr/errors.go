@@ -0,0 +1,113 @@
+// Package apperr описывает типизированные ошибки сервисного слоя и их отображение
+// в коды ответа HTTP и gRPC.
+package apperr
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Domain — домен ошибок в google.rpc.ErrorInfo.
+const Domain = "review-service"
+
+// Kind — класс ошибки, по которому выбирается код ответа.
+type Kind int
+
+const (
+	// KindInternal — ошибка не из сервисного слоя (база, сеть); клиенту детали не отдаются.
+	KindInternal Kind = iota
+	KindNotFound
+	KindInvalidArgument
+	KindPermissionDenied
+	KindConflict
+	KindUnauthenticated
+	KindResourceExhausted
+)
+
+// Error — ошибка сервисного слоя. Текст Message показывается клиенту как есть.
+type Error struct {
+	Kind    Kind
+	Message string
+	// Reason — машиночитаемая причина в UPPER_SNAKE_CASE для google.rpc.ErrorInfo.
+	Reason string
+	// Field — поле запроса с некорректным значением (KindInvalidArgument), может быть пустым.
+	Field string
+	// Resource и ID — тип и идентификатор ненайденной сущности (KindNotFound).
+	Resource string
+	ID       string
+	// RetryAfter — через сколько можно повторить запрос (KindResourceExhausted).
+	RetryAfter time.Duration
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// NotFound — сущность resource с идентификатором id не найдена.
+func NotFound(resource string, id interface{}) *Error {
+	return &Error{
+		Kind:     KindNotFound,
+		Message:  resource + " not found",
+		Reason:   strings.ToUpper(resource) + "_NOT_FOUND",
+		Resource: resource,
+		ID:       fmt.Sprint(id),
+	}
+}
+
+// InvalidArgument — некорректное значение поля field (пустое, если виноваты несколько полей сразу).
+func InvalidArgument(field, format string, args ...interface{}) *Error {
+	return &Error{
+		Kind:    KindInvalidArgument,
+		Message: fmt.Sprintf(format, args...),
+		Reason:  "INVALID_ARGUMENT",
+		Field:   field,
+	}
+}
+
+// PermissionDenied — операция запрещена для автора запроса.
+func PermissionDenied(reason, format string, args ...interface{}) *Error {
+	return &Error{
+		Kind:    KindPermissionDenied,
+		Message: fmt.Sprintf(format, args...),
+		Reason:  reason,
+	}
+}
+
+// Unauthenticated — запрос без токена или с недействительным токеном.
+func Unauthenticated(format string, args ...interface{}) *Error {
+	return &Error{
+		Kind:    KindUnauthenticated,
+		Message: fmt.Sprintf(format, args...),
+		Reason:  "UNAUTHENTICATED",
+	}
+}
+
+// Conflict — операция несовместима с текущим состоянием сущности.
+func Conflict(reason, format string, args ...interface{}) *Error {
+	return &Error{
+		Kind:    KindConflict,
+		Message: fmt.Sprintf(format, args...),
+		Reason:  reason,
+	}
+}
+
+// ResourceExhausted — превышен лимит запросов; повторить можно через retryAfter.
+func ResourceExhausted(reason string, retryAfter time.Duration, format string, args ...interface{}) *Error {
+	return &Error{
+		Kind:       KindResourceExhausted,
+		Message:    fmt.Sprintf(format, args...),
+		Reason:     reason,
+		RetryAfter: retryAfter,
+	}
+}
+
+// KindOf возвращает класс ошибки err с учётом обёрток; для остальных ошибок — KindInternal.
+func KindOf(err error) Kind {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Kind
+	}
+	return KindInternal
+}
@@ -0,0 +1,99 @@
+package apperr
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/ShopOnGO/ShopOnGO/pkg/logger"
+)
+
+var grpcCodes = map[Kind]codes.Code{
+	KindNotFound:          codes.NotFound,
+	KindInvalidArgument:   codes.InvalidArgument,
+	KindPermissionDenied:  codes.PermissionDenied,
+	KindConflict:          codes.FailedPrecondition,
+	KindUnauthenticated:   codes.Unauthenticated,
+	KindResourceExhausted: codes.ResourceExhausted,
+}
+
+// GRPCStatus строит статус с подробностями: ErrorInfo для всех ошибок, BadRequest
+// для некорректного поля, ResourceInfo для ненайденной сущности и RetryInfo для превышения лимита.
+func (e *Error) GRPCStatus() *status.Status {
+	code, ok := grpcCodes[e.Kind]
+	if !ok {
+		code = codes.Internal
+	}
+
+	details := []protoadapt.MessageV1{
+		&errdetails.ErrorInfo{Reason: e.Reason, Domain: Domain},
+	}
+	switch {
+	case e.Kind == KindInvalidArgument && e.Field != "":
+		details = append(details, &errdetails.BadRequest{
+			FieldViolations: []*errdetails.BadRequest_FieldViolation{{Field: e.Field, Description: e.Message}},
+		})
+	case e.Kind == KindNotFound:
+		details = append(details, &errdetails.ResourceInfo{
+			ResourceType: e.Resource,
+			ResourceName: e.ID,
+			Description:  e.Message,
+		})
+	case e.Kind == KindResourceExhausted && e.RetryAfter > 0:
+		details = append(details, &errdetails.RetryInfo{RetryDelay: durationpb.New(e.RetryAfter)})
+	}
+
+	st := status.New(code, e.Message)
+	if withDetails, err := st.WithDetails(details...); err == nil {
+		return withDetails
+	}
+	return st
+}
+
+// ToStatus переводит ошибку сервиса в ошибку gRPC. Уже готовые статусы и отмена контекста
+// передаются как есть, неизвестные ошибки логируются и скрываются за codes.Internal.
+func ToStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+	var e *Error
+	if errors.As(err, &e) {
+		st := e.GRPCStatus()
+		if err != e {
+			// Обёртка дополняет текст, например ErrGuestMergeConflict с ID пользователя.
+			p := st.Proto()
+			p.Message = err.Error()
+			st = status.FromProto(p)
+		}
+		return st.Err()
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return status.FromContextError(err).Err()
+	}
+	logger.Errorf("Internal error: %v", err)
+	return status.Error(codes.Internal, "internal error")
+}
+
+// UnaryServerInterceptor применяет ToStatus к ответам unary-методов.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		resp, err := handler(ctx, req)
+		return resp, ToStatus(err)
+	}
+}
+
+// StreamServerInterceptor применяет ToStatus к ошибкам потоковых методов.
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return ToStatus(handler(srv, ss))
+	}
+}
@@ -0,0 +1,47 @@
+package apperr
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+var httpStatuses = map[Kind]int{
+	KindNotFound:          http.StatusNotFound,
+	KindInvalidArgument:   http.StatusBadRequest,
+	KindPermissionDenied:  http.StatusForbidden,
+	KindConflict:          http.StatusConflict,
+	KindUnauthenticated:   http.StatusUnauthorized,
+	KindResourceExhausted: http.StatusTooManyRequests,
+}
+
+// HTTPStatus возвращает HTTP-код ответа для ошибки; для неизвестных ошибок — 500.
+func HTTPStatus(err error) int {
+	if status, ok := httpStatuses[KindOf(err)]; ok {
+		return status
+	}
+	return http.StatusInternalServerError
+}
+
+// Respond отвечает на запрос ошибкой err. Ошибки сервиса отдаются со своим текстом
+// (и полем для KindInvalidArgument, заголовком Retry-After для KindResourceExhausted),
+// для остальных клиент получает message.
+func Respond(c *gin.Context, err error, message string) {
+	var e *Error
+	if !errors.As(err, &e) {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+		return
+	}
+
+	body := gin.H{"error": err.Error(), "reason": e.Reason}
+	if e.Field != "" {
+		body["field"] = e.Field
+	}
+	if e.RetryAfter > 0 {
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(e.RetryAfter.Seconds()))))
+	}
+	c.JSON(HTTPStatus(err), body)
+}
@@ -0,0 +1,261 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/ShopOnGO/review-service/configs"
+	"github.com/ShopOnGO/review-service/internal/apperr"
+)
+
+func sign(t *testing.T, secret string, method jwt.SigningMethod, claims jwt.MapClaims) string {
+	t.Helper()
+	token, err := jwt.NewWithClaims(method, claims).SignedString([]byte(secret))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return token
+}
+
+func TestVerifier(t *testing.T) {
+	v := NewVerifier(configs.AuthConfig{
+		Enabled:      true,
+		Secrets:      []string{"new", "old"},
+		AdminRoles:   []string{"moderator"},
+		ServiceRoles: []string{"gateway"},
+	})
+	exp := time.Now().Add(time.Hour).Unix()
+
+	tests := []struct {
+		name    string
+		token   string
+		want    Principal
+		wantErr bool
+	}{
+		{
+			name:  "user",
+			token: sign(t, "new", jwt.SigningMethodHS256, jwt.MapClaims{"user_id": 7, "role": "buyer", "exp": exp}),
+			want:  Principal{UserID: 7, Role: "buyer"},
+		},
+		{
+			name:  "previous key",
+			token: sign(t, "old", jwt.SigningMethodHS256, jwt.MapClaims{"user_id": 7, "role": "buyer", "exp": exp}),
+			want:  Principal{UserID: 7, Role: "buyer"},
+		},
+		{
+			name:  "admin role",
+			token: sign(t, "new", jwt.SigningMethodHS256, jwt.MapClaims{"user_id": 1, "role": "moderator", "exp": exp}),
+			want:  Principal{UserID: 1, Role: "moderator", Admin: true},
+		},
+		{
+			name:  "service without user",
+			token: sign(t, "new", jwt.SigningMethodHS256, jwt.MapClaims{"user_id": 0, "role": "gateway", "exp": exp}),
+			want:  Principal{Role: "gateway", Service: true},
+		},
+		{
+			name:    "unknown key",
+			token:   sign(t, "other", jwt.SigningMethodHS256, jwt.MapClaims{"user_id": 7, "role": "buyer", "exp": exp}),
+			wantErr: true,
+		},
+		{
+			name:    "other algorithm",
+			token:   sign(t, "new", jwt.SigningMethodHS512, jwt.MapClaims{"user_id": 7, "role": "buyer", "exp": exp}),
+			wantErr: true,
+		},
+		{
+			name:    "expired",
+			token:   sign(t, "new", jwt.SigningMethodHS256, jwt.MapClaims{"user_id": 7, "role": "buyer", "exp": time.Now().Add(-time.Minute).Unix()}),
+			wantErr: true,
+		},
+		{
+			name:    "without exp",
+			token:   sign(t, "new", jwt.SigningMethodHS256, jwt.MapClaims{"user_id": 7, "role": "buyer"}),
+			wantErr: true,
+		},
+		{
+			name:    "fractional user_id",
+			token:   sign(t, "new", jwt.SigningMethodHS256, jwt.MapClaims{"user_id": 1.5, "role": "buyer", "exp": exp}),
+			wantErr: true,
+		},
+		{
+			name:    "without role",
+			token:   sign(t, "new", jwt.SigningMethodHS256, jwt.MapClaims{"user_id": 7, "exp": exp}),
+			wantErr: true,
+		},
+		{
+			name:    "anonymous user role",
+			token:   sign(t, "new", jwt.SigningMethodHS256, jwt.MapClaims{"user_id": 0, "role": "buyer", "exp": exp}),
+			wantErr: true,
+		},
+		{
+			name:    "garbage",
+			token:   "not-a-token",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := v.Verify(tt.token)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("got principal %+v, want error", p)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if *p != tt.want {
+				t.Fatalf("got %+v, want %+v", *p, tt.want)
+			}
+		})
+	}
+}
+
+func TestFromHeader(t *testing.T) {
+	v := NewVerifier(configs.AuthConfig{Secrets: []string{"key"}})
+	token := sign(t, "key", jwt.SigningMethodHS256, jwt.MapClaims{"user_id": 3, "role": "buyer", "exp": time.Now().Add(time.Hour).Unix()})
+
+	tests := []struct {
+		header   string
+		wantUser uint
+		wantErr  bool
+	}{
+		{"", 0, false},
+		{"Bearer " + token, 3, false},
+		{"bearer " + token, 3, false},
+		{"Basic " + token, 0, true},
+		{"Bearer", 0, true},
+	}
+	for _, tt := range tests {
+		p, err := v.fromHeader(tt.header)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%q: got error %v, want error %v", tt.header, err, tt.wantErr)
+			continue
+		}
+		if err == nil && p.UserID != tt.wantUser {
+			t.Errorf("%q: got user %d, want %d", tt.header, p.UserID, tt.wantUser)
+		}
+	}
+}
+
+func uintPtr(v uint) *uint { return &v }
+
+func TestAuthor(t *testing.T) {
+	internal := context.Background()
+	anonymous := WithPrincipal(internal, &Principal{})
+	user := WithPrincipal(internal, &Principal{UserID: 5, Role: "buyer"})
+	admin := WithPrincipal(internal, &Principal{UserID: 1, Role: "moderator", Admin: true})
+	service := WithPrincipal(internal, &Principal{Role: "gateway", Service: true})
+
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		userID    *uint
+		guestID   []byte
+		wantUser  *uint
+		wantGuest string
+		wantKind  apperr.Kind
+		wantErr   bool
+	}{
+		{name: "internal call keeps user", ctx: internal, userID: uintPtr(9), wantUser: uintPtr(9)},
+		{name: "internal call keeps guest", ctx: internal, guestID: []byte("g"), wantGuest: "g"},
+		{name: "zero user is no user", ctx: internal, userID: uintPtr(0)},
+		{name: "service keeps guest", ctx: service, guestID: []byte("g"), wantGuest: "g"},
+		{name: "anonymous", ctx: anonymous, userID: uintPtr(5), wantErr: true, wantKind: apperr.KindUnauthenticated},
+		{name: "user without author", ctx: user, wantUser: uintPtr(5)},
+		{name: "user as self", ctx: user, userID: uintPtr(5), wantUser: uintPtr(5)},
+		{name: "user as other user", ctx: user, userID: uintPtr(6), wantErr: true, wantKind: apperr.KindPermissionDenied},
+		{name: "user as guest", ctx: user, guestID: []byte("g"), wantErr: true, wantKind: apperr.KindPermissionDenied},
+		{name: "admin for other user", ctx: admin, userID: uintPtr(6), wantUser: uintPtr(6)},
+		{name: "admin without author", ctx: admin, wantUser: uintPtr(1)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userID, guestID, err := Author(tt.ctx, tt.userID, tt.guestID)
+			if tt.wantErr {
+				if err == nil || apperr.KindOf(err) != tt.wantKind {
+					t.Fatalf("got error %v, want kind %v", err, tt.wantKind)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if (userID == nil) != (tt.wantUser == nil) || userID != nil && *userID != *tt.wantUser {
+				t.Errorf("got user %v, want %v", userID, tt.wantUser)
+			}
+			if string(guestID) != tt.wantGuest {
+				t.Errorf("got guest %q, want %q", guestID, tt.wantGuest)
+			}
+		})
+	}
+}
+
+func TestViewer(t *testing.T) {
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		claimed uint
+		want    uint
+	}{
+		{"internal call", context.Background(), 9, 9},
+		{"admin", WithPrincipal(context.Background(), &Principal{UserID: 1, Admin: true}), 9, 9},
+		{"user", WithPrincipal(context.Background(), &Principal{UserID: 5}), 9, 5},
+		{"anonymous", WithPrincipal(context.Background(), &Principal{}), 9, 0},
+	}
+	for _, tt := range tests {
+		if got := Viewer(tt.ctx, tt.claimed); got != tt.want {
+			t.Errorf("%s: got %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestRequirePrivileged(t *testing.T) {
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		wantKind apperr.Kind
+		wantErr  bool
+	}{
+		{name: "internal call", ctx: context.Background()},
+		{name: "admin", ctx: WithPrincipal(context.Background(), &Principal{UserID: 1, Admin: true})},
+		{name: "service", ctx: WithPrincipal(context.Background(), &Principal{Service: true})},
+		{name: "user", ctx: WithPrincipal(context.Background(), &Principal{UserID: 5}), wantErr: true, wantKind: apperr.KindPermissionDenied},
+		{name: "anonymous", ctx: WithPrincipal(context.Background(), &Principal{}), wantErr: true, wantKind: apperr.KindUnauthenticated},
+	}
+	for _, tt := range tests {
+		err := RequirePrivileged(tt.ctx)
+		if (err != nil) != tt.wantErr || err != nil && apperr.KindOf(err) != tt.wantKind {
+			t.Errorf("%s: got %v, want error %v of kind %v", tt.name, err, tt.wantErr, tt.wantKind)
+		}
+	}
+}
+
+func TestRequestAuthor(t *testing.T) {
+	tests := []struct {
+		name      string
+		userID    uint32
+		guestID   []byte
+		wantUser  *uint
+		wantGuest *string
+	}{
+		{name: "none"},
+		{name: "user", userID: 4, wantUser: uintPtr(4)},
+		{name: "guest", guestID: []byte("g"), wantGuest: stringPtr("g")},
+	}
+	for _, tt := range tests {
+		userID, guestID := RequestAuthor(tt.userID, tt.guestID)
+		if (userID == nil) != (tt.wantUser == nil) || userID != nil && *userID != *tt.wantUser {
+			t.Errorf("%s: got user %v, want %v", tt.name, userID, tt.wantUser)
+		}
+		if (guestID == nil) != (tt.wantGuest == nil) || guestID != nil && *guestID != *tt.wantGuest {
+			t.Errorf("%s: got guest %v, want %v", tt.name, guestID, tt.wantGuest)
+		}
+	}
+}
+
+func stringPtr(v string) *string { return &v }
@@ -0,0 +1,39 @@
+package auth
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ShopOnGO/ShopOnGO/pkg/logger"
+	"github.com/ShopOnGO/review-service/internal/apperr"
+)
+
+const bearerPrefix = "Bearer "
+
+// GinMiddleware проверяет заголовок Authorization. Запрос без токена продолжается как анонимный,
+// с недействительным токеном — отклоняется с 401.
+func GinMiddleware(v *Verifier) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		p, err := v.fromHeader(c.GetHeader("Authorization"))
+		if err != nil {
+			logger.Warnf("Rejected token for %s %s: %v", c.Request.Method, c.Request.URL.Path, err)
+			apperr.Respond(c, apperr.Unauthenticated("invalid token"), "")
+			c.Abort()
+			return
+		}
+		c.Request = c.Request.WithContext(WithPrincipal(c.Request.Context(), p))
+		c.Next()
+	}
+}
+
+// fromHeader разбирает значение Authorization; пустой заголовок — анонимный запрос.
+func (v *Verifier) fromHeader(header string) (*Principal, error) {
+	if header == "" {
+		return &Principal{}, nil
+	}
+	if len(header) < len(bearerPrefix) || !strings.EqualFold(header[:len(bearerPrefix)], bearerPrefix) {
+		return nil, apperr.Unauthenticated("authorization header must use the Bearer scheme")
+	}
+	return v.Verify(strings.TrimSpace(header[len(bearerPrefix):]))
+}
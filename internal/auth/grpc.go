@@ -0,0 +1,70 @@
+package auth
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/ShopOnGO/ShopOnGO/pkg/logger"
+	"github.com/ShopOnGO/review-service/internal/apperr"
+)
+
+// UnaryServerInterceptor проверяет метаданные authorization так же, как GinMiddleware заголовок.
+func UnaryServerInterceptor(v *Verifier) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := v.authenticate(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor делает то же для потоковых вызовов.
+func StreamServerInterceptor(v *Verifier) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := v.authenticate(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &principalStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+func (v *Verifier) authenticate(ctx context.Context, method string) (context.Context, error) {
+	var header string
+	if values := metadata.ValueFromIncomingContext(ctx, "authorization"); len(values) > 0 {
+		header = values[0]
+	}
+	p, err := v.fromHeader(header)
+	if err != nil {
+		logger.Warnf("Rejected token for %s: %v", method, err)
+		return nil, apperr.ToStatus(apperr.Unauthenticated("invalid token"))
+	}
+	return WithPrincipal(ctx, p), nil
+}
+
+// principalStream подменяет контекст потока контекстом с автором запроса.
+type principalStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *principalStream) Context() context.Context {
+	return s.ctx
+}
+
+// RequestAuthor переводит oneof author gRPC-запроса (user_id или guest_id) в автора для Author
+// и сервисов; без автора оба значения nil.
+func RequestAuthor(userID uint32, guestID []byte) (*uint, *string) {
+	switch {
+	case userID != 0:
+		id := uint(userID)
+		return &id, nil
+	case len(guestID) > 0:
+		id := string(guestID)
+		return nil, &id
+	}
+	return nil, nil
+}
@@ -0,0 +1,102 @@
+// Package auth проверяет JWT входящих запросов и передаёт автора запроса (Principal)
+// в сервисный слой через контекст.
+//
+// Контекст без Principal — внутренний вызов (консьюмер Kafka, команды CLI) или сервис
+// с выключенной проверкой токенов: таким вызовам доверяют ID автора из запроса.
+package auth
+
+import (
+	"context"
+
+	"github.com/ShopOnGO/review-service/internal/apperr"
+)
+
+// Principal — автор запроса из проверенного токена. Нулевое значение — анонимный запрос
+// без токена: ему доступно только чтение.
+type Principal struct {
+	UserID uint
+	Role   string
+	// Admin — роль модератора из auth.admin_roles
+	Admin bool
+	// Service — роль внутреннего сервиса из auth.service_roles
+	Service bool
+}
+
+// Anonymous сообщает, что запрос пришёл без токена.
+func (p *Principal) Anonymous() bool {
+	return p.UserID == 0 && !p.Admin && !p.Service
+}
+
+type principalKey struct{}
+
+// WithPrincipal кладёт автора запроса в контекст.
+func WithPrincipal(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// FromContext возвращает автора запроса; ok == false — внутренний вызов без токена.
+func FromContext(ctx context.Context) (p *Principal, ok bool) {
+	p, ok = ctx.Value(principalKey{}).(*Principal)
+	return p, ok
+}
+
+// Privileged сообщает, что вызов выполняет модератор, внутренний сервис или сам процесс
+// (Kafka, CLI) — такие вызовы не ограничены своими отзывами и вопросами.
+func Privileged(ctx context.Context) bool {
+	p, ok := FromContext(ctx)
+	return !ok || p.Admin || p.Service
+}
+
+// RequirePrivileged разрешает административные операции только привилегированным вызовам.
+func RequirePrivileged(ctx context.Context) error {
+	if Privileged(ctx) {
+		return nil
+	}
+	if p, _ := FromContext(ctx); p.Anonymous() {
+		return apperr.Unauthenticated("authentication required")
+	}
+	return apperr.PermissionDenied("ADMIN_ONLY", "operation requires a moderator role")
+}
+
+// RequireAuthenticated отклоняет анонимные запросы.
+func RequireAuthenticated(ctx context.Context) error {
+	if p, ok := FromContext(ctx); ok && p.Anonymous() {
+		return apperr.Unauthenticated("authentication required")
+	}
+	return nil
+}
+
+// Author возвращает автора записи. userID и guestID — автор из тела запроса: привилегированным
+// вызовам он передаётся как есть (модератору без автора подставляется он сам), пользователь
+// с токеном всегда пишет от своего имени и не может назваться другим пользователем или гостем.
+func Author(ctx context.Context, userID *uint, guestID []byte) (*uint, []byte, error) {
+	if userID != nil && *userID == 0 {
+		userID = nil
+	}
+
+	p, ok := FromContext(ctx)
+	switch {
+	case !ok || p.Service:
+		return userID, guestID, nil
+	case p.Anonymous():
+		return nil, nil, apperr.Unauthenticated("authentication required")
+	case p.Admin && (userID != nil || len(guestID) > 0):
+		return userID, guestID, nil
+	case len(guestID) > 0:
+		return nil, nil, apperr.PermissionDenied("AUTHOR_MISMATCH", "user %d cannot act as a guest", p.UserID)
+	case userID != nil && *userID != p.UserID:
+		return nil, nil, apperr.PermissionDenied("AUTHOR_MISMATCH", "user %d cannot act as user %d", p.UserID, *userID)
+	}
+	own := p.UserID
+	return &own, nil, nil
+}
+
+// Viewer возвращает пользователя, который смотрит список «мои отзывы/вопросы». claimedID из запроса
+// учитывается только у привилегированных вызовов, остальным подставляется ID из токена (0 — аноним).
+func Viewer(ctx context.Context, claimedID uint) uint {
+	if Privileged(ctx) {
+		return claimedID
+	}
+	p, _ := FromContext(ctx)
+	return p.UserID
+}
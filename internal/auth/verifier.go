@@ -0,0 +1,74 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/ShopOnGO/review-service/configs"
+)
+
+// Verifier проверяет токены ShopOnGO: подпись HS256 одним из ключей, срок действия и claims
+// user_id и role.
+type Verifier struct {
+	parser       *jwt.Parser
+	keys         jwt.VerificationKeySet
+	adminRoles   map[string]bool
+	serviceRoles map[string]bool
+}
+
+func NewVerifier(conf configs.AuthConfig) *Verifier {
+	v := &Verifier{
+		parser: jwt.NewParser(
+			jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+			jwt.WithExpirationRequired(),
+			jwt.WithLeeway(conf.Leeway),
+		),
+		adminRoles:   toSet(conf.AdminRoles),
+		serviceRoles: toSet(conf.ServiceRoles),
+	}
+	for _, secret := range conf.Secrets {
+		v.keys.Keys = append(v.keys.Keys, []byte(secret))
+	}
+	return v
+}
+
+// Verify проверяет токен и возвращает его автора.
+func (v *Verifier) Verify(token string) (*Principal, error) {
+	claims := jwt.MapClaims{}
+	if _, err := v.parser.ParseWithClaims(token, claims, func(*jwt.Token) (interface{}, error) {
+		return v.keys, nil
+	}); err != nil {
+		return nil, err
+	}
+
+	// JWT хранит числа как float64
+	userID, ok := claims["user_id"].(float64)
+	if !ok || userID < 0 || userID != float64(uint(userID)) {
+		return nil, errors.New("invalid token: missing or malformed user_id")
+	}
+	role, ok := claims["role"].(string)
+	if !ok || role == "" {
+		return nil, errors.New("invalid token: missing role")
+	}
+
+	p := &Principal{
+		UserID:  uint(userID),
+		Role:    role,
+		Admin:   v.adminRoles[role],
+		Service: v.serviceRoles[role],
+	}
+	if p.Anonymous() {
+		return nil, fmt.Errorf("invalid token: role %q requires a non-zero user_id", role)
+	}
+	return p, nil
+}
+
+func toSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[v] = true
+	}
+	return set
+}
@@ -0,0 +1,104 @@
+// Package cache — кэш ответов перед репозиториями: LRU в памяти процесса или Redis,
+// общий для всех экземпляров. Значения хранятся в JSON, поэтому вызывающий получает
+// свою копию и может её менять.
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/ShopOnGO/ShopOnGO/pkg/logger"
+)
+
+// Backend — хранилище кэша.
+type Backend interface {
+	// Get возвращает значения ключей в порядке keys; nil — ключа нет или срок истёк.
+	Get(ctx context.Context, keys ...string) ([][]byte, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	Delete(ctx context.Context, keys ...string) error
+}
+
+// Cache сериализует значения в JSON и хранит их в Backend ttl. Ошибки хранилища логируются
+// и считаются промахом: при недоступном Redis запросы идут в базу. Нулевой указатель
+// ничего не кэширует.
+type Cache struct {
+	backend Backend
+	ttl     time.Duration
+}
+
+func New(backend Backend, ttl time.Duration) *Cache {
+	return &Cache{backend: backend, ttl: ttl}
+}
+
+// Get раскладывает значение key в dst и сообщает, найдено ли оно.
+func (c *Cache) Get(ctx context.Context, key string, dst interface{}) bool {
+	return c.GetMany(ctx, []string{key}, func(int) interface{} { return dst })[0]
+}
+
+// GetMany читает ключи одним запросом к хранилищу: значение keys[i] раскладывается в dst(i).
+// Возвращает, какие ключи найдены.
+func (c *Cache) GetMany(ctx context.Context, keys []string, dst func(i int) interface{}) []bool {
+	found := make([]bool, len(keys))
+	if c == nil || len(keys) == 0 {
+		return found
+	}
+
+	values, err := c.backend.Get(ctx, keys...)
+	if err != nil {
+		logger.Errorf("Cache read error: %v", err)
+		for _, key := range keys {
+			requests.WithLabelValues(kind(key), "error").Inc()
+		}
+		return found
+	}
+	for i, value := range values {
+		if value != nil {
+			if err := json.Unmarshal(value, dst(i)); err != nil {
+				logger.Errorf("Cache entry %s is corrupted: %v", keys[i], err)
+			} else {
+				found[i] = true
+			}
+		}
+		if found[i] {
+			requests.WithLabelValues(kind(keys[i]), "hit").Inc()
+		} else {
+			requests.WithLabelValues(kind(keys[i]), "miss").Inc()
+		}
+	}
+	return found
+}
+
+// Set сохраняет v под ключом key.
+func (c *Cache) Set(ctx context.Context, key string, v interface{}) {
+	if c == nil {
+		return
+	}
+	value, err := json.Marshal(v)
+	if err != nil {
+		logger.Errorf("Cache entry %s is not serializable: %v", key, err)
+		return
+	}
+	if err := c.backend.Set(ctx, key, value, c.ttl); err != nil {
+		logger.Errorf("Cache write error for %s: %v", key, err)
+	}
+}
+
+// Delete удаляет ключи. Ошибка только логируется: устаревшее значение проживёт не дольше ttl.
+func (c *Cache) Delete(ctx context.Context, keys ...string) {
+	if c == nil || len(keys) == 0 {
+		return
+	}
+	if err := c.backend.Delete(ctx, keys...); err != nil {
+		logger.Errorf("Cache invalidation error for %v: %v", keys, err)
+	}
+}
+
+// kind — метка метрик: префикс ключа до первого двоеточия (reviews, rating).
+func kind(key string) string {
+	if i := strings.IndexByte(key, ':'); i >= 0 {
+		return key[:i]
+	}
+	return key
+}
@@ -0,0 +1,168 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestLRU(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		run  func(c *LRU)
+		// want — ожидаемые значения ключей a, b, c; "" — ключа нет
+		want [3]string
+	}{
+		{
+			name: "set and get",
+			run: func(c *LRU) {
+				c.Set(ctx, "a", []byte("1"), time.Minute)
+				c.Set(ctx, "b", []byte("2"), time.Minute)
+			},
+			want: [3]string{"1", "2", ""},
+		},
+		{
+			name: "overwrite",
+			run: func(c *LRU) {
+				c.Set(ctx, "a", []byte("1"), time.Minute)
+				c.Set(ctx, "a", []byte("new"), time.Minute)
+			},
+			want: [3]string{"new", "", ""},
+		},
+		{
+			name: "evicts least recently read",
+			run: func(c *LRU) {
+				c.Set(ctx, "a", []byte("1"), time.Minute)
+				c.Set(ctx, "b", []byte("2"), time.Minute)
+				c.Get(ctx, "a")
+				c.Set(ctx, "c", []byte("3"), time.Minute)
+			},
+			want: [3]string{"1", "", "3"},
+		},
+		{
+			name: "expired entry",
+			run: func(c *LRU) {
+				c.Set(ctx, "a", []byte("1"), time.Millisecond)
+				c.Set(ctx, "b", []byte("2"), time.Minute)
+				time.Sleep(5 * time.Millisecond)
+			},
+			want: [3]string{"", "2", ""},
+		},
+		{
+			name: "delete",
+			run: func(c *LRU) {
+				c.Set(ctx, "a", []byte("1"), time.Minute)
+				c.Set(ctx, "b", []byte("2"), time.Minute)
+				c.Delete(ctx, "a", "missing")
+			},
+			want: [3]string{"", "2", ""},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewLRU(2)
+			tt.run(c)
+			values, err := c.Get(ctx, "a", "b", "c")
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i, value := range values {
+				if string(value) != tt.want[i] || (value == nil) != (tt.want[i] == "") {
+					t.Errorf("key %d: got %q, want %q", i, value, tt.want[i])
+				}
+			}
+			if len(c.entries) != c.order.Len() || len(c.entries) > c.maxEntries {
+				t.Errorf("index has %d entries, list %d", len(c.entries), c.order.Len())
+			}
+		})
+	}
+}
+
+// failingBackend имитирует недоступный Redis.
+type failingBackend struct{}
+
+func (failingBackend) Get(context.Context, ...string) ([][]byte, error) {
+	return nil, errors.New("connection refused")
+}
+
+func (failingBackend) Set(context.Context, string, []byte, time.Duration) error {
+	return errors.New("connection refused")
+}
+
+func (failingBackend) Delete(context.Context, ...string) error {
+	return errors.New("connection refused")
+}
+
+type entry struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+func TestCache(t *testing.T) {
+	ctx := context.Background()
+	c := New(NewLRU(10), time.Minute)
+
+	c.Set(ctx, "entries:1", entry{Name: "one", Count: 1})
+	c.Set(ctx, "entries:2", entry{Name: "two", Count: 2})
+
+	var got entry
+	if found := c.Get(ctx, "entries:1", &got); !found || got != (entry{Name: "one", Count: 1}) {
+		t.Fatalf("get: found=%v value=%+v", found, got)
+	}
+
+	values := make([]entry, 3)
+	found := c.GetMany(ctx, []string{"entries:2", "entries:3", "entries:1"}, func(i int) interface{} { return &values[i] })
+	if !found[0] || found[1] || !found[2] || values[0].Name != "two" || values[2].Name != "one" {
+		t.Fatalf("get many: found=%v values=%+v", found, values)
+	}
+
+	c.Delete(ctx, "entries:1")
+	if c.Get(ctx, "entries:1", &got) {
+		t.Fatal("deleted entry is still cached")
+	}
+}
+
+func TestCacheMisses(t *testing.T) {
+	ctx := context.Background()
+	corrupted := NewLRU(1)
+	corrupted.Set(ctx, "entries:1", []byte("{not json"), time.Minute)
+
+	tests := []struct {
+		name  string
+		cache *Cache
+		// set — записать значение через Cache перед чтением
+		set bool
+	}{
+		{"nil cache", nil, true},
+		{"backend unavailable", New(failingBackend{}, time.Minute), true},
+		{"corrupted entry", New(corrupted, time.Minute), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.set {
+				tt.cache.Set(ctx, "entries:1", entry{Name: "one"})
+			}
+			var got entry
+			if tt.cache.Get(ctx, "entries:1", &got) {
+				t.Fatalf("got a hit: %+v", got)
+			}
+			tt.cache.Delete(ctx, "entries:1")
+		})
+	}
+}
+
+func TestKind(t *testing.T) {
+	tests := map[string]string{
+		"reviews:1:newest": "reviews",
+		"rating:7":         "rating",
+		"plain":            "plain",
+	}
+	for key, want := range tests {
+		if got := kind(key); got != want {
+			t.Errorf("kind(%q) = %q, want %q", key, got, want)
+		}
+	}
+}
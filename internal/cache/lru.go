@@ -0,0 +1,89 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+// LRU — кэш в памяти процесса на maxEntries записей: при переполнении вытесняется запись,
+// которую дольше всех не читали. Записи с истёкшим сроком удаляются при чтении.
+type LRU struct {
+	mu         sync.Mutex
+	maxEntries int
+	order      *list.List // начало — последние прочитанные
+	entries    map[string]*list.Element
+}
+
+type lruEntry struct {
+	key       string
+	value     []byte
+	expiresAt time.Time
+}
+
+func NewLRU(maxEntries int) *LRU {
+	return &LRU{
+		maxEntries: maxEntries,
+		order:      list.New(),
+		entries:    make(map[string]*list.Element),
+	}
+}
+
+func (c *LRU) Get(_ context.Context, keys ...string) ([][]byte, error) {
+	now := time.Now()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	values := make([][]byte, len(keys))
+	for i, key := range keys {
+		el, ok := c.entries[key]
+		if !ok {
+			continue
+		}
+		entry := el.Value.(*lruEntry)
+		if !now.Before(entry.expiresAt) {
+			c.remove(el)
+			continue
+		}
+		c.order.MoveToFront(el)
+		values[i] = entry.value
+	}
+	return values, nil
+}
+
+func (c *LRU) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.entries[key]; ok {
+		entry := el.Value.(*lruEntry)
+		entry.value = value
+		entry.expiresAt = time.Now().Add(ttl)
+		c.order.MoveToFront(el)
+		return nil
+	}
+
+	c.entries[key] = c.order.PushFront(&lruEntry{key: key, value: value, expiresAt: time.Now().Add(ttl)})
+	for c.order.Len() > c.maxEntries {
+		c.remove(c.order.Back())
+	}
+	return nil
+}
+
+func (c *LRU) Delete(_ context.Context, keys ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, key := range keys {
+		if el, ok := c.entries[key]; ok {
+			c.remove(el)
+		}
+	}
+	return nil
+}
+
+func (c *LRU) remove(el *list.Element) {
+	c.order.Remove(el)
+	delete(c.entries, el.Value.(*lruEntry).key)
+}
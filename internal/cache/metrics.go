@@ -0,0 +1,11 @@
+package cache
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var requests = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "review_service_cache_requests_total",
+	Help: "Обращения к кэшу по виду данных (reviews, rating) и результату (hit, miss, error).",
+}, []string{"kind", "result"})
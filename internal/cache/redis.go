@@ -0,0 +1,51 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// keyPrefix отделяет ключи сервиса от остальных данных общего Redis ShopOnGO.
+const keyPrefix = "review-service:cache:"
+
+// Redis — кэш, общий для всех экземпляров сервиса: инвалидация на одном экземпляре
+// видна остальным.
+type Redis struct {
+	client redis.UniversalClient
+}
+
+func NewRedis(client redis.UniversalClient) *Redis {
+	return &Redis{client: client}
+}
+
+func (r *Redis) Get(ctx context.Context, keys ...string) ([][]byte, error) {
+	res, err := r.client.MGet(ctx, prefixed(keys)...).Result()
+	if err != nil {
+		return nil, err
+	}
+	values := make([][]byte, len(res))
+	for i, v := range res {
+		if s, ok := v.(string); ok {
+			values[i] = []byte(s)
+		}
+	}
+	return values, nil
+}
+
+func (r *Redis) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return r.client.Set(ctx, keyPrefix+key, value, ttl).Err()
+}
+
+func (r *Redis) Delete(ctx context.Context, keys ...string) error {
+	return r.client.Del(ctx, prefixed(keys)...).Err()
+}
+
+func prefixed(keys []string) []string {
+	out := make([]string, len(keys))
+	for i, key := range keys {
+		out[i] = keyPrefix + key
+	}
+	return out
+}
@@ -0,0 +1,96 @@
+package gdpr
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/ShopOnGO/review-service/internal/apperr"
+	"github.com/gin-gonic/gin"
+)
+
+type GdprHandler struct {
+	gdprSvc *GdprService
+}
+
+func NewGdprHandler(router *gin.Engine, gdprSvc *GdprService) *GdprHandler {
+	handler := &GdprHandler{gdprSvc: gdprSvc}
+
+	adminGroup := router.Group("/reviews-service/admin/gdpr")
+	{
+		adminGroup.GET("/export", handler.exportData)
+		adminGroup.POST("/erase", handler.eraseData)
+	}
+
+	return handler
+}
+
+// exportData godoc
+// @Summary Выгрузка данных пользователя
+// @Description Возвращает все отзывы, вопросы, голоса и лайки пользователя или гостя в JSON, включая удалённые
+// @Tags Администрирование
+// @Security BearerAuth
+// @Param user_id query int false "ID пользователя"
+// @Param guest_id query string false "ID гостя"
+// @Success 200 {object} gdpr.Export
+// @Failure 400 {object} gin.H "Некорректный субъект"
+// @Failure 401 {object} gin.H "Нужен токен"
+// @Failure 403 {object} gin.H "Нет доступа к данным субъекта"
+// @Failure 500 {object} gin.H "Ошибка выгрузки данных"
+// @Router /reviews-service/admin/gdpr/export [get]
+func (h *GdprHandler) exportData(c *gin.Context) {
+	subject, ok := subjectFromQuery(c)
+	if !ok {
+		return
+	}
+
+	export, err := h.gdprSvc.Export(c.Request.Context(), subject)
+	if err != nil {
+		apperr.Respond(c, err, "Ошибка выгрузки данных")
+		return
+	}
+
+	c.JSON(http.StatusOK, export)
+}
+
+// eraseData godoc
+// @Summary Удаление данных пользователя
+// @Description Обезличивает (mode=anonymize) или физически удаляет (mode=delete) данные пользователя или гостя с пересчётом рейтингов и лайков
+// @Tags Администрирование
+// @Security BearerAuth
+// @Param user_id query int false "ID пользователя"
+// @Param guest_id query string false "ID гостя"
+// @Param mode query string true "anonymize или delete"
+// @Success 200 {object} gdpr.ErasureResult
+// @Failure 400 {object} gin.H "Некорректный запрос"
+// @Failure 401 {object} gin.H "Нужен токен"
+// @Failure 403 {object} gin.H "Нет доступа к данным субъекта"
+// @Failure 500 {object} gin.H "Ошибка удаления данных"
+// @Router /reviews-service/admin/gdpr/erase [post]
+func (h *GdprHandler) eraseData(c *gin.Context) {
+	subject, ok := subjectFromQuery(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.gdprSvc.Erase(c.Request.Context(), subject, c.Query("mode"))
+	if err != nil {
+		apperr.Respond(c, err, "Ошибка удаления данных")
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func subjectFromQuery(c *gin.Context) (Subject, bool) {
+	var subject Subject
+	if raw := c.Query("user_id"); raw != "" {
+		userID, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Некорректный ID пользователя"})
+			return subject, false
+		}
+		subject.UserID = uint(userID)
+	}
+	subject.GuestID = c.Query("guest_id")
+	return subject, true
+}
@@ -0,0 +1,46 @@
+package gdpr
+
+import (
+	"time"
+
+	"github.com/ShopOnGO/review-service/internal/question"
+	"github.com/ShopOnGO/review-service/internal/review"
+)
+
+// Режимы удаления данных субъекта.
+const (
+	// ModeAnonymize отвязывает отзывы и вопросы от автора, оставляя их текст и оценки,
+	// и удаляет персональные голоса и лайки без изменения счётчиков.
+	ModeAnonymize = "anonymize"
+	// ModeDelete физически удаляет отзывы, вопросы, голоса и лайки субъекта,
+	// пересчитывая агрегаты рейтинга товаров и счётчики лайков.
+	ModeDelete = "delete"
+)
+
+// Subject — владелец данных: зарегистрированный пользователь или гость. Задаётся ровно одно поле.
+type Subject struct {
+	UserID  uint   `json:"user_id,omitempty"`
+	GuestID string `json:"guest_id,omitempty"`
+}
+
+// Export — все данные субъекта, хранящиеся в сервисе отзывов, включая мягко удалённые.
+type Export struct {
+	Subject       Subject                 `json:"subject"`
+	ExportedAt    time.Time               `json:"exported_at"`
+	Reviews       []review.Review         `json:"reviews"`
+	ReviewVotes   []review.ReviewVote     `json:"review_votes"`
+	Questions     []question.Question     `json:"questions"`
+	QuestionLikes []question.QuestionLike `json:"question_likes"`
+	GuestMerges   []question.GuestMerge   `json:"guest_merges"`
+}
+
+type ErasureResult struct {
+	Subject              Subject `json:"subject"`
+	Mode                 string  `json:"mode"`
+	Reviews              int64   `json:"reviews"`
+	Questions            int64   `json:"questions"`
+	ReviewVotesRemoved   int64   `json:"review_votes_removed"`
+	QuestionLikesRemoved int64   `json:"question_likes_removed"`
+	// товары, чьи отзывы или счётчики изменились
+	ProductIDs []uint `json:"product_ids"`
+}
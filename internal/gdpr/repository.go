@@ -0,0 +1,226 @@
+package gdpr
+
+import (
+	"context"
+	"github.com/ShopOnGO/review-service/internal/question"
+	"github.com/ShopOnGO/review-service/internal/review"
+	"github.com/ShopOnGO/review-service/pkg/db"
+	"gorm.io/gorm"
+)
+
+type GdprRepository struct {
+	Db *db.Db
+}
+
+func NewGdprRepository(db *db.Db) *GdprRepository {
+	return &GdprRepository{
+		Db: db,
+	}
+}
+
+func (r *GdprRepository) Export(ctx context.Context, subject Subject) (*Export, error) {
+	export := &Export{Subject: subject}
+
+	if err := authorScope(r.Db.WithContext(ctx).Unscoped(), subject).Order("id").Find(&export.Reviews).Error; err != nil {
+		return nil, err
+	}
+	if err := authorScope(r.Db.WithContext(ctx).Unscoped(), subject).Order("id").Find(&export.Questions).Error; err != nil {
+		return nil, err
+	}
+	if err := authorScope(r.Db.WithContext(ctx), subject).Order("id").Find(&export.QuestionLikes).Error; err != nil {
+		return nil, err
+	}
+	if err := authorScope(r.Db.WithContext(ctx), subject).Order("id").Find(&export.GuestMerges).Error; err != nil {
+		return nil, err
+	}
+	if subject.UserID != 0 {
+		if err := r.Db.WithContext(ctx).Where("user_id = ?", subject.UserID).Order("id").Find(&export.ReviewVotes).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	return export, nil
+}
+
+// Erase удаляет или обезличивает данные субъекта в одной транзакции.
+func (r *GdprRepository) Erase(ctx context.Context, subject Subject, mode string) (*ErasureResult, error) {
+	result := &ErasureResult{Subject: subject, Mode: mode}
+	products := make(map[uint]bool)
+
+	err := r.Db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if subject.UserID != 0 {
+			votedProducts, removed, err := eraseReviewVotes(tx, subject.UserID, mode)
+			if err != nil {
+				return err
+			}
+			for _, id := range votedProducts {
+				products[id] = true
+			}
+			result.ReviewVotesRemoved = removed
+		}
+
+		var reviews []review.Review
+		if err := authorScope(tx.Unscoped(), subject).Find(&reviews).Error; err != nil {
+			return err
+		}
+		result.Reviews = int64(len(reviews))
+		if err := eraseReviews(tx, reviews, mode); err != nil {
+			return err
+		}
+		for _, rv := range reviews {
+			products[rv.ProductID] = true
+		}
+
+		removed, err := eraseQuestionLikes(tx, subject, mode)
+		if err != nil {
+			return err
+		}
+		result.QuestionLikesRemoved = removed
+
+		var questionIDs []uint
+		if err := authorScope(tx.Unscoped().Model(&question.Question{}), subject).Pluck("id", &questionIDs).Error; err != nil {
+			return err
+		}
+		result.Questions = int64(len(questionIDs))
+		if err := eraseQuestions(tx, questionIDs, mode); err != nil {
+			return err
+		}
+
+		return authorScope(tx, subject).Delete(&question.GuestMerge{}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for id := range products {
+		result.ProductIDs = append(result.ProductIDs, id)
+	}
+	return result, nil
+}
+
+// eraseReviewVotes удаляет голоса пользователя за отзывы. В режиме delete счётчики
+// отзывов уменьшаются, в режиме anonymize голоса остаются в счётчиках обезличенными.
+func eraseReviewVotes(tx *gorm.DB, userID uint, mode string) ([]uint, int64, error) {
+	var productIDs []uint
+	err := tx.Model(&review.Review{}).Unscoped().
+		Where("id IN (?)", tx.Model(&review.ReviewVote{}).Select("review_id").Where("user_id = ?", userID)).
+		Distinct().
+		Pluck("product_id", &productIDs).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if mode == ModeDelete {
+		err := tx.Exec(`
+			UPDATE reviews r
+			SET likes_count    = GREATEST(r.likes_count - v.likes, 0),
+				dislikes_count = GREATEST(r.dislikes_count - v.dislikes, 0)
+			FROM (
+				SELECT review_id,
+					COUNT(*) FILTER (WHERE helpful)     AS likes,
+					COUNT(*) FILTER (WHERE NOT helpful) AS dislikes
+				FROM review_votes
+				WHERE user_id = ?
+				GROUP BY review_id
+			) v
+			WHERE r.id = v.review_id`, userID).Error
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+
+	res := tx.Where("user_id = ?", userID).Delete(&review.ReviewVote{})
+	return productIDs, res.RowsAffected, res.Error
+}
+
+// eraseReviews обезличивает или удаляет отзывы. При удалении опубликованные отзывы
+// вычитаются из агрегатов рейтинга товаров, вместе с отзывами удаляются чужие голоса за них
+// и выделения.
+func eraseReviews(tx *gorm.DB, reviews []review.Review, mode string) error {
+	if len(reviews) == 0 {
+		return nil
+	}
+	ids := make([]uint, 0, len(reviews))
+	var rated []uint
+	for _, rv := range reviews {
+		ids = append(ids, rv.ID)
+		if rv.Status == review.StatusPublished && !rv.DeletedAt.Valid {
+			rated = append(rated, rv.ID)
+		}
+	}
+
+	if mode == ModeAnonymize {
+		return tx.Unscoped().Model(&review.Review{}).
+			Where("id IN ?", ids).
+			Updates(map[string]interface{}{"user_id": nil, "guest_id": nil}).Error
+	}
+
+	if len(rated) > 0 {
+		err := tx.Exec(`
+			UPDATE products p
+			SET review_count = p.review_count - d.cnt,
+				rating_sum   = p.rating_sum   - d.total,
+				rating = CASE
+					WHEN p.review_count - d.cnt > 0
+						THEN (p.rating_sum - d.total)::numeric / (p.review_count - d.cnt)
+					ELSE 0
+				END
+			FROM (
+				SELECT product_id, COUNT(*) AS cnt, SUM(rating) AS total
+				FROM reviews
+				WHERE id IN ?
+				GROUP BY product_id
+			) d
+			WHERE p.id = d.product_id`, rated).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Where("review_id IN ?", ids).Delete(&review.ReviewVote{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("review_id IN ?", ids).Delete(&review.ReviewHighlight{}).Error; err != nil {
+		return err
+	}
+	return tx.Unscoped().Where("id IN ?", ids).Delete(&review.Review{}).Error
+}
+
+func eraseQuestionLikes(tx *gorm.DB, subject Subject, mode string) (int64, error) {
+	if mode == ModeDelete {
+		likes := authorScope(tx.Model(&question.QuestionLike{}), subject).Select("question_id")
+		err := tx.Unscoped().Model(&question.Question{}).
+			Where("id IN (?)", likes).
+			Update("likes_count", gorm.Expr("GREATEST(likes_count - 1, 0)")).Error
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	res := authorScope(tx, subject).Delete(&question.QuestionLike{})
+	return res.RowsAffected, res.Error
+}
+
+func eraseQuestions(tx *gorm.DB, ids []uint, mode string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	if mode == ModeAnonymize {
+		return tx.Unscoped().Model(&question.Question{}).
+			Where("id IN ?", ids).
+			Updates(map[string]interface{}{"user_id": nil, "guest_id": nil}).Error
+	}
+
+	if err := tx.Where("question_id IN ?", ids).Delete(&question.QuestionLike{}).Error; err != nil {
+		return err
+	}
+	return tx.Unscoped().Where("id IN ?", ids).Delete(&question.Question{}).Error
+}
+
+func authorScope(tx *gorm.DB, subject Subject) *gorm.DB {
+	if subject.UserID != 0 {
+		return tx.Where("user_id = ?", subject.UserID)
+	}
+	return tx.Where("guest_id = ?", []byte(subject.GuestID))
+}
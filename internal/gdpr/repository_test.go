@@ -0,0 +1,211 @@
+package gdpr
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"github.com/ShopOnGO/review-service/migrations"
+	"github.com/ShopOnGO/review-service/pkg/db"
+)
+
+// testDB открывает базу из REVIEW_TEST_DSN в отдельной схеме с применёнными миграциями
+// и таблицей products, которую в проде ведёт сервис товаров. Схема удаляется после теста;
+// без REVIEW_TEST_DSN тесты базы пропускаются.
+func testDB(t *testing.T) *db.Db {
+	t.Helper()
+	dsn := os.Getenv("REVIEW_TEST_DSN")
+	if dsn == "" {
+		t.Skip("REVIEW_TEST_DSN is not set")
+	}
+	gormDB, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open test database: %v", err)
+	}
+	sqlDB, err := gormDB.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// одно соединение, чтобы search_path действовал на все запросы теста
+	sqlDB.SetMaxOpenConns(1)
+
+	schema := fmt.Sprintf("gdpr_test_%d", time.Now().UnixNano())
+	mustExec(t, gormDB, "CREATE SCHEMA "+schema)
+	t.Cleanup(func() {
+		gormDB.Exec("DROP SCHEMA " + schema + " CASCADE")
+		sqlDB.Close()
+	})
+	mustExec(t, gormDB, "SET search_path TO "+schema)
+
+	ctx := context.Background()
+	migrator, err := migrations.NewMigrator(ctx, gormDB)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := migrator.Up(ctx); err != nil {
+		t.Fatal(err)
+	}
+	mustExec(t, gormDB, `CREATE TABLE products (
+		id bigint PRIMARY KEY,
+		review_count bigint NOT NULL DEFAULT 0,
+		rating_sum bigint NOT NULL DEFAULT 0,
+		rating numeric NOT NULL DEFAULT 0)`)
+	return &db.Db{DB: gormDB}
+}
+
+func mustExec(t *testing.T, gormDB *gorm.DB, sql string, args ...interface{}) {
+	t.Helper()
+	if err := gormDB.Exec(sql, args...).Error; err != nil {
+		t.Fatalf("%s: %v", sql, err)
+	}
+}
+
+// Данные теста: пользователь subjectUserID и двое других, 8 и 9.
+//
+//	товар 1: отзыв 1 субъекта (5★, опубликован), отзыв 2 пользователя 8 (3★)
+//	товар 2: отзыв 3 субъекта (на модерации), отзыв 4 пользователя 8 (4★)
+//	голоса: субъект — «полезно» отзыву 2 и «бесполезно» отзыву 4, 9 — «полезно» отзыву 2,
+//	        8 — «полезно» отзыву 1 субъекта
+//	вопросы: 1 субъекта (лайк от 8), 2 пользователя 8 (лайки субъекта и 9)
+//
+// Счётчики и агрегаты товаров согласованы с этими строками.
+const subjectUserID = 7
+
+func seed(t *testing.T, database *db.Db) {
+	t.Helper()
+	for _, sql := range []string{
+		`INSERT INTO products (id, review_count, rating_sum, rating) VALUES (1, 2, 8, 4), (2, 1, 4, 4)`,
+		`INSERT INTO reviews (id, user_id, product_id, rating, comment, status, likes_count, dislikes_count) VALUES
+			(1, 7, 1, 5, 'own', 'published', 1, 0),
+			(2, 8, 1, 3, 'other', 'published', 2, 0),
+			(3, 7, 2, 2, 'own pending', 'pending', 0, 0),
+			(4, 8, 2, 4, 'other', 'published', 0, 1)`,
+		`INSERT INTO review_votes (review_id, user_id, helpful) VALUES (2, 7, true), (4, 7, false), (2, 9, true), (1, 8, true)`,
+		`INSERT INTO questions (id, user_id, product_id, question_text, likes_count) VALUES (1, 7, 1, 'own', 1), (2, 8, 1, 'other', 2)`,
+		`INSERT INTO question_likes (question_id, user_id) VALUES (1, 8), (2, 7), (2, 9)`,
+	} {
+		mustExec(t, database.DB, sql)
+	}
+}
+
+type product struct {
+	ID          uint
+	ReviewCount int64
+	RatingSum   int64
+}
+
+type counters struct {
+	ID            uint
+	LikesCount    int64
+	DislikesCount int64
+}
+
+func TestErase(t *testing.T) {
+	tests := []struct {
+		name     string
+		mode     string
+		products []product
+		// reviews — счётчики оставшихся отзывов по id
+		reviews []counters
+		// questions — likes_count оставшихся вопросов по id
+		questions       map[uint]int64
+		votesLeft       int64
+		questionLikes   int64
+		ownReviewsLeft  int64
+		ownQuestionLeft int64
+	}{
+		{
+			name:            "delete",
+			mode:            ModeDelete,
+			products:        []product{{1, 1, 3}, {2, 1, 4}},
+			reviews:         []counters{{2, 1, 0}, {4, 0, 0}},
+			questions:       map[uint]int64{2: 1},
+			votesLeft:       1,
+			questionLikes:   1,
+			ownReviewsLeft:  0,
+			ownQuestionLeft: 0,
+		},
+		{
+			name:            "anonymize",
+			mode:            ModeAnonymize,
+			products:        []product{{1, 2, 8}, {2, 1, 4}},
+			reviews:         []counters{{1, 1, 0}, {2, 2, 0}, {3, 0, 0}, {4, 0, 1}},
+			questions:       map[uint]int64{1: 1, 2: 2},
+			votesLeft:       2,
+			questionLikes:   2,
+			ownReviewsLeft:  2,
+			ownQuestionLeft: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			database := testDB(t)
+			seed(t, database)
+
+			result, err := NewGdprRepository(database).Erase(context.Background(), Subject{UserID: subjectUserID}, tt.mode)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result.Reviews != 2 || result.Questions != 1 || result.ReviewVotesRemoved != 2 || result.QuestionLikesRemoved != 1 {
+				t.Errorf("result = %+v", result)
+			}
+
+			var products []product
+			if err := database.Raw("SELECT id, review_count, rating_sum FROM products ORDER BY id").Scan(&products).Error; err != nil {
+				t.Fatal(err)
+			}
+			if fmt.Sprint(products) != fmt.Sprint(tt.products) {
+				t.Errorf("products = %v, want %v", products, tt.products)
+			}
+
+			var reviews []counters
+			if err := database.Raw("SELECT id, likes_count, dislikes_count FROM reviews ORDER BY id").Scan(&reviews).Error; err != nil {
+				t.Fatal(err)
+			}
+			if fmt.Sprint(reviews) != fmt.Sprint(tt.reviews) {
+				t.Errorf("review counters = %v, want %v", reviews, tt.reviews)
+			}
+
+			var questions []counters
+			if err := database.Raw("SELECT id, likes_count FROM questions ORDER BY id").Scan(&questions).Error; err != nil {
+				t.Fatal(err)
+			}
+			if len(questions) != len(tt.questions) {
+				t.Errorf("questions = %v, want %v", questions, tt.questions)
+			}
+			for _, q := range questions {
+				if want, ok := tt.questions[q.ID]; !ok || q.LikesCount != want {
+					t.Errorf("question %d likes = %d, want %d", q.ID, q.LikesCount, want)
+				}
+			}
+
+			counts := []struct {
+				query string
+				want  int64
+			}{
+				{"SELECT COUNT(*) FROM review_votes", tt.votesLeft},
+				{"SELECT COUNT(*) FROM question_likes", tt.questionLikes},
+				{"SELECT COUNT(*) FROM review_votes WHERE user_id = 7", 0},
+				{"SELECT COUNT(*) FROM question_likes WHERE user_id = 7", 0},
+				{"SELECT COUNT(*) FROM reviews WHERE user_id IS NULL", tt.ownReviewsLeft},
+				{"SELECT COUNT(*) FROM questions WHERE user_id IS NULL", tt.ownQuestionLeft},
+				{"SELECT COUNT(*) FROM reviews WHERE user_id = 7", 0},
+				{"SELECT COUNT(*) FROM questions WHERE user_id = 7", 0},
+			}
+			for _, c := range counts {
+				var got int64
+				if err := database.Raw(c.query).Scan(&got).Error; err != nil {
+					t.Fatal(err)
+				}
+				if got != c.want {
+					t.Errorf("%s = %d, want %d", c.query, got, c.want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package gdpr
+
+import (
+	"context"
+	"time"
+
+	"github.com/ShopOnGO/ShopOnGO/pkg/logger"
+	"github.com/ShopOnGO/review-service/internal/apperr"
+	"github.com/ShopOnGO/review-service/internal/auth"
+	"github.com/ShopOnGO/review-service/internal/review"
+)
+
+// GdprService отвечает на запросы субъектов данных: выгрузка и удаление всего,
+// что сервис хранит о пользователе или госте.
+type GdprService struct {
+	GdprRepository *GdprRepository
+	// ReviewService пересчитывает выделенные отзывы и сбрасывает кэш затронутых товаров
+	ReviewService *review.ReviewService
+}
+
+func NewGdprService(gdprRepo *GdprRepository, reviewSvc *review.ReviewService) *GdprService {
+	return &GdprService{
+		GdprRepository: gdprRepo,
+		ReviewService:  reviewSvc,
+	}
+}
+
+func (s *GdprService) Export(ctx context.Context, subject Subject) (*Export, error) {
+	if err := validateSubject(ctx, subject); err != nil {
+		return nil, err
+	}
+
+	export, err := s.GdprRepository.Export(ctx, subject)
+	if err != nil {
+		logger.Errorf("Error exporting data of %+v: %v", subject, err)
+		return nil, err
+	}
+	export.ExportedAt = time.Now().UTC()
+	return export, nil
+}
+
+func (s *GdprService) Erase(ctx context.Context, subject Subject, mode string) (*ErasureResult, error) {
+	if err := validateSubject(ctx, subject); err != nil {
+		return nil, err
+	}
+	if mode != ModeAnonymize && mode != ModeDelete {
+		return nil, apperr.InvalidArgument("mode", "unknown erasure mode: %s", mode)
+	}
+
+	result, err := s.GdprRepository.Erase(ctx, subject, mode)
+	if err != nil {
+		logger.Errorf("Error erasing data of %+v (%s): %v", subject, mode, err)
+		return nil, err
+	}
+
+	for _, productID := range result.ProductIDs {
+		s.ReviewService.ProductChanged(ctx, productID)
+	}
+
+	logger.Infof("Data of %+v erased (%s): reviews=%d, questions=%d, review_votes=%d, question_likes=%d",
+		subject, mode, result.Reviews, result.Questions, result.ReviewVotesRemoved, result.QuestionLikesRemoved)
+	return result, nil
+}
+
+// validateSubject проверяет, что субъект задан ровно одним ID и что вызывающий вправе
+// работать с его данными: пользователь с токеном — только со своими, модератор — с любыми.
+func validateSubject(ctx context.Context, subject Subject) error {
+	if (subject.UserID == 0) == (subject.GuestID == "") {
+		return apperr.InvalidArgument("", "exactly one of user_id or guest_id is required")
+	}
+	var guestID []byte
+	if subject.GuestID != "" {
+		guestID = []byte(subject.GuestID)
+	}
+	_, _, err := auth.Author(ctx, &subject.UserID, guestID)
+	return err
+}
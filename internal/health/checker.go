@@ -0,0 +1,104 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Check — проверка одной зависимости. nil означает, что зависимость готова.
+type Check func(ctx context.Context) error
+
+var errShuttingDown = errors.New("shutting down")
+
+// Report — результат проверки готовности: общий статус и статус каждой проверки.
+type Report struct {
+	Ready  bool              `json:"ready"`
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks"`
+}
+
+// Checker собирает проверки готовности сервиса. После SetShuttingDown сервис
+// всегда считается неготовым, чтобы балансировщик успел снять его с трафика.
+type Checker struct {
+	timeout      time.Duration
+	mu           sync.RWMutex
+	checks       map[string]Check
+	shuttingDown atomic.Bool
+	done         chan struct{}
+	doneOnce     sync.Once
+}
+
+func NewChecker(timeout time.Duration) *Checker {
+	return &Checker{
+		timeout: timeout,
+		checks:  make(map[string]Check),
+		done:    make(chan struct{}),
+	}
+}
+
+func (c *Checker) Register(name string, check Check) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.checks[name] = check
+}
+
+func (c *Checker) SetShuttingDown() {
+	c.shuttingDown.Store(true)
+	c.doneOnce.Do(func() { close(c.done) })
+}
+
+// Done закрывается при переходе в режим остановки.
+func (c *Checker) Done() <-chan struct{} {
+	return c.done
+}
+
+// Check выполняет все проверки параллельно, каждую — не дольше таймаута.
+func (c *Checker) Check(ctx context.Context) Report {
+	c.mu.RLock()
+	names := make([]string, 0, len(c.checks))
+	for name := range c.checks {
+		names = append(names, name)
+	}
+	c.mu.RUnlock()
+	sort.Strings(names)
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	results := make([]error, len(names))
+	var wg sync.WaitGroup
+	for i, name := range names {
+		c.mu.RLock()
+		check := c.checks[name]
+		c.mu.RUnlock()
+
+		wg.Add(1)
+		go func(i int, check Check) {
+			defer wg.Done()
+			results[i] = check(ctx)
+		}(i, check)
+	}
+	wg.Wait()
+
+	report := Report{Ready: true, Status: "ok", Checks: make(map[string]string, len(names)+1)}
+	for i, name := range names {
+		if results[i] != nil {
+			report.Ready = false
+			report.Checks[name] = results[i].Error()
+		} else {
+			report.Checks[name] = "ok"
+		}
+	}
+	if c.shuttingDown.Load() {
+		report.Ready = false
+		report.Checks["lifecycle"] = errShuttingDown.Error()
+	}
+	if !report.Ready {
+		report.Status = "unavailable"
+	}
+	return report
+}
@@ -0,0 +1,41 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/segmentio/kafka-go"
+	"gorm.io/gorm"
+)
+
+// DBCheck проверяет, что база отвечает на ping.
+func DBCheck(db *gorm.DB) Check {
+	return func(ctx context.Context) error {
+		sqlDB, err := db.DB()
+		if err != nil {
+			return err
+		}
+		return sqlDB.PingContext(ctx)
+	}
+}
+
+// KafkaCheck проверяет, что цикл чтения консьюмера запущен (running) и хотя бы один брокер доступен.
+func KafkaCheck(running *atomic.Bool, brokers []string) Check {
+	return func(ctx context.Context) error {
+		if !running.Load() {
+			return errors.New("consumer is not running")
+		}
+		var lastErr error
+		for _, broker := range brokers {
+			conn, err := kafka.DialContext(ctx, "tcp", broker)
+			if err == nil {
+				conn.Close()
+				return nil
+			}
+			lastErr = err
+		}
+		return fmt.Errorf("no reachable brokers: %w", lastErr)
+	}
+}
@@ -0,0 +1,44 @@
+package health
+
+import (
+	"context"
+	"time"
+
+	"github.com/ShopOnGO/ShopOnGO/pkg/logger"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// WatchGRPC раз в interval переносит результат проверок в статус grpc.health.v1 для
+// services и общего сервиса "". При остановке все статусы переводятся в NOT_SERVING.
+func WatchGRPC(checker *Checker, srv *health.Server, interval time.Duration, services ...string) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	lastReady := false
+	update := func() {
+		report := checker.Check(context.Background())
+		status := healthpb.HealthCheckResponse_NOT_SERVING
+		if report.Ready {
+			status = healthpb.HealthCheckResponse_SERVING
+		}
+		if report.Ready != lastReady {
+			logger.Infof("gRPC health status: %s %v", status, report.Checks)
+			lastReady = report.Ready
+		}
+		srv.SetServingStatus("", status)
+		for _, service := range services {
+			srv.SetServingStatus(service, status)
+		}
+	}
+
+	for {
+		update()
+		select {
+		case <-checker.Done():
+			srv.Shutdown()
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package health
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type HealthHandler struct {
+	checker *Checker
+}
+
+func NewHealthHandler(router *gin.Engine, checker *Checker) *HealthHandler {
+	handler := &HealthHandler{checker: checker}
+
+	router.GET("/healthz", handler.liveness)
+	router.GET("/readyz", handler.readiness)
+
+	return handler
+}
+
+// liveness godoc
+// @Summary Проверка живости
+// @Description Отвечает 200, пока процесс обслуживает HTTP. Зависимости не проверяются
+// @Tags Служебные
+// @Success 200 {object} gin.H
+// @Router /healthz [get]
+func (h *HealthHandler) liveness(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+// readiness godoc
+// @Summary Проверка готовности
+// @Description Проверяет базу, версию схемы и Kafka-консьюмер. Во время остановки всегда отвечает 503
+// @Tags Служебные
+// @Success 200 {object} health.Report
+// @Failure 503 {object} health.Report
+// @Router /readyz [get]
+func (h *HealthHandler) readiness(c *gin.Context) {
+	report := h.checker.Check(c.Request.Context())
+	status := http.StatusOK
+	if !report.Ready {
+		status = http.StatusServiceUnavailable
+	}
+	c.JSON(status, report)
+}
@@ -0,0 +1,92 @@
+// Package httpcache — условные GET-запросы для Gin: ETag и Last-Modified в ответах,
+// 304 Not Modified на If-None-Match/If-Modified-Since и заголовок Cache-Control
+// для браузеров и CDN.
+package httpcache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Private — Cache-Control ответов, зависящих от запрашивающего (списки «мои отзывы»):
+// CDN их не хранит, браузер перед каждым использованием проверяет ETag.
+const Private = "private, no-cache"
+
+// Policy задаёт Cache-Control публичных ответов — отзывов, вопросов и списков товара,
+// одинаковых для всех покупателей.
+type Policy struct {
+	// MaxAge — сколько ответ считается свежим без повторной проверки
+	MaxAge time.Duration
+	// StaleWhileRevalidate — сколько после MaxAge кэш может отдавать ответ, обновляя его в фоне
+	StaleWhileRevalidate time.Duration
+}
+
+// Public возвращает Cache-Control публичного ответа.
+func (p Policy) Public() string {
+	value := fmt.Sprintf("public, max-age=%d", int(p.MaxAge.Seconds()))
+	if p.StaleWhileRevalidate > 0 {
+		value += fmt.Sprintf(", stale-while-revalidate=%d", int(p.StaleWhileRevalidate.Seconds()))
+	}
+	return value
+}
+
+// JSON отдаёт body со статусом 200, ETag по содержимому и Last-Modified (если lastModified
+// не нулевое). Если у клиента уже есть эта версия, вместо тела отдаётся 304.
+func JSON(c *gin.Context, body interface{}, lastModified time.Time, cacheControl string) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка формирования ответа"})
+		return
+	}
+
+	sum := sha256.Sum256(data)
+	etag := `W/"` + hex.EncodeToString(sum[:16]) + `"`
+	header := c.Writer.Header()
+	header.Set("ETag", etag)
+	header.Set("Cache-Control", cacheControl)
+	if !lastModified.IsZero() {
+		header.Set("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	}
+
+	if notModified(c.Request, etag, lastModified) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+	c.Data(http.StatusOK, "application/json; charset=utf-8", data)
+}
+
+// notModified проверяет условия запроса. If-None-Match имеет приоритет: If-Modified-Since
+// учитывается, только если клиент не прислал ETag (RFC 9110, 13.2.2).
+func notModified(r *http.Request, etag string, lastModified time.Time) bool {
+	if inm := r.Header.Get("If-None-Match"); inm != "" {
+		return matchETag(inm, etag)
+	}
+	ims := r.Header.Get("If-Modified-Since")
+	if ims == "" || lastModified.IsZero() {
+		return false
+	}
+	since, err := http.ParseTime(ims)
+	if err != nil {
+		return false
+	}
+	// Last-Modified передаётся с точностью до секунды.
+	return !lastModified.Truncate(time.Second).After(since)
+}
+
+// matchETag сравнивает ETag слабо: префикс W/ не учитывается.
+func matchETag(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
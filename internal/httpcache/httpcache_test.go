@@ -0,0 +1,137 @@
+package httpcache
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestPolicyPublic(t *testing.T) {
+	tests := []struct {
+		policy Policy
+		want   string
+	}{
+		{Policy{}, "public, max-age=0"},
+		{Policy{MaxAge: time.Minute}, "public, max-age=60"},
+		{Policy{MaxAge: 30 * time.Second, StaleWhileRevalidate: 5 * time.Minute}, "public, max-age=30, stale-while-revalidate=300"},
+	}
+	for _, tt := range tests {
+		if got := tt.policy.Public(); got != tt.want {
+			t.Errorf("%+v: got %q, want %q", tt.policy, got, tt.want)
+		}
+	}
+}
+
+func TestMatchETag(t *testing.T) {
+	const etag = `W/"abc"`
+
+	tests := []struct {
+		header string
+		want   bool
+	}{
+		{`W/"abc"`, true},
+		{`"abc"`, true},
+		{`"xyz", W/"abc"`, true},
+		{`*`, true},
+		{`"xyz"`, false},
+		{`W/"ab"`, false},
+	}
+	for _, tt := range tests {
+		if got := matchETag(tt.header, etag); got != tt.want {
+			t.Errorf("matchETag(%q) = %v, want %v", tt.header, got, tt.want)
+		}
+	}
+}
+
+func TestNotModified(t *testing.T) {
+	const etag = `W/"abc"`
+	modified := time.Date(2024, 5, 1, 12, 0, 0, 500_000_000, time.UTC)
+	at := func(t time.Time) string { return t.Format(http.TimeFormat) }
+
+	tests := []struct {
+		name         string
+		header       map[string]string
+		lastModified time.Time
+		want         bool
+	}{
+		{"no conditions", nil, modified, false},
+		{"etag matches", map[string]string{"If-None-Match": etag}, modified, true},
+		{"etag differs", map[string]string{"If-None-Match": `"old"`}, modified, false},
+		{"same second", map[string]string{"If-Modified-Since": at(modified)}, modified, true},
+		{"modified later", map[string]string{"If-Modified-Since": at(modified.Add(-time.Minute))}, modified, false},
+		{"not modified since", map[string]string{"If-Modified-Since": at(modified.Add(time.Hour))}, modified, true},
+		{"unknown last modified", map[string]string{"If-Modified-Since": at(modified)}, time.Time{}, false},
+		{"malformed date", map[string]string{"If-Modified-Since": "yesterday"}, modified, false},
+		{
+			name:         "etag takes precedence",
+			header:       map[string]string{"If-None-Match": `"old"`, "If-Modified-Since": at(modified.Add(time.Hour))},
+			lastModified: modified,
+			want:         false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			for k, v := range tt.header {
+				r.Header.Set(k, v)
+			}
+			if got := notModified(r, etag, tt.lastModified); got != tt.want {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJSON(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	modified := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	body := gin.H{"id": 1}
+
+	serve := func(header map[string]string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/reviews/1", nil)
+		for k, v := range header {
+			c.Request.Header.Set(k, v)
+		}
+		JSON(c, body, modified, Private)
+		c.Writer.WriteHeaderNow()
+		return w
+	}
+
+	first := serve(nil)
+	etag := first.Header().Get("ETag")
+	if first.Code != http.StatusOK || first.Body.String() != `{"id":1}` {
+		t.Fatalf("got %d %q", first.Code, first.Body.String())
+	}
+	if etag == "" || first.Header().Get("Cache-Control") != Private || first.Header().Get("Last-Modified") != modified.Format(http.TimeFormat) {
+		t.Fatalf("headers: %v", first.Header())
+	}
+
+	tests := []struct {
+		name   string
+		header map[string]string
+		want   int
+	}{
+		{"same etag", map[string]string{"If-None-Match": etag}, http.StatusNotModified},
+		{"stale etag", map[string]string{"If-None-Match": `W/"old"`}, http.StatusOK},
+		{"not modified since", map[string]string{"If-Modified-Since": modified.Format(http.TimeFormat)}, http.StatusNotModified},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serve(tt.header)
+			if w.Code != tt.want {
+				t.Fatalf("got %d, want %d", w.Code, tt.want)
+			}
+			if w.Header().Get("ETag") != etag {
+				t.Errorf("ETag changed: %q", w.Header().Get("ETag"))
+			}
+			if tt.want == http.StatusNotModified && w.Body.Len() != 0 {
+				t.Errorf("304 has a body: %q", w.Body.String())
+			}
+		})
+	}
+}
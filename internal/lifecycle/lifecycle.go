@@ -0,0 +1,104 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ShopOnGO/ShopOnGO/pkg/logger"
+)
+
+// Component — часть процесса со своим циклом жизни: HTTP-сервер, gRPC-сервер, консьюмер, фоновая задача.
+type Component interface {
+	Name() string
+	// Run блокируется, пока компонент работает, и возвращает управление после Stop.
+	Run() error
+	// Stop останавливает компонент, дожидаясь завершения начатой работы не дольше ctx.
+	// По истечении ctx работа прерывается принудительно.
+	Stop(ctx context.Context) error
+}
+
+type entry struct {
+	component   Component
+	stopTimeout time.Duration
+}
+
+// Manager запускает компоненты и останавливает их в порядке, обратном добавлению:
+// сначала те, что принимают новую работу, затем те, что её доделывают.
+type Manager struct {
+	components []entry
+	beforeStop []func()
+}
+
+func NewManager() *Manager {
+	return &Manager{}
+}
+
+// Add регистрирует компонент; на его остановку отводится stopTimeout.
+func (m *Manager) Add(c Component, stopTimeout time.Duration) {
+	m.components = append(m.components, entry{component: c, stopTimeout: stopTimeout})
+}
+
+// BeforeStop добавляет шаг, который выполняется перед остановкой компонентов (в порядке добавления).
+func (m *Manager) BeforeStop(fn func()) {
+	m.beforeStop = append(m.beforeStop, fn)
+}
+
+// Run запускает все компоненты и ждёт отмены ctx или завершения любого из них, после чего
+// останавливает остальные. Возвращает ошибки компонентов и их остановки.
+func (m *Manager) Run(ctx context.Context) error {
+	type exit struct {
+		name string
+		err  error
+	}
+	exits := make(chan exit, len(m.components))
+	for _, e := range m.components {
+		c := e.component
+		logger.Infof("Starting %s", c.Name())
+		go func() {
+			exits <- exit{name: c.Name(), err: c.Run()}
+		}()
+	}
+
+	var errs []error
+	running := len(m.components)
+	select {
+	case <-ctx.Done():
+		logger.Info("Shutdown signal received")
+	case e := <-exits:
+		running--
+		if e.err != nil {
+			logger.Errorf("%s failed: %v", e.name, e.err)
+			errs = append(errs, fmt.Errorf("%s: %w", e.name, e.err))
+		} else {
+			logger.Warnf("%s stopped unexpectedly", e.name)
+			errs = append(errs, fmt.Errorf("%s stopped unexpectedly", e.name))
+		}
+	}
+
+	for _, fn := range m.beforeStop {
+		fn()
+	}
+
+	for i := len(m.components) - 1; i >= 0; i-- {
+		e := m.components[i]
+		start := time.Now()
+		stopCtx, cancel := context.WithTimeout(context.Background(), e.stopTimeout)
+		err := e.component.Stop(stopCtx)
+		cancel()
+		if err != nil {
+			logger.Errorf("Stopping %s: %v", e.component.Name(), err)
+			errs = append(errs, fmt.Errorf("stop %s: %w", e.component.Name(), err))
+			continue
+		}
+		logger.Infof("%s stopped in %s", e.component.Name(), time.Since(start).Round(time.Millisecond))
+	}
+
+	for ; running > 0; running-- {
+		if e := <-exits; e.err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", e.name, e.err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,43 @@
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	httpRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "review_service_http_requests_total",
+		Help: "Количество HTTP-запросов по методу, маршруту и коду ответа.",
+	}, []string{"method", "route", "status"})
+
+	httpDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "review_service_http_request_duration_seconds",
+		Help:    "Длительность обработки HTTP-запросов.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "route"})
+)
+
+// unmatchedRoute — метка для запросов, не попавших ни в один маршрут. Сырой путь
+// в метку не пишется, чтобы произвольные URL не раздували число серий.
+const unmatchedRoute = "unmatched"
+
+// GinMiddleware считает запросы и их длительность по шаблону маршрута (c.FullPath()).
+func GinMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = unmatchedRoute
+		}
+		method := c.Request.Method
+		httpRequests.WithLabelValues(method, route, strconv.Itoa(c.Writer.Status())).Inc()
+		httpDuration.WithLabelValues(method, route).Observe(time.Since(start).Seconds())
+	}
+}
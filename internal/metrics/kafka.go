@@ -0,0 +1,54 @@
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/segmentio/kafka-go"
+)
+
+// Метки action для сообщений, которые не дошли до обработчика.
+const (
+	ActionInvalid = "invalid"
+	ActionUnknown = "unknown"
+)
+
+var (
+	kafkaEvents = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "review_service_kafka_events_total",
+		Help: "Количество обработанных событий Kafka по сущности, действию и результату (ok, error).",
+	}, []string{"entity", "action", "result"})
+
+	kafkaDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "review_service_kafka_event_duration_seconds",
+		Help:    "Длительность обработки событий Kafka.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"entity", "action"})
+
+	kafkaLag = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "review_service_kafka_consumer_lag",
+		Help: "Отставание консьюмера от конца партиции на момент чтения последнего сообщения.",
+	}, []string{"topic", "partition"})
+)
+
+// ObserveKafkaEvent учитывает одно событие entity (review, question) с действием action.
+func ObserveKafkaEvent(entity, action string, start time.Time, err error) {
+	result := "ok"
+	if err != nil {
+		result = "error"
+	}
+	kafkaEvents.WithLabelValues(entity, action, result).Inc()
+	kafkaDuration.WithLabelValues(entity, action).Observe(time.Since(start).Seconds())
+}
+
+// ObserveKafkaLag обновляет отставание по партиции сообщения: HighWaterMark — смещение
+// следующего сообщения, которое будет записано в партицию.
+func ObserveKafkaLag(msg kafka.Message) {
+	lag := msg.HighWaterMark - msg.Offset - 1
+	if lag < 0 {
+		lag = 0
+	}
+	kafkaLag.WithLabelValues(msg.Topic, strconv.Itoa(msg.Partition)).Set(float64(lag))
+}
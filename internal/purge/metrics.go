@@ -0,0 +1,51 @@
+package purge
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	purgeRuns = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "review_service_purge_runs_total",
+		Help: "Количество проходов очистки мягко удалённых строк по результату (ok, error, dry_run).",
+	}, []string{"result"})
+
+	purgedRows = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "review_service_purge_deleted_rows_total",
+		Help: "Количество физически удалённых строк по таблицам.",
+	}, []string{"table"})
+
+	purgeDuration = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "review_service_purge_duration_seconds",
+		Help:    "Длительность прохода очистки.",
+		Buckets: prometheus.ExponentialBuckets(0.1, 2, 12),
+	})
+
+	purgeLastSuccess = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "review_service_purge_last_success_timestamp_seconds",
+		Help: "Время последнего успешного прохода очистки (unix).",
+	})
+)
+
+func observe(result *Result, err error) {
+	switch {
+	case err != nil:
+		purgeRuns.WithLabelValues("error").Inc()
+	case result.DryRun:
+		purgeRuns.WithLabelValues("dry_run").Inc()
+	default:
+		purgeRuns.WithLabelValues("ok").Inc()
+		purgeLastSuccess.SetToCurrentTime()
+	}
+	purgeDuration.Observe(result.Duration.Seconds())
+
+	if result.DryRun {
+		return
+	}
+	purgedRows.WithLabelValues("reviews").Add(float64(result.Reviews))
+	purgedRows.WithLabelValues("review_votes").Add(float64(result.ReviewVotes))
+	purgedRows.WithLabelValues("review_highlights").Add(float64(result.Highlights))
+	purgedRows.WithLabelValues("questions").Add(float64(result.Questions))
+	purgedRows.WithLabelValues("question_likes").Add(float64(result.QuestionLikes))
+}
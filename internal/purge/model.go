@@ -0,0 +1,16 @@
+package purge
+
+import "time"
+
+// Result — итог одного прохода очистки. В режиме DryRun счётчики показывают,
+// сколько строк было бы удалено.
+type Result struct {
+	Cutoff        time.Time     `json:"cutoff"`
+	DryRun        bool          `json:"dry_run"`
+	Reviews       int64         `json:"reviews"`
+	ReviewVotes   int64         `json:"review_votes"`
+	Highlights    int64         `json:"highlights"`
+	Questions     int64         `json:"questions"`
+	QuestionLikes int64         `json:"question_likes"`
+	Duration      time.Duration `json:"duration"`
+}
@@ -0,0 +1,115 @@
+package purge
+
+import (
+	"context"
+	"time"
+
+	"github.com/ShopOnGO/review-service/internal/question"
+	"github.com/ShopOnGO/review-service/internal/review"
+	"github.com/ShopOnGO/review-service/pkg/db"
+	"gorm.io/gorm"
+)
+
+type PurgeRepository struct {
+	Db *db.Db
+}
+
+func NewPurgeRepository(db *db.Db) *PurgeRepository {
+	return &PurgeRepository{
+		Db: db,
+	}
+}
+
+// CountExpired считает мягко удалённые до cutoff отзывы и вопросы и зависящие от них строки.
+func (r *PurgeRepository) CountExpired(ctx context.Context, cutoff time.Time, result *Result) error {
+	tx := r.Db.WithContext(ctx)
+	expiredReviews := tx.Unscoped().Model(&review.Review{}).Select("id").Where("deleted_at < ?", cutoff)
+	expiredQuestions := tx.Unscoped().Model(&question.Question{}).Select("id").Where("deleted_at < ?", cutoff)
+
+	counts := []struct {
+		query *gorm.DB
+		dest  *int64
+	}{
+		{tx.Unscoped().Model(&review.Review{}).Where("deleted_at < ?", cutoff), &result.Reviews},
+		{tx.Model(&review.ReviewVote{}).Where("review_id IN (?)", expiredReviews), &result.ReviewVotes},
+		{tx.Model(&review.ReviewHighlight{}).Where("review_id IN (?)", expiredReviews), &result.Highlights},
+		{tx.Unscoped().Model(&question.Question{}).Where("deleted_at < ?", cutoff), &result.Questions},
+		{tx.Model(&question.QuestionLike{}).Where("question_id IN (?)", expiredQuestions), &result.QuestionLikes},
+	}
+	for _, c := range counts {
+		if err := c.query.Count(c.dest).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PurgeReviewsBatch физически удаляет до batchSize отзывов, мягко удалённых до cutoff,
+// вместе с голосами и выделениями. Агрегаты рейтинга не трогаются: они были пересчитаны
+// ещё при мягком удалении. Возвращает число удалённых отзывов — 0 означает, что удалять больше нечего.
+func (r *PurgeRepository) PurgeReviewsBatch(ctx context.Context, cutoff time.Time, batchSize int, result *Result) (int64, error) {
+	var deleted int64
+	err := r.Db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var ids []uint
+		err := tx.Unscoped().Model(&review.Review{}).
+			Where("deleted_at < ?", cutoff).
+			Order("id").
+			Limit(batchSize).
+			Pluck("id", &ids).Error
+		if err != nil || len(ids) == 0 {
+			return err
+		}
+
+		res := tx.Where("review_id IN ?", ids).Delete(&review.ReviewVote{})
+		if res.Error != nil {
+			return res.Error
+		}
+		result.ReviewVotes += res.RowsAffected
+
+		res = tx.Where("review_id IN ?", ids).Delete(&review.ReviewHighlight{})
+		if res.Error != nil {
+			return res.Error
+		}
+		result.Highlights += res.RowsAffected
+
+		res = tx.Unscoped().Where("id IN ?", ids).Delete(&review.Review{})
+		if res.Error != nil {
+			return res.Error
+		}
+		deleted = res.RowsAffected
+		result.Reviews += deleted
+		return nil
+	})
+	return deleted, err
+}
+
+// PurgeQuestionsBatch физически удаляет до batchSize вопросов, мягко удалённых до cutoff, вместе с их лайками.
+func (r *PurgeRepository) PurgeQuestionsBatch(ctx context.Context, cutoff time.Time, batchSize int, result *Result) (int64, error) {
+	var deleted int64
+	err := r.Db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var ids []uint
+		err := tx.Unscoped().Model(&question.Question{}).
+			Where("deleted_at < ?", cutoff).
+			Order("id").
+			Limit(batchSize).
+			Pluck("id", &ids).Error
+		if err != nil || len(ids) == 0 {
+			return err
+		}
+
+		res := tx.Where("question_id IN ?", ids).Delete(&question.QuestionLike{})
+		if res.Error != nil {
+			return res.Error
+		}
+		result.QuestionLikes += res.RowsAffected
+
+		res = tx.Unscoped().Where("id IN ?", ids).Delete(&question.Question{})
+		if res.Error != nil {
+			return res.Error
+		}
+		deleted = res.RowsAffected
+		result.Questions += deleted
+		return nil
+	})
+	return deleted, err
+}
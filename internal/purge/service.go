@@ -0,0 +1,103 @@
+package purge
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ShopOnGO/ShopOnGO/pkg/logger"
+	"github.com/ShopOnGO/review-service/configs"
+)
+
+const defaultBatchSize = 500
+
+// PurgeService физически удаляет отзывы и вопросы, мягко удалённые раньше, чем RetentionDays дней назад.
+type PurgeService struct {
+	PurgeRepository *PurgeRepository
+	RetentionDays   int
+	BatchSize       int
+}
+
+func NewPurgeService(purgeRepo *PurgeRepository, conf configs.PurgeConfig) *PurgeService {
+	batchSize := conf.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultBatchSize
+	}
+	return &PurgeService{
+		PurgeRepository: purgeRepo,
+		RetentionDays:   conf.RetentionDays,
+		BatchSize:       batchSize,
+	}
+}
+
+// Run выполняет один проход очистки. При dryRun ничего не удаляется, а в результате
+// возвращается количество строк, которые были бы удалены.
+func (s *PurgeService) Run(ctx context.Context, dryRun bool) (*Result, error) {
+	if s.RetentionDays <= 0 {
+		return nil, fmt.Errorf("retention days must be positive, got %d", s.RetentionDays)
+	}
+
+	start := time.Now()
+	result := &Result{
+		Cutoff: start.AddDate(0, 0, -s.RetentionDays),
+		DryRun: dryRun,
+	}
+
+	err := s.run(ctx, result)
+	result.Duration = time.Since(start)
+	observe(result, err)
+	if err != nil {
+		logger.Errorf("Purge failed after %s: %v", result.Duration, err)
+		return result, err
+	}
+
+	logger.Infof("Purge finished (dry_run=%t, cutoff=%s): reviews=%d, review_votes=%d, highlights=%d, questions=%d, question_likes=%d in %s",
+		dryRun, result.Cutoff.Format(time.RFC3339), result.Reviews, result.ReviewVotes, result.Highlights,
+		result.Questions, result.QuestionLikes, result.Duration)
+	return result, nil
+}
+
+func (s *PurgeService) run(ctx context.Context, result *Result) error {
+	if result.DryRun {
+		return s.PurgeRepository.CountExpired(ctx, result.Cutoff, result)
+	}
+
+	batches := []func(context.Context, time.Time, int, *Result) (int64, error){
+		s.PurgeRepository.PurgeReviewsBatch,
+		s.PurgeRepository.PurgeQuestionsBatch,
+	}
+	for _, purgeBatch := range batches {
+		for {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			deleted, err := purgeBatch(ctx, result.Cutoff, s.BatchSize, result)
+			if err != nil {
+				return err
+			}
+			if deleted < int64(s.BatchSize) {
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// Start запускает очистку раз в interval, пока не отменён ctx.
+func (s *PurgeService) Start(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	logger.Infof("Purge job started: retention=%d days, interval=%s, batch=%d", s.RetentionDays, interval, s.BatchSize)
+	for {
+		if _, err := s.Run(ctx, false); err != nil && ctx.Err() != nil {
+			return
+		}
+		select {
+		case <-ctx.Done():
+			logger.Info("Purge job stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	pb "github.com/ShopOnGO/review-proto/pkg/service"
+	"github.com/ShopOnGO/review-service/internal/auth"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -16,42 +17,162 @@ func NewGrpcQuestionService(svc *QuestionService) *GrpcQuestionService {
 	return &GrpcQuestionService{questionSvc: svc}
 }
 
+func (g *GrpcQuestionService) GetQuestion(ctx context.Context, req *pb.GetQuestionRequest) (*pb.Question, error) {
+	question, err := g.questionSvc.GetQuestionByID(ctx, uint(req.QuestionId))
+	if err != nil {
+		return nil, err
+	}
+	return toProtoQuestion(question), nil
+}
+
+func (g *GrpcQuestionService) CreateQuestion(ctx context.Context, req *pb.CreateQuestionRequest) (*pb.Question, error) {
+	userID, guestID := auth.RequestAuthor(req.GetUserId(), req.GetGuestId())
+	question, err := g.questionSvc.AddQuestion(ctx, uint(req.ProductId), req.QuestionText, userID, guestID)
+	if err != nil {
+		return nil, err
+	}
+	return toProtoQuestion(question), nil
+}
+
+// UpdateQuestion правит текст вопроса. Вопрос должен принадлежать автору запроса (см. CheckAuthor).
+func (g *GrpcQuestionService) UpdateQuestion(ctx context.Context, req *pb.UpdateQuestionRequest) (*pb.Question, error) {
+	userID, _ := auth.RequestAuthor(req.GetUserId(), req.GetGuestId())
+	if _, err := g.questionSvc.CheckAuthor(ctx, uint(req.QuestionId), userID, req.GetGuestId()); err != nil {
+		return nil, err
+	}
+
+	question, err := g.questionSvc.UpdateQuestion(ctx, uint(req.QuestionId), req.QuestionText)
+	if err != nil {
+		return nil, err
+	}
+	return toProtoQuestion(question), nil
+}
+
+func (g *GrpcQuestionService) DeleteQuestion(ctx context.Context, req *pb.DeleteQuestionRequest) (*pb.DeleteQuestionResponse, error) {
+	// в запросе нет автора: без проверки токенов (нет Principal) удаление, как и из Kafka,
+	// автора не проверяет, с токеном вопрос может удалить только его автор или модератор
+	if _, ok := auth.FromContext(ctx); ok {
+		if _, err := g.questionSvc.CheckAuthor(ctx, uint(req.QuestionId), nil, nil); err != nil {
+			return nil, err
+		}
+	}
+	if err := g.questionSvc.DeleteQuestion(ctx, uint(req.QuestionId)); err != nil {
+		return nil, err
+	}
+	return &pb.DeleteQuestionResponse{}, nil
+}
+
+func (g *GrpcQuestionService) LikeQuestion(ctx context.Context, req *pb.LikeQuestionRequest) (*pb.LikeQuestionResponse, error) {
+	like := g.questionSvc.AddLikeToQuestion
+	if req.Remove {
+		like = g.questionSvc.RemoveLikeToQuestion
+	}
+
+	userID, guestID := auth.RequestAuthor(req.GetUserId(), req.GetGuestId())
+	likes, err := like(ctx, uint(req.QuestionId), userID, guestID)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.LikeQuestionResponse{LikesCount: int32(likes)}, nil
+}
+
+func (g *GrpcQuestionService) AnswerQuestion(ctx context.Context, req *pb.AnswerQuestionRequest) (*pb.Question, error) {
+	question, err := g.questionSvc.AnswerQuestion(ctx, uint(req.QuestionId), req.AnswerText, req.GetOverwrite())
+	if err != nil {
+		return nil, err
+	}
+	return toProtoQuestion(question), nil
+}
+
 func (g *GrpcQuestionService) GetQuestionsForProduct(ctx context.Context, req *pb.GetQuestionsRequest) (*pb.QuestionListResponse, error) {
-	questions, err := g.questionSvc.GetQuestionsForProduct(uint(req.ProductId), int(req.Limit), int(req.Offset))
+	questions, err := g.questionSvc.GetQuestionsForProduct(ctx, uint(req.ProductId), int(req.Limit), int(req.Offset))
 	if err != nil {
 		return nil, err
 	}
 
 	resp := &pb.QuestionListResponse{}
 	for _, q := range questions {
-		protoModel := &pb.Model{
-			Id:        uint32(q.ID),
-			CreatedAt: timestamppb.New(q.CreatedAt),
-			UpdatedAt: timestamppb.New(q.UpdatedAt),
-			DeletedAt: func() *timestamppb.Timestamp {
-				if q.DeletedAt.Valid {
-					return timestamppb.New(q.DeletedAt.Time)
-				}
-				return nil
-			}(),
-		}
+		resp.Questions = append(resp.Questions, toProtoQuestion(q))
+	}
 
-		protoQuestion := &pb.Question{
-			Model:          protoModel,
-			ProductId: 		uint32(q.ProductID),
-			QuestionText:   q.QuestionText,
-			AnswerText:     q.AnswerText,
-			LikesCount:		int32(q.LikesCount),
-		}
+	return resp, nil
+}
 
-		if q.UserID != nil {
-			protoQuestion.Author = &pb.Question_UserId{UserId: uint32(*q.UserID)}
-		} else if len(q.GuestID) > 0 {
-			protoQuestion.Author = &pb.Question_GuestId{GuestId: q.GuestID}
-		}
+func (g *GrpcQuestionService) GetQuestionsByUser(ctx context.Context, req *pb.GetQuestionsByUserRequest) (*pb.UserQuestionListResponse, error) {
+	questions, err := g.questionSvc.GetQuestionsByUser(ctx, uint(req.UserId), uint(req.ViewerId), int(req.Limit), int(req.Offset))
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pb.UserQuestionListResponse{}
+	for _, q := range questions {
+		resp.Questions = append(resp.Questions, &pb.UserQuestion{
+			Question: toProtoQuestion(&q.Question),
+			Status:   q.Status,
+		})
+	}
+
+	return resp, nil
+}
+
+func (g *GrpcQuestionService) SearchQuestions(ctx context.Context, req *pb.SearchQuestionsRequest) (*pb.SearchQuestionsResponse, error) {
+	results, err := g.questionSvc.SearchQuestions(ctx, uint(req.ProductId), req.Query, int(req.Limit), int(req.Offset))
+	if err != nil {
+		return nil, err
+	}
 
-		resp.Questions = append(resp.Questions, protoQuestion)
+	resp := &pb.SearchQuestionsResponse{}
+	for _, r := range results {
+		resp.Hits = append(resp.Hits, &pb.QuestionSearchHit{
+			Question:        toProtoQuestion(&r.Question),
+			Rank:            r.Rank,
+			QuestionSnippet: r.QuestionSnippet,
+			AnswerSnippet:   r.AnswerSnippet,
+		})
 	}
 
 	return resp, nil
 }
+
+func (g *GrpcQuestionService) MergeGuest(ctx context.Context, req *pb.MergeGuestRequest) (*pb.MergeGuestResponse, error) {
+	merge, err := g.questionSvc.MergeGuest(ctx, string(req.GuestId), uint(req.UserId))
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.MergeGuestResponse{
+		QuestionsMoved: merge.QuestionsMoved,
+		LikesMoved:     merge.LikesMoved,
+		LikesDropped:   merge.LikesDropped,
+	}, nil
+}
+
+func toProtoQuestion(q *Question) *pb.Question {
+	protoModel := &pb.Model{
+		Id:        uint32(q.ID),
+		CreatedAt: timestamppb.New(q.CreatedAt),
+		UpdatedAt: timestamppb.New(q.UpdatedAt),
+		DeletedAt: func() *timestamppb.Timestamp {
+			if q.DeletedAt.Valid {
+				return timestamppb.New(q.DeletedAt.Time)
+			}
+			return nil
+		}(),
+	}
+
+	protoQuestion := &pb.Question{
+		Model:        protoModel,
+		ProductId:    uint32(q.ProductID),
+		QuestionText: q.QuestionText,
+		AnswerText:   q.AnswerText,
+		LikesCount:   int32(q.LikesCount),
+	}
+
+	if q.UserID != nil {
+		protoQuestion.Author = &pb.Question_UserId{UserId: uint32(*q.UserID)}
+	} else if len(q.GuestID) > 0 {
+		protoQuestion.Author = &pb.Question_GuestId{GuestId: q.GuestID}
+	}
+
+	return protoQuestion
+}
@@ -3,21 +3,34 @@ package question
 import (
 	"net/http"
 	"strconv"
+	"time"
+	"github.com/ShopOnGO/review-service/internal/apperr"
+	"github.com/ShopOnGO/review-service/internal/httpcache"
 	"github.com/gin-gonic/gin"
 )
 
 type QuestionHandler struct {
 	questionSvc *QuestionService
+	cache       httpcache.Policy
 }
 
-func NewQuestionHandler(router *gin.Engine, questionSvc *QuestionService) *QuestionHandler {
-	handler := &QuestionHandler{questionSvc: questionSvc}
+// NewQuestionHandler регистрирует маршруты вопросов. Публичные GET-ответы отдаются с ETag,
+// Last-Modified и Cache-Control по политике cache.
+func NewQuestionHandler(router *gin.Engine, questionSvc *QuestionService, cache httpcache.Policy) *QuestionHandler {
+	handler := &QuestionHandler{questionSvc: questionSvc, cache: cache}
 
 	questionGroup := router.Group("/reviews-service/questions")
 	{
+		questionGroup.GET("/search", handler.SearchQuestions)
+		questionGroup.GET("/user/:user_id", handler.GetQuestionsByUser)
 		questionGroup.GET("/:id", handler.GetQuestionByID)
 	}
 
+	adminGroup := router.Group("/reviews-service/admin/questions")
+	{
+		adminGroup.POST("/:id/restore", handler.RestoreQuestion)
+	}
+
 	return handler
 }
 
@@ -26,9 +39,15 @@ func NewQuestionHandler(router *gin.Engine, questionSvc *QuestionService) *Quest
 // @Description Возвращает вопрос по его уникальному идентификатору
 // @Tags Вопросы
 // @Param id path int true "ID вопроса"
+// @Param If-None-Match header string false "ETag из предыдущего ответа"
+// @Param If-Modified-Since header string false "Last-Modified из предыдущего ответа"
 // @Success 200 {object} question.Question
+// @Header 200 {string} ETag "Версия ответа"
+// @Header 200 {string} Last-Modified "Время последнего изменения"
+// @Success 304 "Не изменилось с прошлого запроса"
 // @Failure 400 {object} gin.H "Некорректный ID"
 // @Failure 404 {object} gin.H "Вопрос не найден"
+// @Failure 500 {object} gin.H "Ошибка получения вопроса"
 // @Router /reviews-service/questions/{id} [get]
 func (h *QuestionHandler) GetQuestionByID(c *gin.Context) {
 	idParam := c.Param("id")
@@ -38,11 +57,114 @@ func (h *QuestionHandler) GetQuestionByID(c *gin.Context) {
 		return
 	}
 
-	question, err := h.questionSvc.GetQuestionByID(uint(id))
+	question, err := h.questionSvc.GetQuestionByID(c.Request.Context(), uint(id))
+	if err != nil {
+		apperr.Respond(c, err, "Ошибка получения вопроса")
+		return
+	}
+
+	httpcache.JSON(c, question, question.UpdatedAt, h.cache.Public())
+}
+
+// SearchQuestions godoc
+// @Summary Поиск по вопросам товара
+// @Description Полнотекстовый поиск (русский и английский) по тексту вопросов и ответов товара. Результаты отсортированы по релевантности, совпадения в сниппетах выделены тегом <b>
+// @Tags Вопросы
+// @Param product_id query int true "ID товара"
+// @Param q query string true "Поисковый запрос"
+// @Param limit query int false "Количество результатов (не более 50)"
+// @Param offset query int false "Смещение"
+// @Param If-None-Match header string false "ETag из предыдущего ответа"
+// @Param If-Modified-Since header string false "Last-Modified из предыдущего ответа"
+// @Success 200 {array} question.QuestionSearchResult
+// @Header 200 {string} ETag "Версия ответа"
+// @Header 200 {string} Last-Modified "Время последнего изменения"
+// @Success 304 "Не изменилось с прошлого запроса"
+// @Failure 400 {object} gin.H "Некорректные параметры поиска"
+// @Failure 500 {object} gin.H "Ошибка поиска вопросов"
+// @Router /reviews-service/questions/search [get]
+func (h *QuestionHandler) SearchQuestions(c *gin.Context) {
+	productID, err := strconv.ParseUint(c.Query("product_id"), 10, 64)
+	if err != nil || productID == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Некорректный ID товара"})
+		return
+	}
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	results, err := h.questionSvc.SearchQuestions(c.Request.Context(), uint(productID), c.Query("q"), limit, offset)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Вопрос не найден"})
+		apperr.Respond(c, err, "Ошибка поиска вопросов")
+		return
+	}
+
+	// Без Last-Modified ответ всё равно проверяется по ETag.
+	lastModified, _ := h.questionSvc.LastModified(c.Request.Context(), uint(productID))
+	httpcache.JSON(c, results, lastModified, h.cache.Public())
+}
+
+// GetQuestionsByUser godoc
+// @Summary Вопросы пользователя
+// @Description Возвращает вопросы пользователя со статусами, новые первыми. Удалённые вопросы возвращаются, только если viewer_id совпадает с автором
+// @Tags Вопросы
+// @Security BearerAuth
+// @Param user_id path int true "ID пользователя"
+// @Param viewer_id query int false "ID пользователя, запрашивающего список; при запросе с токеном берётся из токена"
+// @Param limit query int false "Количество вопросов"
+// @Param offset query int false "Смещение"
+// @Param If-None-Match header string false "ETag из предыдущего ответа"
+// @Success 200 {array} question.UserQuestion
+// @Header 200 {string} ETag "Версия ответа"
+// @Success 304 "Не изменилось с прошлого запроса"
+// @Failure 400 {object} gin.H "Некорректный ID пользователя"
+// @Failure 500 {object} gin.H "Ошибка получения вопросов"
+// @Router /reviews-service/questions/user/{user_id} [get]
+func (h *QuestionHandler) GetQuestionsByUser(c *gin.Context) {
+	userID, err := strconv.ParseUint(c.Param("user_id"), 10, 64)
+	if err != nil || userID == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Некорректный ID пользователя"})
+		return
+	}
+	viewerID, _ := strconv.ParseUint(c.Query("viewer_id"), 10, 64)
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	questions, err := h.questionSvc.GetQuestionsByUser(c.Request.Context(), uint(userID), uint(viewerID), limit, offset)
+	if err != nil {
+		apperr.Respond(c, err, "Ошибка получения вопросов")
+		return
+	}
+
+	// Список зависит от того, кто его запрашивает: удалённые вопросы видит только автор.
+	httpcache.JSON(c, questions, time.Time{}, httpcache.Private)
+}
+
+// RestoreQuestion godoc
+// @Summary Восстановить удалённый вопрос
+// @Description Снимает пометку об удалении с вопроса вместе с ответом и лайками
+// @Tags Администрирование
+// @Security BearerAuth
+// @Param id path int true "ID вопроса"
+// @Success 200 {object} question.Question
+// @Failure 400 {object} gin.H "Некорректный ID"
+// @Failure 401 {object} gin.H "Нужен токен"
+// @Failure 403 {object} gin.H "Нужна роль модератора"
+// @Failure 404 {object} gin.H "Вопрос не найден"
+// @Failure 409 {object} gin.H "Вопрос не удалён"
+// @Failure 500 {object} gin.H "Ошибка восстановления вопроса"
+// @Router /reviews-service/admin/questions/{id}/restore [post]
+func (h *QuestionHandler) RestoreQuestion(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil || id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Некорректный ID"})
+		return
+	}
+
+	question, err := h.questionSvc.RestoreQuestion(c.Request.Context(), uint(id))
+	if err != nil {
+		apperr.Respond(c, err, "Ошибка восстановления вопроса")
 		return
 	}
 
 	c.JSON(http.StatusOK, question)
-}
\ No newline at end of file
+}
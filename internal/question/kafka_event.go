@@ -1,35 +1,48 @@
 package question
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/ShopOnGO/ShopOnGO/pkg/logger"
+	"github.com/ShopOnGO/review-service/internal/metrics"
+	"github.com/ShopOnGO/review-service/internal/tracing"
 )
 
-func HandleQuestionEvent(msg []byte, key string, questionSvc *QuestionService) error {
+func HandleQuestionEvent(ctx context.Context, msg []byte, key string, questionSvc *QuestionService) error {
+	start := time.Now()
 	var base BaseQuestionEvent
 	if err := json.Unmarshal(msg, &base); err != nil {
+		metrics.ObserveKafkaEvent("question", metrics.ActionInvalid, start, err)
 		return fmt.Errorf("ошибка десериализации базового сообщения: %w", err)
 	}
 
-	eventHandlers := map[string]func([]byte, *QuestionService) error{
+	eventHandlers := map[string]func(context.Context, []byte, *QuestionService) error{
 		"create":  		HandleCreateQuestionEvent,
 		"answer":  		HandleAnswerQuestionEvent,
 		"delete":  		HandleDeleteQuestionEvent,
+		"restore":		HandleRestoreQuestionEvent,
 		"addLike": 		HandleAddLikeQuestionEvent,
 		"removeLike":	HandleRemoveLikeQuestionEvent,
+		"mergeGuest":	HandleMergeGuestQuestionEvent,
 	}
 
+	tracing.SetEventAction(ctx, base.Action)
 	handler, exists := eventHandlers[base.Action]
 	if !exists {
-		return fmt.Errorf("неизвестное действие для вопроса: %s", base.Action)
+		err := fmt.Errorf("неизвестное действие для вопроса: %s", base.Action)
+		metrics.ObserveKafkaEvent("question", metrics.ActionUnknown, start, err)
+		return err
 	}
 
-	return handler(msg, questionSvc)
+	err := handler(ctx, msg, questionSvc)
+	metrics.ObserveKafkaEvent("question", base.Action, start, err)
+	return err
 }
 
-func HandleCreateQuestionEvent(msg []byte, questionSvc *QuestionService) error {
+func HandleCreateQuestionEvent(ctx context.Context, msg []byte, questionSvc *QuestionService) error {
 	var event QuestionCreatedEvent
 	if err := json.Unmarshal(msg, &event); err != nil {
 		logger.Errorf("Ошибка десериализации события создания вопроса: %v", err)
@@ -38,7 +51,7 @@ func HandleCreateQuestionEvent(msg []byte, questionSvc *QuestionService) error {
 	logger.Infof("Создаём вопрос: product_id=%d, text=%q, user=%v, guest=%v",
 		event.ProductID, event.QuestionText, event.Author.UserID, event.Author.GuestID)
 
-	_, err := questionSvc.AddQuestion(event.ProductID, event.QuestionText, event.Author.UserID, event.Author.GuestID)
+	_, err := questionSvc.AddQuestion(ctx, event.ProductID, event.QuestionText, event.Author.UserID, event.Author.GuestID)
 	if err != nil {
 		logger.Errorf("Ошибка при создании вопроса: %v", err)
 		return err
@@ -48,7 +61,7 @@ func HandleCreateQuestionEvent(msg []byte, questionSvc *QuestionService) error {
 	return nil
 }
 
-func HandleAnswerQuestionEvent(msg []byte, questionSvc *QuestionService) error {
+func HandleAnswerQuestionEvent(ctx context.Context, msg []byte, questionSvc *QuestionService) error {
 	var event QuestionAnsweredEvent
 	if err := json.Unmarshal(msg, &event); err != nil {
 		logger.Errorf("Ошибка десериализации события ответа на вопрос: %v", err)
@@ -62,7 +75,7 @@ func HandleAnswerQuestionEvent(msg []byte, questionSvc *QuestionService) error {
 		return fmt.Errorf("answer_text отсутствует")
 	}
 
-	if err := questionSvc.AnswerQuestion(event.QuestionID, event.AnswerText); err != nil {
+	if _, err := questionSvc.AnswerQuestion(ctx, event.QuestionID, event.AnswerText, event.Overwrite); err != nil {
 		logger.Errorf("Ошибка при ответе на вопрос: %v", err)
 		return err
 	}
@@ -71,7 +84,7 @@ func HandleAnswerQuestionEvent(msg []byte, questionSvc *QuestionService) error {
 	return nil
 }
 
-func HandleDeleteQuestionEvent(msg []byte, questionSvc *QuestionService) error {
+func HandleDeleteQuestionEvent(ctx context.Context, msg []byte, questionSvc *QuestionService) error {
 	var event QuestionDeletedEvent
 	if err := json.Unmarshal(msg, &event); err != nil {
 		logger.Errorf("Ошибка десериализации события удаления вопроса: %v", err)
@@ -82,7 +95,7 @@ func HandleDeleteQuestionEvent(msg []byte, questionSvc *QuestionService) error {
 		return fmt.Errorf("неверный question_id для удаления")
 	}
 
-	if err := questionSvc.DeleteQuestion(event.QuestionID); err != nil {
+	if err := questionSvc.DeleteQuestion(ctx, event.QuestionID); err != nil {
 		logger.Errorf("Ошибка при удалении вопроса: %v", err)
 		return err
 	}
@@ -91,14 +104,26 @@ func HandleDeleteQuestionEvent(msg []byte, questionSvc *QuestionService) error {
 	return nil
 }
 
-func HandleAddLikeQuestionEvent(msg []byte, questionSvc *QuestionService) error {
-	logger.Infof("Получено сообщение для лайка: %s", string(msg))
+func HandleRestoreQuestionEvent(ctx context.Context, msg []byte, questionSvc *QuestionService) error {
+	var event QuestionRestoredEvent
+	if err := json.Unmarshal(msg, &event); err != nil {
+		logger.Errorf("Ошибка десериализации события восстановления вопроса: %v", err)
+		return err
+	}
 
-	var event struct {
-		QuestionID uint   `json:"question_id"`
-		UserID     uint   `json:"user_id"`
+	if _, err := questionSvc.RestoreQuestion(ctx, event.QuestionID); err != nil {
+		logger.Errorf("Ошибка при восстановлении вопроса: %v", err)
+		return err
 	}
-	
+
+	logger.Infof("Вопрос успешно восстановлен. question_id: %d", event.QuestionID)
+	return nil
+}
+
+func HandleAddLikeQuestionEvent(ctx context.Context, msg []byte, questionSvc *QuestionService) error {
+	logger.Infof("Получено сообщение для лайка: %s", string(msg))
+
+	var event QuestionLikeEvent
 	if err := json.Unmarshal(msg, &event); err != nil {
 		logger.Errorf("Ошибка десериализации события лайка вопроса: %v", err)
 		return err
@@ -107,47 +132,61 @@ func HandleAddLikeQuestionEvent(msg []byte, questionSvc *QuestionService) error
 	if event.QuestionID == 0 {
 		return fmt.Errorf("неверный question_id для лайка")
 	}
-	if event.UserID == 0 {
-		return fmt.Errorf("неверный user_id для лайка")
+	if event.UserID == nil && event.GuestID == nil {
+		return fmt.Errorf("не указан user_id или guest_id для лайка")
 	}
 
-	newLikes, err := questionSvc.AddLikeToQuestion(event.QuestionID, event.UserID)
+	newLikes, err := questionSvc.AddLikeToQuestion(ctx, event.QuestionID, event.UserID, event.GuestID)
 	if err != nil {
 		logger.Errorf("Ошибка при добавлении лайка к вопросу: %v", err)
 		return err
 	}
 
-	logger.Infof("Лайк успешно добавлен. question_id: %d, user_id: %d, new_likes: %d", event.QuestionID, event.UserID, newLikes)
+	logger.Infof("Лайк успешно добавлен. question_id: %d, user=%v, guest=%v, new_likes: %d", event.QuestionID, event.UserID, event.GuestID, newLikes)
 	return nil
 }
 
-func HandleRemoveLikeQuestionEvent(msg []byte, questionSvc *QuestionService) error {
+func HandleRemoveLikeQuestionEvent(ctx context.Context, msg []byte, questionSvc *QuestionService) error {
 	logger.Infof("Получено сообщение для удаления лайка: %s", string(msg))
 
-	var event struct {
-		QuestionID uint   `json:"question_id"`
-		UserID     uint   `json:"user_id"`
-	}
-	
+	var event QuestionLikeEvent
 	if err := json.Unmarshal(msg, &event); err != nil {
 		logger.Errorf("Ошибка десериализации события удаления лайка на вопроса: %v", err)
 		return err
 	}
-	logger.Infof("Удаляем лайк у вопроса: review_id=%d, от user_id=%d", event.QuestionID, event.UserID)
+	logger.Infof("Удаляем лайк у вопроса: question_id=%d, user=%v, guest=%v", event.QuestionID, event.UserID, event.GuestID)
 
 	if event.QuestionID == 0 {
 		return fmt.Errorf("неверный question_id для лайка")
 	}
-	if event.UserID == 0 {
-		return fmt.Errorf("неверный user_id для лайка")
+	if event.UserID == nil && event.GuestID == nil {
+		return fmt.Errorf("не указан user_id или guest_id для лайка")
 	}
 
-	newLikes, err := questionSvc.RemoveLikeToQuestion(event.QuestionID, event.UserID)
+	newLikes, err := questionSvc.RemoveLikeToQuestion(ctx, event.QuestionID, event.UserID, event.GuestID)
 	if err != nil {
 		logger.Errorf("Ошибка при удалении лайка к вопросу: %v", err)
 		return err
 	}
 
-	logger.Infof("Лайк успешно удален. question_id: %d, user_id: %d, new_likes: %d", event.QuestionID, event.UserID, newLikes)
+	logger.Infof("Лайк успешно удален. question_id: %d, user=%v, guest=%v, new_likes: %d", event.QuestionID, event.UserID, event.GuestID, newLikes)
+	return nil
+}
+
+func HandleMergeGuestQuestionEvent(ctx context.Context, msg []byte, questionSvc *QuestionService) error {
+	var event GuestMergeEvent
+	if err := json.Unmarshal(msg, &event); err != nil {
+		logger.Errorf("Ошибка десериализации события объединения гостя: %v", err)
+		return err
+	}
+
+	merge, err := questionSvc.MergeGuest(ctx, event.GuestID, event.UserID)
+	if err != nil {
+		logger.Errorf("Ошибка при объединении гостя с пользователем: %v", err)
+		return err
+	}
+
+	logger.Infof("Гость объединён с пользователем. user_id: %d, questions_moved: %d, likes_moved: %d, likes_dropped: %d",
+		event.UserID, merge.QuestionsMoved, merge.LikesMoved, merge.LikesDropped)
 	return nil
 }
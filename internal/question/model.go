@@ -1,6 +1,9 @@
 package question
 
 import (
+	"bytes"
+	"time"
+
 	"gorm.io/gorm"
 )
 
@@ -13,3 +16,57 @@ type Question struct {
 	AnswerText      string    `json:"answer_text"`
 	LikesCount		int       `gorm:"default:0" json:"likes_count"`
 }
+
+// IsAuthor сообщает, задан ли вопрос указанным пользователем или гостем.
+func (q *Question) IsAuthor(userID *uint, guestID []byte) bool {
+	if userID != nil {
+		return q.UserID != nil && *q.UserID == *userID
+	}
+	return len(guestID) > 0 && bytes.Equal(q.GuestID, guestID)
+}
+
+// QuestionLike — лайк вопроса от пользователя или гостя. От одного автора — не больше одного лайка,
+// LikesCount в Question пересчитывается вместе с ним.
+type QuestionLike struct {
+	ID         uint      `gorm:"primarykey" json:"id"`
+	QuestionID uint      `gorm:"not null;uniqueIndex:idx_question_likes_question_user;uniqueIndex:idx_question_likes_question_guest" json:"question_id"`
+	UserID     *uint     `gorm:"uniqueIndex:idx_question_likes_question_user;index" json:"user_id"`
+	GuestID    []byte    `gorm:"type:bytea;uniqueIndex:idx_question_likes_question_guest;index" json:"guest_id"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// GuestMerge — запись аудита о переносе вопросов и лайков гостя на зарегистрированного пользователя.
+// Гость может быть объединён только с одним пользователем; повторное объединение с тем же
+// пользователем переносит то, что появилось после предыдущего, и накапливает счётчики.
+type GuestMerge struct {
+	ID             uint      `gorm:"primarykey" json:"id"`
+	GuestID        []byte    `gorm:"type:bytea;not null;uniqueIndex" json:"guest_id"`
+	UserID         uint      `gorm:"not null;index" json:"user_id"`
+	QuestionsMoved int64     `gorm:"not null;default:0" json:"questions_moved"`
+	LikesMoved     int64     `gorm:"not null;default:0" json:"likes_moved"`
+	LikesDropped   int64     `gorm:"not null;default:0" json:"likes_dropped"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// Статусы вопроса в личном кабинете автора.
+const (
+	StatusAwaitingAnswer = "awaiting_answer"
+	StatusAnswered       = "answered"
+	StatusDeleted        = "deleted"
+)
+
+// UserQuestion — вопрос в списке «мои вопросы» вместе с его статусом.
+type UserQuestion struct {
+	Question
+	Status string `json:"status"`
+}
+
+// QuestionSearchResult — вопрос, найденный полнотекстовым поиском, с релевантностью
+// и подсвеченными фрагментами вопроса и ответа.
+type QuestionSearchResult struct {
+	Question
+	Rank            float32 `json:"rank"`
+	QuestionSnippet string  `json:"question_snippet"`
+	AnswerSnippet   string  `json:"answer_snippet"`
+}
@@ -24,9 +24,29 @@ type QuestionAnsweredEvent struct {
 	Action     string `json:"action"`
 	QuestionID uint   `json:"question_id"`
 	AnswerText string `json:"answer_text"`
+	// Overwrite — заменить существующий ответ
+	Overwrite bool `json:"overwrite,omitempty"`
 }
 
 type QuestionDeletedEvent struct {
 	Action     string `json:"action"`
 	QuestionID uint   `json:"question_id"`
-}
\ No newline at end of file
+}
+
+type QuestionRestoredEvent struct {
+	Action     string `json:"action"`
+	QuestionID uint   `json:"question_id"`
+}
+
+type QuestionLikeEvent struct {
+	Action     string  `json:"action"`
+	QuestionID uint    `json:"question_id"`
+	UserID     *uint   `json:"user_id,omitempty"`
+	GuestID    *string `json:"guest_id,omitempty"`
+}
+
+type GuestMergeEvent struct {
+	Action  string `json:"action"`
+	GuestID string `json:"guest_id"`
+	UserID  uint   `json:"user_id"`
+}
@@ -1,13 +1,25 @@
 package question
 
 import (
+	"context"
+	"database/sql"
 	"errors"
 	"fmt"
+	"time"
 
+	"github.com/ShopOnGO/review-service/internal/apperr"
 	"github.com/ShopOnGO/review-service/pkg/db"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// ErrGuestMergeConflict — гость уже объединён с другим пользователем.
+var ErrGuestMergeConflict = apperr.Conflict("GUEST_ALREADY_MERGED", "guest merge conflict")
+
+// guestMergeLockSpace — первый ключ pg_advisory_xact_lock(int, int), под которым объединяется гость
+// (второй — хэш guest_id): одновременные объединения одного гостя выполняются по очереди.
+const guestMergeLockSpace = 727002
+
 type QuestionRepository struct {
 	Db *db.Db
 }
@@ -18,60 +30,72 @@ func NewQuestionRepository(db *db.Db) *QuestionRepository {
 	}
 }
 
-func (r *QuestionRepository) CreateQuestion(question *Question) error {
-	return r.Db.Create(question).Error
+func (r *QuestionRepository) CreateQuestion(ctx context.Context, question *Question) error {
+	return r.Db.WithContext(ctx).Create(question).Error
 }
 
-func (r *QuestionRepository) GetQuestionsByProductID(productID uint) ([]Question, error) {
+func (r *QuestionRepository) GetQuestionsByProductID(ctx context.Context, productID uint) ([]Question, error) {
 	var questions []Question
-	err := r.Db.Where("product_id = ?", productID).Find(&questions).Error
+	err := r.Db.WithContext(ctx).Where("product_id = ?", productID).Find(&questions).Error
 	if err != nil {
 		return nil, err
 	}
 	return questions, nil
 }
 
-func (r *QuestionRepository) GetQuestionByID(id uint) (*Question, error) {
+func (r *QuestionRepository) GetQuestionByID(ctx context.Context, id uint) (*Question, error) {
 	var question Question
-	err := r.Db.First(&question, id).Error
+	err := r.Db.WithContext(ctx).First(&question, id).Error
 	if err != nil {
 		return nil, err
 	}
 	return &question, nil
 }
 
-func (r *QuestionRepository) getLikesCount(questionID uint) (uint, error) {
-    var likesCount uint
-    err := r.Db.Raw(`SELECT likes_count FROM questions WHERE id = ?`, questionID).Scan(&likesCount).Error
-    if err != nil {
-        if errors.Is(err, gorm.ErrRecordNotFound) {
-            return 0, fmt.Errorf("question not found")
-        }
-        return 0, err
-    }
+// GetQuestionByIDUnscoped возвращает вопрос по ID, в том числе мягко удалённый.
+func (r *QuestionRepository) GetQuestionByIDUnscoped(ctx context.Context, id uint) (*Question, error) {
+	var question Question
+	err := r.Db.WithContext(ctx).Unscoped().First(&question, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &question, nil
+}
 
-    return likesCount, nil
+func (r *QuestionRepository) UpdateQuestion(ctx context.Context, question *Question) error {
+	return r.Db.WithContext(ctx).Save(question).Error
 }
 
-func (r *QuestionRepository) UpdateQuestion(question *Question) error {
-	return r.Db.Save(question).Error
+// UpdateAnswer сохраняет ответ на вопрос. Без overwrite ответ записывается, только если
+// вопрос ещё не отвечен; false — ответ уже был.
+func (r *QuestionRepository) UpdateAnswer(ctx context.Context, questionID uint, answer string, overwrite bool) (bool, error) {
+	query := r.Db.WithContext(ctx).Model(&Question{}).Where("id = ?", questionID)
+	if !overwrite {
+		query = query.Where("answer_text IS NULL OR answer_text = ''")
+	}
+	res := query.Update("answer_text", answer)
+	return res.RowsAffected > 0, res.Error
 }
 
-func (r *QuestionRepository) UpdateAnswer(questionID uint, answer string) error {
-	return r.Db.Model(&Question{}).Where("id = ?", questionID).Update("answer_text", answer).Error
+func (r *QuestionRepository) DeleteQuestion(ctx context.Context, question *Question) error {
+	return r.Db.WithContext(ctx).Delete(question).Error
 }
 
-func (r *QuestionRepository) DeleteQuestion(question *Question) error {
-	return r.Db.Delete(question).Error
+func (r *QuestionRepository) DeleteQuestionByID(ctx context.Context, id uint) error {
+	return r.Db.WithContext(ctx).Delete(&Question{}, id).Error
 }
 
-func (r *QuestionRepository) DeleteQuestionByID(id uint) error {
-	return r.Db.Delete(&Question{}, id).Error
+// RestoreQuestion снимает пометку об удалении. Возвращает false, если вопрос уже не был удалён.
+func (r *QuestionRepository) RestoreQuestion(ctx context.Context, id uint) (bool, error) {
+	res := r.Db.WithContext(ctx).Unscoped().Model(&Question{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Update("deleted_at", nil)
+	return res.RowsAffected > 0, res.Error
 }
 
-func (r *QuestionRepository) GetQuestionsByProductIDPaginated(productID uint, limit, offset int) ([]*Question, error) {
+func (r *QuestionRepository) GetQuestionsByProductIDPaginated(ctx context.Context, productID uint, limit, offset int) ([]*Question, error) {
     var questions []*Question
-    result := r.Db.
+    result := r.Db.WithContext(ctx).
         Where("product_id = ?", productID).
         Limit(limit).
         Offset(offset).
@@ -81,49 +105,184 @@ func (r *QuestionRepository) GetQuestionsByProductIDPaginated(productID uint, li
     return questions, result.Error
 }
 
-func (r *QuestionRepository) IncrementLikes(questionID uint) (uint, error) {
-    var newLikes uint
+// GetQuestionsByUserIDPaginated возвращает вопросы пользователя, новые первыми.
+// withDeleted включает мягко удалённые вопросы.
+func (r *QuestionRepository) GetQuestionsByUserIDPaginated(ctx context.Context, userID uint, limit, offset int, withDeleted bool) ([]*Question, error) {
+    query := r.Db.WithContext(ctx)
+    if withDeleted {
+        query = query.Unscoped()
+    }
+
+    var questions []*Question
+    result := query.
+        Where("user_id = ?", userID).
+        Limit(limit).
+        Offset(offset).
+        Order("created_at DESC").
+        Find(&questions)
+
+    return questions, result.Error
+}
+
+func (r *QuestionRepository) SearchQuestionsByProductID(ctx context.Context, productID uint, query string, limit, offset int) ([]*QuestionSearchResult, error) {
+    var results []*QuestionSearchResult
+    err := r.Db.WithContext(ctx).Raw(`
+        SELECT questions.*,
+            ts_rank(search_vector, q.query) AS rank,
+            ts_headline('russian', question_text, q.query, 'StartSel=<b>, StopSel=</b>, HighlightAll=true') AS question_snippet,
+            ts_headline('russian', coalesce(answer_text, ''), q.query, 'StartSel=<b>, StopSel=</b>, MaxFragments=2, MaxWords=25, MinWords=5') AS answer_snippet
+        FROM questions,
+            (SELECT websearch_to_tsquery('russian', ?) || websearch_to_tsquery('english', ?) AS query) q
+        WHERE product_id = ?
+            AND deleted_at IS NULL
+            AND search_vector @@ q.query
+        ORDER BY rank DESC, created_at DESC
+        LIMIT ? OFFSET ?
+    `, query, query, productID, limit, offset).Scan(&results).Error
 
-    err := r.Db.Raw(`
-        UPDATE questions
-        SET likes_count = likes_count + 1
-        WHERE id = ?
-        RETURNING likes_count
-    `, questionID).Scan(&newLikes).Error
+    return results, err
+}
 
-    if err != nil {
-        if errors.Is(err, gorm.ErrRecordNotFound) || newLikes == 0 {
-            return 0, fmt.Errorf("question not found")
+// LastModified возвращает время последнего изменения вопросов товара с учётом удалённых;
+// нулевое время — вопросов нет.
+func (r *QuestionRepository) LastModified(ctx context.Context, productID uint) (time.Time, error) {
+    var lastModified sql.NullTime
+    err := r.Db.WithContext(ctx).Raw(`
+        SELECT MAX(GREATEST(updated_at, deleted_at)) FROM questions WHERE product_id = ?
+    `, productID).Row().Scan(&lastModified)
+    return lastModified.Time, err
+}
+
+// AddLike сохраняет лайк пользователя или гостя и увеличивает счётчик. Повторный лайк
+// того же автора ничего не меняет. Возвращает актуальное количество лайков.
+func (r *QuestionRepository) AddLike(ctx context.Context, questionID uint, userID *uint, guestID []byte) (uint, error) {
+    var question Question
+    err := r.Db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+        if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&question, questionID).Error; err != nil {
+            if errors.Is(err, gorm.ErrRecordNotFound) {
+                return apperr.NotFound("question", questionID)
+            }
+            return err
         }
+
+        res := tx.Clauses(clause.OnConflict{DoNothing: true}).
+            Create(&QuestionLike{QuestionID: questionID, UserID: userID, GuestID: guestID})
+        if res.Error != nil {
+            return res.Error
+        }
+        if res.RowsAffected == 0 {
+            return nil
+        }
+        return applyLikesDelta(tx, &question, 1)
+    })
+    if err != nil {
         return 0, err
     }
-
-    return newLikes, nil
+    return uint(question.LikesCount), nil
 }
 
-func (r *QuestionRepository) DecrementLikes(questionID uint) (uint, error) {
-    currentLikes, err := r.getLikesCount(questionID)
+// RemoveLike удаляет лайк пользователя или гостя и уменьшает счётчик.
+func (r *QuestionRepository) RemoveLike(ctx context.Context, questionID uint, userID *uint, guestID []byte) (uint, error) {
+    var question Question
+    err := r.Db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+        if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&question, questionID).Error; err != nil {
+            if errors.Is(err, gorm.ErrRecordNotFound) {
+                return apperr.NotFound("question", questionID)
+            }
+            return err
+        }
+
+        res := likeAuthorScope(tx.Where("question_id = ?", questionID), userID, guestID).Delete(&QuestionLike{})
+        if res.Error != nil {
+            return res.Error
+        }
+        if res.RowsAffected == 0 {
+            return apperr.NotFound("question_like", questionID)
+        }
+        return applyLikesDelta(tx, &question, -1)
+    })
     if err != nil {
         return 0, err
     }
-    if currentLikes < 1 {
-        return 0, fmt.Errorf("likes count cannot be less than 1")
-    }
+    return uint(question.LikesCount), nil
+}
 
-	var newLikes uint
-    err = r.Db.Raw(`
-        UPDATE questions
-        SET likes_count = likes_count - 1
-        WHERE id = ?
-        RETURNING likes_count
-    `, questionID).Scan(&newLikes).Error
+// MergeGuest атомарно переносит вопросы и лайки гостя на пользователя и пишет запись аудита.
+// Лайки гостя к вопросам, которые пользователь уже лайкнул сам, удаляются, чтобы не считать их дважды.
+func (r *QuestionRepository) MergeGuest(ctx context.Context, guestID []byte, userID uint) (*GuestMerge, error) {
+    var merge GuestMerge
+    err := r.Db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+        // Записи аудита ещё может не быть, и FOR UPDATE её не заблокирует: без общей блокировки
+        // второе одновременное объединение упало бы на уникальном индексе guest_merges.
+        err := tx.Exec("SELECT pg_advisory_xact_lock(?, hashtext(encode(?::bytea, 'hex')))", guestMergeLockSpace, guestID).Error
+        if err != nil {
+            return err
+        }
 
-    if err != nil {
-        if errors.Is(err, gorm.ErrRecordNotFound) || newLikes == 0 {
-            return 0, fmt.Errorf("question not found")
+        err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("guest_id = ?", guestID).First(&merge).Error
+        switch {
+        case errors.Is(err, gorm.ErrRecordNotFound):
+            merge = GuestMerge{GuestID: guestID, UserID: userID}
+        case err != nil:
+            return err
+        case merge.UserID != userID:
+            return fmt.Errorf("%w: guest already merged into user %d", ErrGuestMergeConflict, merge.UserID)
         }
-        return 0, err
+
+        res := tx.Unscoped().Model(&Question{}).
+            Where("guest_id = ?", guestID).
+            Updates(map[string]interface{}{"user_id": userID, "guest_id": nil})
+        if res.Error != nil {
+            return res.Error
+        }
+        merge.QuestionsMoved += res.RowsAffected
+
+        var duplicated []uint
+        err = tx.Model(&QuestionLike{}).
+            Where("guest_id = ? AND question_id IN (?)", guestID,
+                tx.Model(&QuestionLike{}).Select("question_id").Where("user_id = ?", userID)).
+            Pluck("question_id", &duplicated).Error
+        if err != nil {
+            return err
+        }
+        if len(duplicated) > 0 {
+            if err := tx.Where("guest_id = ? AND question_id IN ?", guestID, duplicated).Delete(&QuestionLike{}).Error; err != nil {
+                return err
+            }
+            err := tx.Model(&Question{}).Unscoped().
+                Where("id IN ?", duplicated).
+                Update("likes_count", gorm.Expr("GREATEST(likes_count - 1, 0)")).Error
+            if err != nil {
+                return err
+            }
+            merge.LikesDropped += int64(len(duplicated))
+        }
+
+        res = tx.Model(&QuestionLike{}).
+            Where("guest_id = ?", guestID).
+            Updates(map[string]interface{}{"user_id": userID, "guest_id": nil})
+        if res.Error != nil {
+            return res.Error
+        }
+        merge.LikesMoved += res.RowsAffected
+
+        return tx.Save(&merge).Error
+    })
+    if err != nil {
+        return nil, err
     }
+    return &merge, nil
+}
 
-    return newLikes, nil
-}
\ No newline at end of file
+func likeAuthorScope(tx *gorm.DB, userID *uint, guestID []byte) *gorm.DB {
+    if userID != nil {
+        return tx.Where("user_id = ?", *userID)
+    }
+    return tx.Where("guest_id = ?", guestID)
+}
+
+func applyLikesDelta(tx *gorm.DB, question *Question, delta int) error {
+    return tx.Model(question).
+        Clauses(clause.Returning{Columns: []clause.Column{{Name: "likes_count"}, {Name: "updated_at"}}}).
+        Update("likes_count", gorm.Expr("GREATEST(likes_count + ?, 0)", delta)).Error
+}
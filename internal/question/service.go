@@ -1,28 +1,54 @@
 package question
 
 import (
-	"fmt"
+	"context"
+	"errors"
+	"strings"
+	"time"
 
 	"github.com/ShopOnGO/ShopOnGO/pkg/logger"
+	"github.com/ShopOnGO/review-service/internal/apperr"
+	"github.com/ShopOnGO/review-service/internal/auth"
+	"github.com/ShopOnGO/review-service/internal/ratelimit"
+	"gorm.io/gorm"
 )
 
+const (
+	maxSearchQueryLength = 200
+	maxSearchLimit       = 50
+)
+
+// ErrQuestionNotDeleted — попытка восстановить вопрос, который не удалён.
+var ErrQuestionNotDeleted = apperr.Conflict("QUESTION_NOT_DELETED", "question is not deleted")
+
+// ErrQuestionAlreadyAnswered — ответ на уже отвеченный вопрос без явной перезаписи.
+var ErrQuestionAlreadyAnswered = apperr.Conflict("QUESTION_ALREADY_ANSWERED", "question is already answered")
+
 type QuestionService struct {
 	QuestionRepository *QuestionRepository
+	// Limiter ограничивает частоту вопросов и лайков; nil — без ограничений
+	Limiter *ratelimit.Limiter
 }
 
-func NewQuestionService(questionRepo *QuestionRepository) *QuestionService {
+func NewQuestionService(questionRepo *QuestionRepository, limiter *ratelimit.Limiter) *QuestionService {
 	return &QuestionService{
 		QuestionRepository: questionRepo,
+		Limiter:            limiter,
 	}
 }
 
-func (s *QuestionService) AddQuestion(productID uint, questionText string, userID *uint, guestID *string) (*Question, error) {
+// AddQuestion создаёт вопрос от пользователя (userID) или гостя (guestID) — ровно один из них должен быть задан.
+// Пользователь с токеном всегда пишет от своего имени (auth.Author).
+func (s *QuestionService) AddQuestion(ctx context.Context, productID uint, questionText string, userID *uint, guestID *string) (*Question, error) {
 	if productID == 0 || questionText == "" {
-		return nil, fmt.Errorf("invalid input parameters")
+		return nil, apperr.InvalidArgument("", "invalid input parameters")
+	}
+	userID, guestIDBytes, err := resolveAuthor(ctx, userID, guestID)
+	if err != nil {
+		return nil, err
 	}
-	var guestIDBytes []byte
-	if guestID != nil {
-		guestIDBytes = []byte(*guestID)
+	if err := s.Limiter.Allow(ctx, ratelimit.ActionQuestion, userID, guestIDBytes); err != nil {
+		return nil, err
 	}
 
 	question := &Question{
@@ -32,7 +58,7 @@ func (s *QuestionService) AddQuestion(productID uint, questionText string, userI
         GuestID:        guestIDBytes,
 	}
 
-	if err := s.QuestionRepository.CreateQuestion(question); err != nil {
+	if err := s.QuestionRepository.CreateQuestion(ctx, question); err != nil {
 		logger.Errorf("Error creating question: %v", err)
 		return nil, err
 	}
@@ -40,54 +66,151 @@ func (s *QuestionService) AddQuestion(productID uint, questionText string, userI
 	return question, nil
 }
 
-func (s *QuestionService) GetQuestionByID(questionID uint) (*Question, error) {
+func (s *QuestionService) GetQuestionByID(ctx context.Context, questionID uint) (*Question, error) {
 	if questionID == 0 {
-		return nil, fmt.Errorf("неверный ID вопроса")
+		return nil, apperr.InvalidArgument("question_id", "неверный ID вопроса")
+	}
+
+	question, err := s.QuestionRepository.GetQuestionByID(ctx, questionID)
+	if err != nil {
+		return nil, questionNotFound(err, questionID)
 	}
+	return question, nil
+}
+
 
-	question, err := s.QuestionRepository.GetQuestionByID(questionID)
+// CheckAuthor возвращает вопрос, если его автор — указанный пользователь или гость. Автор
+// определяется через auth.Author. Без автора проверку пропускают только модератор и внутренний
+// сервис с токеном; вызов без токена обязан указать автора, иначе он может править чужой вопрос.
+func (s *QuestionService) CheckAuthor(ctx context.Context, questionID uint, userID *uint, guestID []byte) (*Question, error) {
+	question, err := s.GetQuestionByID(ctx, questionID)
 	if err != nil {
-		logger.Errorf("Ошибка при получении вопроса: %v", err)
 		return nil, err
 	}
+	if (userID == nil || *userID == 0) && len(guestID) == 0 {
+		if p, ok := auth.FromContext(ctx); ok && (p.Admin || p.Service) {
+			return question, nil
+		}
+	}
+	userID, guestID, err = auth.Author(ctx, userID, guestID)
+	if err != nil {
+		return nil, err
+	}
+	if !question.IsAuthor(userID, guestID) {
+		logger.Warnf("Question %d is not asked by user %v / guest %q", questionID, userID, guestID)
+		return nil, apperr.PermissionDenied("NOT_QUESTION_AUTHOR", "question %d is not asked by the requester", questionID)
+	}
 	return question, nil
 }
 
+// UpdateQuestion меняет текст вопроса; ответ, если он уже есть, сохраняется.
+func (s *QuestionService) UpdateQuestion(ctx context.Context, questionID uint, questionText string) (*Question, error) {
+	if questionID == 0 || questionText == "" {
+		return nil, apperr.InvalidArgument("", "invalid input parameters")
+	}
+	question, err := s.QuestionRepository.GetQuestionByID(ctx, questionID)
+	if err != nil {
+		return nil, questionNotFound(err, questionID)
+	}
+
+	question.QuestionText = questionText
+	if err := s.QuestionRepository.UpdateQuestion(ctx, question); err != nil {
+		logger.Errorf("Error updating question %d: %v", questionID, err)
+		return nil, err
+	}
+	return question, nil
+}
 
-func (s *QuestionService) AnswerQuestion(questionID uint, answerText string) error {
+// AnswerQuestion сохраняет ответ на вопрос. Отвечают только привилегированные вызовы (продавец
+// через сервис, модератор); уже отвеченный вопрос переписывается только при overwrite.
+func (s *QuestionService) AnswerQuestion(ctx context.Context, questionID uint, answerText string, overwrite bool) (*Question, error) {
+	if err := auth.RequirePrivileged(ctx); err != nil {
+		return nil, err
+	}
 	if questionID == 0 || answerText == "" {
-		return fmt.Errorf("invalid input parameters")
+		return nil, apperr.InvalidArgument("", "invalid input parameters")
 	}
-	_, err := s.QuestionRepository.GetQuestionByID(questionID)
+	question, err := s.QuestionRepository.GetQuestionByID(ctx, questionID)
 	if err != nil {
-		return fmt.Errorf("question with id %d not found", questionID)
+		return nil, questionNotFound(err, questionID)
+	}
+
+	if question.AnswerText != "" && !overwrite {
+		return nil, ErrQuestionAlreadyAnswered
 	}
 
-	if err := s.QuestionRepository.UpdateAnswer(questionID, answerText); err != nil {
+	updated, err := s.QuestionRepository.UpdateAnswer(ctx, questionID, answerText, overwrite)
+	if err != nil {
 		logger.Errorf("Error answering question: %v", err)
-		return err
+		return nil, err
 	}
-	return nil
+	if !updated {
+		// ответ успели записать между чтением вопроса и обновлением
+		return nil, ErrQuestionAlreadyAnswered
+	}
+	question.AnswerText = answerText
+	return question, nil
 }
 
-func (s *QuestionService) DeleteQuestion(questionID uint) error {
+func (s *QuestionService) DeleteQuestion(ctx context.Context, questionID uint) error {
 	if questionID == 0 {
-		return fmt.Errorf("invalid question ID")
+		return apperr.InvalidArgument("question_id", "invalid question ID")
 	}
-	if err := s.QuestionRepository.DeleteQuestionByID(questionID); err != nil {
+	if _, err := s.QuestionRepository.GetQuestionByID(ctx, questionID); err != nil {
+		return questionNotFound(err, questionID)
+	}
+	if err := s.QuestionRepository.DeleteQuestionByID(ctx, questionID); err != nil {
 		logger.Errorf("Error deleting question: %v", err)
 		return err
 	}
 	return nil
 }
 
+// RestoreQuestion восстанавливает мягко удалённый вопрос вместе с ответом и лайками.
+func (s *QuestionService) RestoreQuestion(ctx context.Context, questionID uint) (*Question, error) {
+	if err := auth.RequirePrivileged(ctx); err != nil {
+		return nil, err
+	}
+	if questionID == 0 {
+		return nil, apperr.InvalidArgument("question_id", "invalid question ID")
+	}
+
+	question, err := s.QuestionRepository.GetQuestionByIDUnscoped(ctx, questionID)
+	if err != nil {
+		return nil, questionNotFound(err, questionID)
+	}
+	if !question.DeletedAt.Valid {
+		return nil, ErrQuestionNotDeleted
+	}
+
+	restored, err := s.QuestionRepository.RestoreQuestion(ctx, questionID)
+	if err != nil {
+		logger.Errorf("Error restoring question %d: %v", questionID, err)
+		return nil, err
+	}
+	if !restored {
+		return nil, ErrQuestionNotDeleted
+	}
+	question.DeletedAt = gorm.DeletedAt{}
+
+	return question, nil
+}
+
+// questionNotFound заменяет gorm.ErrRecordNotFound ошибкой сервиса, остальные ошибки логирует как есть.
+func questionNotFound(err error, questionID uint) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return apperr.NotFound("question", questionID)
+	}
+	logger.Errorf("Ошибка при получении вопроса %d: %v", questionID, err)
+	return err
+}
 
-func (s *QuestionService) GetQuestionsForProduct(productID uint, limit, offset int) ([]*Question, error) {
+func (s *QuestionService) GetQuestionsForProduct(ctx context.Context, productID uint, limit, offset int) ([]*Question, error) {
     if productID == 0 {
-        return nil, fmt.Errorf("productID is required")
+        return nil, apperr.InvalidArgument("product_id", "productID is required")
     }
 
-    questions, err := s.QuestionRepository.GetQuestionsByProductIDPaginated(productID, limit, offset)
+    questions, err := s.QuestionRepository.GetQuestionsByProductIDPaginated(ctx, productID, limit, offset)
     if err != nil {
         logger.Errorf("Error getting paginated questions for product %d: %v", productID, err)
         return nil, err
@@ -96,32 +219,140 @@ func (s *QuestionService) GetQuestionsForProduct(productID uint, limit, offset i
     return questions, nil
 }
 
-func (s *QuestionService) AddLikeToQuestion(questionID, userID uint) (uint, error) {
-    if questionID == 0 {
-        return 0, fmt.Errorf("invalid question id")
+// GetQuestionsByUser возвращает вопросы пользователя userID. Удалённые вопросы видны,
+// только если список запрашивает сам автор (viewerID == userID).
+func (s *QuestionService) GetQuestionsByUser(ctx context.Context, userID, viewerID uint, limit, offset int) ([]*UserQuestion, error) {
+    if userID == 0 {
+        return nil, apperr.InvalidArgument("user_id", "userID is required")
+    }
+    isOwner := auth.Viewer(ctx, viewerID) == userID
+
+    questions, err := s.QuestionRepository.GetQuestionsByUserIDPaginated(ctx, userID, limit, offset, isOwner)
+    if err != nil {
+        logger.Errorf("Error getting questions of user %d: %v", userID, err)
+        return nil, err
+    }
+
+    result := make([]*UserQuestion, 0, len(questions))
+    for _, q := range questions {
+        result = append(result, &UserQuestion{Question: *q, Status: questionStatus(q)})
+    }
+    return result, nil
+}
+
+func questionStatus(q *Question) string {
+    switch {
+    case q.DeletedAt.Valid:
+        return StatusDeleted
+    case q.AnswerText != "":
+        return StatusAnswered
+    default:
+        return StatusAwaitingAnswer
+    }
+}
+
+func (s *QuestionService) SearchQuestions(ctx context.Context, productID uint, query string, limit, offset int) ([]*QuestionSearchResult, error) {
+    if productID == 0 {
+        return nil, apperr.InvalidArgument("product_id", "productID is required")
+    }
+    query = strings.TrimSpace(query)
+    if query == "" {
+        return nil, apperr.InvalidArgument("q", "search query is required")
+    }
+    if len([]rune(query)) > maxSearchQueryLength {
+        return nil, apperr.InvalidArgument("q", "search query is too long")
+    }
+    if limit <= 0 || limit > maxSearchLimit {
+        limit = maxSearchLimit
+    }
+    if offset < 0 {
+        offset = 0
+    }
+
+    results, err := s.QuestionRepository.SearchQuestionsByProductID(ctx, productID, query, limit, offset)
+    if err != nil {
+        logger.Errorf("Error searching questions for product %d: %v", productID, err)
+        return nil, err
+    }
+
+    return results, nil
+}
+
+// LastModified возвращает время последнего изменения вопросов товара — Last-Modified списков товара.
+func (s *QuestionService) LastModified(ctx context.Context, productID uint) (time.Time, error) {
+    lastModified, err := s.QuestionRepository.LastModified(ctx, productID)
+    if err != nil {
+        logger.Errorf("Error getting last modification time of questions for product %d: %v", productID, err)
+        return time.Time{}, err
     }
+    return lastModified, nil
+}
 
-    // Можно здесь добавить дополнительную логику:
-    // – проверка, что пользователь ещё не лайкал (если нужна такая фича)
+func (s *QuestionService) AddLikeToQuestion(ctx context.Context, questionID uint, userID *uint, guestID *string) (uint, error) {
+    if questionID == 0 {
+        return 0, apperr.InvalidArgument("question_id", "invalid question id")
+    }
+    userID, guestIDBytes, err := resolveAuthor(ctx, userID, guestID)
+    if err != nil {
+        return 0, err
+    }
+    if err := s.Limiter.Allow(ctx, ratelimit.ActionLike, userID, guestIDBytes); err != nil {
+        return 0, err
+    }
 
-    newCount, err := s.QuestionRepository.IncrementLikes(questionID)
+    newCount, err := s.QuestionRepository.AddLike(ctx, questionID, userID, guestIDBytes)
     if err != nil {
         return 0, err
     }
     return newCount, nil
 }
 
-func (s *QuestionService) RemoveLikeToQuestion(questionID, userID uint) (uint, error) {
+func (s *QuestionService) RemoveLikeToQuestion(ctx context.Context, questionID uint, userID *uint, guestID *string) (uint, error) {
     if questionID == 0 {
-        return 0, fmt.Errorf("invalid question id")
+        return 0, apperr.InvalidArgument("question_id", "invalid question id")
+    }
+    userID, guestIDBytes, err := resolveAuthor(ctx, userID, guestID)
+    if err != nil {
+        return 0, err
     }
 
-    // Можно здесь добавить дополнительную логику:
-    // – проверка, что пользователь ещё не лайкал (если нужна такая фича)
-
-    newCount, err := s.QuestionRepository.DecrementLikes(questionID)
+    newCount, err := s.QuestionRepository.RemoveLike(ctx, questionID, userID, guestIDBytes)
     if err != nil {
         return 0, err
     }
     return newCount, nil
-}
\ No newline at end of file
+}
+
+// MergeGuest переносит вопросы и лайки гостя на зарегистрировавшегося пользователя.
+func (s *QuestionService) MergeGuest(ctx context.Context, guestID string, userID uint) (*GuestMerge, error) {
+    if err := auth.RequirePrivileged(ctx); err != nil {
+        return nil, err
+    }
+    if guestID == "" || userID == 0 {
+        return nil, apperr.InvalidArgument("", "guest_id and user_id are required")
+    }
+
+    merge, err := s.QuestionRepository.MergeGuest(ctx, []byte(guestID), userID)
+    if err != nil {
+        logger.Errorf("Error merging guest %q into user %d: %v", guestID, userID, err)
+        return nil, err
+    }
+    return merge, nil
+}
+
+// resolveAuthor определяет автора вопроса или лайка через auth.Author и проверяет,
+// что он ровно один — пользователь или гость.
+func resolveAuthor(ctx context.Context, userID *uint, guestID *string) (*uint, []byte, error) {
+    var guest []byte
+    if guestID != nil {
+        guest = []byte(*guestID)
+    }
+    userID, guest, err := auth.Author(ctx, userID, guest)
+    if err != nil {
+        return nil, nil, err
+    }
+    if (userID != nil) == (len(guest) > 0) {
+        return nil, nil, apperr.InvalidArgument("", "exactly one of user_id or guest_id is required")
+    }
+    return userID, guest, nil
+}
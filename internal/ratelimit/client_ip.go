@@ -0,0 +1,19 @@
+package ratelimit
+
+import "context"
+
+type clientIPKey struct{}
+
+// WithClientIP кладёт в контекст IP-адрес клиента, по которому считается лимит per_ip.
+func WithClientIP(ctx context.Context, ip string) context.Context {
+	if ip == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, clientIPKey{}, ip)
+}
+
+// ClientIP возвращает IP-адрес клиента; пустая строка — адрес неизвестен, лимит per_ip не действует.
+func ClientIP(ctx context.Context) string {
+	ip, _ := ctx.Value(clientIPKey{}).(string)
+	return ip
+}
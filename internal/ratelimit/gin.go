@@ -0,0 +1,13 @@
+package ratelimit
+
+import "github.com/gin-gonic/gin"
+
+// GinMiddleware передаёт IP клиента в контекст запроса. Адрес за прокси берётся из
+// X-Forwarded-For / X-Real-IP только от доверенных прокси
+// (rate_limit.trusted_proxies, см. Engine.SetTrustedProxies).
+func GinMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Request = c.Request.WithContext(WithClientIP(c.Request.Context(), c.ClientIP()))
+		c.Next()
+	}
+}
@@ -0,0 +1,83 @@
+package ratelimit
+
+import (
+	"context"
+	"net"
+	"net/netip"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+
+	"github.com/ShopOnGO/review-service/internal/auth"
+)
+
+// UnaryServerInterceptor передаёт IP клиента в контекст вызова. gRPC-клиент сервиса — шлюз,
+// поэтому адрес пользователя берётся из метаданных x-forwarded-for или x-real-ip, которые
+// выставляет шлюз. Метаданным верят, только если соединение пришло из trustedProxies или
+// вызывающий — внутренний сервис с токеном (auth.Principal.Service); иначе, как и без них,
+// берётся адрес соединения.
+func UnaryServerInterceptor(trustedProxies []netip.Prefix) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return handler(WithClientIP(ctx, grpcClientIP(ctx, trustedProxies)), req)
+	}
+}
+
+// StreamServerInterceptor делает то же для потоковых вызовов.
+func StreamServerInterceptor(trustedProxies []netip.Prefix) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx := WithClientIP(ss.Context(), grpcClientIP(ss.Context(), trustedProxies))
+		return handler(srv, &clientIPStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+func grpcClientIP(ctx context.Context, trustedProxies []netip.Prefix) string {
+	var peerIP string
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		if host, _, err := net.SplitHostPort(p.Addr.String()); err == nil {
+			peerIP = host
+		}
+	}
+	if !trustedCaller(ctx, peerIP, trustedProxies) {
+		return peerIP
+	}
+	if values := metadata.ValueFromIncomingContext(ctx, "x-forwarded-for"); len(values) > 0 {
+		// первый адрес цепочки — исходный клиент
+		if ip := strings.TrimSpace(strings.Split(values[0], ",")[0]); ip != "" {
+			return ip
+		}
+	}
+	if values := metadata.ValueFromIncomingContext(ctx, "x-real-ip"); len(values) > 0 && values[0] != "" {
+		return strings.TrimSpace(values[0])
+	}
+	return peerIP
+}
+
+// trustedCaller сообщает, можно ли верить адресу клиента из метаданных вызова.
+func trustedCaller(ctx context.Context, peerIP string, trustedProxies []netip.Prefix) bool {
+	if p, ok := auth.FromContext(ctx); ok && p.Service {
+		return true
+	}
+	addr, err := netip.ParseAddr(peerIP)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, prefix := range trustedProxies {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// clientIPStream подменяет контекст потока контекстом с IP клиента.
+type clientIPStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *clientIPStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,54 @@
+package ratelimit
+
+import (
+	"context"
+	"net"
+	"net/netip"
+	"testing"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+
+	"github.com/ShopOnGO/review-service/internal/auth"
+)
+
+func TestGrpcClientIP(t *testing.T) {
+	trusted := []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")}
+	forwarded := metadata.Pairs("x-forwarded-for", "203.0.113.7, 10.0.0.5")
+	realIP := metadata.Pairs("x-real-ip", "203.0.113.8")
+
+	tests := []struct {
+		name      string
+		peer      string
+		md        metadata.MD
+		principal *auth.Principal
+		want      string
+	}{
+		{"no metadata", "198.51.100.1:5000", nil, nil, "198.51.100.1"},
+		{"untrusted peer ignores forwarded", "198.51.100.1:5000", forwarded, nil, "198.51.100.1"},
+		{"untrusted peer ignores real ip", "198.51.100.1:5000", realIP, nil, "198.51.100.1"},
+		{"user token does not make metadata trusted", "198.51.100.1:5000", forwarded, &auth.Principal{UserID: 5}, "198.51.100.1"},
+		{"trusted proxy", "10.0.0.5:5000", forwarded, nil, "203.0.113.7"},
+		{"trusted proxy real ip", "10.0.0.5:5000", realIP, nil, "203.0.113.8"},
+		{"trusted proxy without metadata", "10.0.0.5:5000", nil, nil, "10.0.0.5"},
+		{"service token", "198.51.100.1:5000", forwarded, &auth.Principal{Service: true}, "203.0.113.7"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addr, err := net.ResolveTCPAddr("tcp", tt.peer)
+			if err != nil {
+				t.Fatal(err)
+			}
+			ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: addr})
+			if tt.md != nil {
+				ctx = metadata.NewIncomingContext(ctx, tt.md)
+			}
+			if tt.principal != nil {
+				ctx = auth.WithPrincipal(ctx, tt.principal)
+			}
+			if got := grpcClientIP(ctx, trusted); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
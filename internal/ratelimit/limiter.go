@@ -0,0 +1,87 @@
+// Package ratelimit ограничивает частоту создания отзывов, вопросов и лайков одним
+// пользователем, гостем или IP-адресом клиента.
+package ratelimit
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/ShopOnGO/ShopOnGO/pkg/logger"
+	"github.com/ShopOnGO/review-service/configs"
+	"github.com/ShopOnGO/review-service/internal/apperr"
+)
+
+// Action — ограничиваемое действие, у каждого свои лимиты в rate_limit.
+type Action string
+
+const (
+	ActionReview   Action = "review"
+	ActionQuestion Action = "question"
+	// ActionLike — лайк или дизлайк отзыва, лайк вопроса
+	ActionLike Action = "like"
+)
+
+// Limiter проверяет лимиты действий. Нулевой указатель ничего не ограничивает — так сервисы
+// работают в командах CLI (seed) и при rate_limit.enabled: false.
+type Limiter struct {
+	store Store
+	rules map[Action]configs.RateLimitRule
+}
+
+func NewLimiter(store Store, conf configs.RateLimitConfig) *Limiter {
+	return &Limiter{
+		store: store,
+		rules: map[Action]configs.RateLimitRule{
+			ActionReview:   conf.Reviews,
+			ActionQuestion: conf.Questions,
+			ActionLike:     conf.Likes,
+		},
+	}
+}
+
+// Allow учитывает действие автора (userID или guestID) и IP клиента из контекста и отклоняет
+// его ошибкой apperr.KindResourceExhausted, если превышен любой из лимитов. Если хранилище
+// счётчиков недоступно, действие разрешается: сбой Redis не должен останавливать запись отзывов.
+func (l *Limiter) Allow(ctx context.Context, action Action, userID *uint, guestID []byte) error {
+	if l == nil {
+		return nil
+	}
+	rule := l.rules[action]
+
+	if userID != nil {
+		if err := l.check(ctx, action, "user", strconv.FormatUint(uint64(*userID), 10), rule.PerUser, rule.Window); err != nil {
+			return err
+		}
+	}
+	if len(guestID) > 0 {
+		if err := l.check(ctx, action, "guest", string(guestID), rule.PerGuest, rule.Window); err != nil {
+			return err
+		}
+	}
+	if ip := ClientIP(ctx); ip != "" {
+		if err := l.check(ctx, action, "ip", ip, rule.PerIP, rule.Window); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (l *Limiter) check(ctx context.Context, action Action, subject, id string, limit int, window time.Duration) error {
+	if limit <= 0 {
+		return nil
+	}
+	count, resetIn, err := l.store.Incr(ctx, "ratelimit:"+string(action)+":"+subject+":"+id, window)
+	if err != nil {
+		logger.Errorf("Rate limit store error, %s by %s %s allowed: %v", action, subject, id, err)
+		return nil
+	}
+	if count <= int64(limit) {
+		return nil
+	}
+
+	rejected.WithLabelValues(string(action), subject).Inc()
+	logger.Warnf("Rate limit exceeded: %s by %s %s (%d per %s)", action, subject, id, limit, window)
+	return apperr.ResourceExhausted("RATE_LIMITED", resetIn,
+		"rate limit exceeded: at most %d %s actions per %s, retry in %s", limit, action, window, resetIn.Round(time.Second))
+}
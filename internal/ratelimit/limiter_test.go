@@ -0,0 +1,124 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ShopOnGO/review-service/configs"
+	"github.com/ShopOnGO/review-service/internal/apperr"
+)
+
+func TestMemoryStore(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+
+	for want := int64(1); want <= 3; want++ {
+		count, resetIn, err := store.Incr(ctx, "a", time.Minute)
+		if err != nil || count != want {
+			t.Fatalf("incr %d: got %d, %v", want, count, err)
+		}
+		if resetIn <= 0 || resetIn > time.Minute {
+			t.Fatalf("incr %d: reset in %s", want, resetIn)
+		}
+	}
+	if count, _, _ := store.Incr(ctx, "b", time.Minute); count != 1 {
+		t.Fatalf("other key: got %d, want 1", count)
+	}
+
+	// окно закончилось — счёт начинается заново
+	store.Incr(ctx, "short", time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+	if count, _, _ := store.Incr(ctx, "short", time.Millisecond); count != 1 {
+		t.Fatalf("after window: got %d, want 1", count)
+	}
+}
+
+// failingStore имитирует недоступный Redis.
+type failingStore struct{}
+
+func (failingStore) Incr(context.Context, string, time.Duration) (int64, time.Duration, error) {
+	return 0, 0, errors.New("connection refused")
+}
+
+func TestLimiterAllow(t *testing.T) {
+	conf := configs.RateLimitConfig{
+		Reviews:   configs.RateLimitRule{PerUser: 2, PerGuest: 1, PerIP: 3, Window: time.Minute},
+		Questions: configs.RateLimitRule{PerUser: 0, PerIP: 0, Window: time.Minute},
+	}
+	user := func(id uint) *uint { return &id }
+	withIP := WithClientIP(context.Background(), "10.0.0.1")
+
+	type call struct {
+		ctx     context.Context
+		action  Action
+		userID  *uint
+		guestID []byte
+	}
+	tests := []struct {
+		name  string
+		calls []call
+		// allowed — сколько первых вызовов проходит, остальные отклоняются
+		allowed int
+	}{
+		{
+			name:    "per user",
+			calls:   []call{{context.Background(), ActionReview, user(1), nil}, {context.Background(), ActionReview, user(1), nil}, {context.Background(), ActionReview, user(1), nil}},
+			allowed: 2,
+		},
+		{
+			name:    "per guest",
+			calls:   []call{{context.Background(), ActionReview, nil, []byte("g")}, {context.Background(), ActionReview, nil, []byte("g")}},
+			allowed: 1,
+		},
+		{
+			name:    "per ip across users",
+			calls:   []call{{withIP, ActionReview, user(1), nil}, {withIP, ActionReview, user(2), nil}, {withIP, ActionReview, user(3), nil}, {withIP, ActionReview, user(4), nil}},
+			allowed: 3,
+		},
+		{
+			name:    "zero limit is unlimited",
+			calls:   []call{{withIP, ActionQuestion, user(1), nil}, {withIP, ActionQuestion, user(1), nil}, {withIP, ActionQuestion, user(1), nil}},
+			allowed: 3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limiter := NewLimiter(NewMemoryStore(), conf)
+			for i, c := range tt.calls {
+				err := limiter.Allow(c.ctx, c.action, c.userID, c.guestID)
+				if i < tt.allowed {
+					if err != nil {
+						t.Fatalf("call %d: %v", i+1, err)
+					}
+					continue
+				}
+				var appErr *apperr.Error
+				if !errors.As(err, &appErr) || appErr.Kind != apperr.KindResourceExhausted || appErr.RetryAfter <= 0 {
+					t.Fatalf("call %d: got %v, want a rate limit error with RetryAfter", i+1, err)
+				}
+			}
+		})
+	}
+}
+
+func TestLimiterAllowWithoutLimits(t *testing.T) {
+	userID := uint(1)
+	rule := configs.RateLimitRule{PerUser: 1, Window: time.Minute}
+
+	tests := []struct {
+		name    string
+		limiter *Limiter
+	}{
+		{"nil limiter", nil},
+		{"store unavailable", NewLimiter(failingStore{}, configs.RateLimitConfig{Reviews: rule})},
+	}
+	for _, tt := range tests {
+		for i := 0; i < 3; i++ {
+			if err := tt.limiter.Allow(context.Background(), ActionReview, &userID, nil); err != nil {
+				t.Fatalf("%s: call %d: %v", tt.name, i+1, err)
+			}
+		}
+	}
+}
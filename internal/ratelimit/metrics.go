@@ -0,0 +1,11 @@
+package ratelimit
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var rejected = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "review_service_rate_limited_total",
+	Help: "Количество действий, отклонённых из-за превышения лимита, по действию и субъекту (user, guest, ip).",
+}, []string{"action", "subject"})
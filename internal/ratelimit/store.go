@@ -0,0 +1,89 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// Store хранит счётчики фиксированных окон. Интерфейс повторяет пару INCR + PEXPIRE Redis,
+// поэтому счётчики можно держать в процессе (MemoryStore) или общими для всех экземпляров (RedisStore).
+type Store interface {
+	// Incr увеличивает счётчик key и возвращает его новое значение и время до конца окна.
+	// Окно длиной window начинается с первого увеличения.
+	Incr(ctx context.Context, key string, window time.Duration) (count int64, resetIn time.Duration, err error)
+}
+
+// sweepInterval — как часто MemoryStore удаляет счётчики закончившихся окон.
+const sweepInterval = time.Minute
+
+// MemoryStore — счётчики в памяти процесса. При нескольких экземплярах лимит действует
+// на каждый экземпляр отдельно.
+type MemoryStore struct {
+	mu        sync.Mutex
+	counters  map[string]*counter
+	nextSweep time.Time
+}
+
+type counter struct {
+	count   int64
+	resetAt time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{counters: make(map[string]*counter)}
+}
+
+func (s *MemoryStore) Incr(_ context.Context, key string, window time.Duration) (int64, time.Duration, error) {
+	now := time.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if now.After(s.nextSweep) {
+		for k, c := range s.counters {
+			if !now.Before(c.resetAt) {
+				delete(s.counters, k)
+			}
+		}
+		s.nextSweep = now.Add(sweepInterval)
+	}
+
+	c, ok := s.counters[key]
+	if !ok || !now.Before(c.resetAt) {
+		c = &counter{resetAt: now.Add(window)}
+		s.counters[key] = c
+	}
+	c.count++
+	return c.count, c.resetAt.Sub(now), nil
+}
+
+// incrScript атомарно увеличивает счётчик и ставит срок жизни при открытии окна. Ключу без TTL
+// срок тоже ставится, чтобы счётчик не остался навсегда.
+var incrScript = redis.NewScript(`
+local count = redis.call('INCR', KEYS[1])
+local ttl = redis.call('PTTL', KEYS[1])
+if count == 1 or ttl < 0 then
+	redis.call('PEXPIRE', KEYS[1], ARGV[1])
+	ttl = tonumber(ARGV[1])
+end
+return {count, ttl}
+`)
+
+// RedisStore — счётчики в Redis, общие для всех экземпляров сервиса.
+type RedisStore struct {
+	client redis.UniversalClient
+}
+
+func NewRedisStore(client redis.UniversalClient) *RedisStore {
+	return &RedisStore{client: client}
+}
+
+func (s *RedisStore) Incr(ctx context.Context, key string, window time.Duration) (int64, time.Duration, error) {
+	res, err := incrScript.Run(ctx, s.client, []string{key}, window.Milliseconds()).Int64Slice()
+	if err != nil {
+		return 0, 0, err
+	}
+	return res[0], time.Duration(res[1]) * time.Millisecond, nil
+}
@@ -0,0 +1,109 @@
+package reconcile
+
+import (
+	"context"
+	"github.com/ShopOnGO/review-service/pkg/db"
+)
+
+// Фактические агрегаты рейтинга: только опубликованные неудалённые отзывы.
+// Товары, у которых сохранённые агрегаты расходятся с фактическими, попадают в drift.
+const ratingDriftCTE = `
+	WITH actual AS (
+		SELECT product_id, COUNT(*) AS cnt, SUM(rating) AS total
+		FROM reviews
+		WHERE deleted_at IS NULL AND status = 'published'
+		GROUP BY product_id
+	), drift AS (
+		SELECT p.id, COALESCE(a.cnt, 0) AS cnt, COALESCE(a.total, 0) AS total
+		FROM products p
+		LEFT JOIN actual a ON a.product_id = p.id
+		WHERE p.review_count IS DISTINCT FROM COALESCE(a.cnt, 0)
+		   OR p.rating_sum   IS DISTINCT FROM COALESCE(a.total, 0)
+	)`
+
+const voteDriftCTE = `
+	WITH actual AS (
+		SELECT r.id,
+			COUNT(v.id) FILTER (WHERE v.helpful)     AS likes,
+			COUNT(v.id) FILTER (WHERE NOT v.helpful) AS dislikes
+		FROM reviews r
+		LEFT JOIN review_votes v ON v.review_id = r.id
+		GROUP BY r.id
+	), drift AS (
+		SELECT a.id, a.likes, a.dislikes
+		FROM actual a
+		JOIN reviews r ON r.id = a.id
+		WHERE r.likes_count IS DISTINCT FROM a.likes
+		   OR r.dislikes_count IS DISTINCT FROM a.dislikes
+	)`
+
+const questionLikeDriftCTE = `
+	WITH actual AS (
+		SELECT q.id, COUNT(l.id) AS likes
+		FROM questions q
+		LEFT JOIN question_likes l ON l.question_id = q.id
+		GROUP BY q.id
+	), drift AS (
+		SELECT a.id, a.likes
+		FROM actual a
+		JOIN questions q ON q.id = a.id
+		WHERE q.likes_count IS DISTINCT FROM a.likes
+	)`
+
+type ReconcileRepository struct {
+	Db *db.Db
+}
+
+func NewReconcileRepository(db *db.Db) *ReconcileRepository {
+	return &ReconcileRepository{
+		Db: db,
+	}
+}
+
+// ProductRatings пересчитывает review_count, rating_sum и rating товаров по отзывам.
+// При dryRun только считает товары с расхождениями.
+func (r *ReconcileRepository) ProductRatings(ctx context.Context, dryRun bool) (int64, error) {
+	if dryRun {
+		return r.countDrift(ctx, ratingDriftCTE)
+	}
+	res := r.Db.WithContext(ctx).Exec(ratingDriftCTE + `
+		UPDATE products p
+		SET review_count = d.cnt,
+			rating_sum   = d.total,
+			rating = CASE WHEN d.cnt > 0 THEN d.total::numeric / d.cnt ELSE 0 END
+		FROM drift d
+		WHERE p.id = d.id`)
+	return res.RowsAffected, res.Error
+}
+
+// ReviewVoteCounts пересчитывает likes_count и dislikes_count отзывов по review_votes.
+func (r *ReconcileRepository) ReviewVoteCounts(ctx context.Context, dryRun bool) (int64, error) {
+	if dryRun {
+		return r.countDrift(ctx, voteDriftCTE)
+	}
+	res := r.Db.WithContext(ctx).Exec(voteDriftCTE + `
+		UPDATE reviews r
+		SET likes_count = d.likes, dislikes_count = d.dislikes
+		FROM drift d
+		WHERE r.id = d.id`)
+	return res.RowsAffected, res.Error
+}
+
+// QuestionLikeCounts пересчитывает likes_count вопросов по question_likes.
+func (r *ReconcileRepository) QuestionLikeCounts(ctx context.Context, dryRun bool) (int64, error) {
+	if dryRun {
+		return r.countDrift(ctx, questionLikeDriftCTE)
+	}
+	res := r.Db.WithContext(ctx).Exec(questionLikeDriftCTE + `
+		UPDATE questions q
+		SET likes_count = d.likes
+		FROM drift d
+		WHERE q.id = d.id`)
+	return res.RowsAffected, res.Error
+}
+
+func (r *ReconcileRepository) countDrift(ctx context.Context, cte string) (int64, error) {
+	var count int64
+	err := r.Db.WithContext(ctx).Raw(cte + ` SELECT COUNT(*) FROM drift`).Scan(&count).Error
+	return count, err
+}
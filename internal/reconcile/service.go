@@ -0,0 +1,61 @@
+package reconcile
+
+import (
+	"context"
+	"github.com/ShopOnGO/ShopOnGO/pkg/logger"
+)
+
+// Options — какие денормализованные счётчики пересчитывать.
+type Options struct {
+	DryRun  bool
+	Ratings bool
+	// Likes пересчитывает счётчики лайков по голосам. Лайки, поставленные до появления
+	// голосов по пользователям, в голосах не записаны и при пересчёте пропадут.
+	Likes bool
+}
+
+// Result — количество строк с расхождениями (при DryRun) или исправленных строк.
+type Result struct {
+	DryRun    bool  `json:"dry_run"`
+	Products  int64 `json:"products"`
+	Reviews   int64 `json:"reviews"`
+	Questions int64 `json:"questions"`
+}
+
+// ReconcileService сверяет денормализованные агрегаты (рейтинг товара, счётчики лайков)
+// с исходными строками и исправляет расхождения, накопившиеся из-за сбоев при обработке событий.
+type ReconcileService struct {
+	ReconcileRepository *ReconcileRepository
+}
+
+func NewReconcileService(reconcileRepo *ReconcileRepository) *ReconcileService {
+	return &ReconcileService{
+		ReconcileRepository: reconcileRepo,
+	}
+}
+
+func (s *ReconcileService) Run(ctx context.Context, opts Options) (*Result, error) {
+	result := &Result{DryRun: opts.DryRun}
+	var err error
+
+	if opts.Ratings {
+		if result.Products, err = s.ReconcileRepository.ProductRatings(ctx, opts.DryRun); err != nil {
+			logger.Errorf("Error reconciling product ratings: %v", err)
+			return nil, err
+		}
+	}
+	if opts.Likes {
+		if result.Reviews, err = s.ReconcileRepository.ReviewVoteCounts(ctx, opts.DryRun); err != nil {
+			logger.Errorf("Error reconciling review vote counts: %v", err)
+			return nil, err
+		}
+		if result.Questions, err = s.ReconcileRepository.QuestionLikeCounts(ctx, opts.DryRun); err != nil {
+			logger.Errorf("Error reconciling question like counts: %v", err)
+			return nil, err
+		}
+	}
+
+	logger.Infof("Reconcile finished (dry_run=%t): products=%d, reviews=%d, questions=%d",
+		opts.DryRun, result.Products, result.Reviews, result.Questions)
+	return result, nil
+}
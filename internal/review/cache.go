@@ -0,0 +1,98 @@
+package review
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ShopOnGO/review-service/internal/cache"
+)
+
+// ListingCache кэширует первые size опубликованных отзывов товара для каждой сортировки
+// и сводку его оценок. Любая страница в пределах первых size отзывов отдаётся из кэша.
+// Нулевой указатель ничего не кэширует.
+type ListingCache struct {
+	cache *cache.Cache
+	size  int
+}
+
+func NewListingCache(c *cache.Cache, size int) *ListingCache {
+	return &ListingCache{cache: c, size: size}
+}
+
+func listingKey(productID uint, sort string) string {
+	return fmt.Sprintf("reviews:%d:%s", productID, sort)
+}
+
+func ratingKey(productID uint) string {
+	return fmt.Sprintf("rating:%d", productID)
+}
+
+// covers сообщает, что страница помещается в кэшируемые первые отзывы.
+func (c *ListingCache) covers(limit, offset int) bool {
+	return c != nil && limit > 0 && offset >= 0 && offset+limit <= c.size
+}
+
+// listing возвращает первые size отзывов товара из кэша или загружает их через load.
+func (c *ListingCache) listing(ctx context.Context, productID uint, sort string, load func(size int) ([]*Review, error)) ([]*Review, error) {
+	key := listingKey(productID, sort)
+	var first []*Review
+	if c.cache.Get(ctx, key, &first) {
+		return first, nil
+	}
+	first, err := load(c.size)
+	if err != nil {
+		return nil, err
+	}
+	c.cache.Set(ctx, key, first)
+	return first, nil
+}
+
+// ratings кладёт в byProduct найденные в кэше сводки и возвращает товары, которых в кэше нет.
+func (c *ListingCache) ratings(ctx context.Context, productIDs []uint, byProduct map[uint]*RatingSummary) []uint {
+	if c == nil {
+		return productIDs
+	}
+	keys := make([]string, len(productIDs))
+	for i, id := range productIDs {
+		keys[i] = ratingKey(id)
+	}
+	cached := make([]RatingSummary, len(productIDs))
+	found := c.cache.GetMany(ctx, keys, func(i int) interface{} { return &cached[i] })
+
+	var missing []uint
+	for i, id := range productIDs {
+		if found[i] {
+			byProduct[id] = &cached[i]
+		} else {
+			missing = append(missing, id)
+		}
+	}
+	return missing
+}
+
+func (c *ListingCache) setRating(ctx context.Context, summary *RatingSummary) {
+	if c == nil {
+		return
+	}
+	c.cache.Set(ctx, ratingKey(summary.ProductID), summary)
+}
+
+// invalidate удаляет все записи товара: списки в обеих сортировках и сводку оценок.
+func (c *ListingCache) invalidate(ctx context.Context, productID uint) {
+	if c == nil {
+		return
+	}
+	c.cache.Delete(ctx, listingKey(productID, SortNewest), listingKey(productID, SortHelpful), ratingKey(productID))
+}
+
+// page вырезает страницу из кэшированного начала списка.
+func page(reviews []*Review, limit, offset int) []*Review {
+	if offset >= len(reviews) {
+		return []*Review{}
+	}
+	end := offset + limit
+	if end > len(reviews) {
+		end = len(reviews)
+	}
+	return reviews[offset:end]
+}
@@ -0,0 +1,114 @@
+package review
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/ShopOnGO/review-service/internal/cache"
+)
+
+func reviewsWithIDs(ids ...uint) []*Review {
+	reviews := make([]*Review, len(ids))
+	for i, id := range ids {
+		reviews[i] = &Review{Model: gorm.Model{ID: id}}
+	}
+	return reviews
+}
+
+func idsOf(reviews []*Review) []uint {
+	ids := make([]uint, len(reviews))
+	for i, r := range reviews {
+		ids[i] = r.ID
+	}
+	return ids
+}
+
+func TestListingCacheCovers(t *testing.T) {
+	c := NewListingCache(nil, 20)
+
+	tests := []struct {
+		limit, offset int
+		want          bool
+	}{
+		{10, 0, true},
+		{10, 10, true},
+		{20, 0, true},
+		{10, 11, false},
+		{21, 0, false},
+		{0, 0, false},
+		{10, -1, false},
+	}
+	for _, tt := range tests {
+		if got := c.covers(tt.limit, tt.offset); got != tt.want {
+			t.Errorf("covers(%d, %d) = %v, want %v", tt.limit, tt.offset, got, tt.want)
+		}
+	}
+
+	var disabled *ListingCache
+	if disabled.covers(10, 0) {
+		t.Error("nil cache covers a page")
+	}
+}
+
+func TestPage(t *testing.T) {
+	reviews := reviewsWithIDs(1, 2, 3, 4, 5)
+
+	tests := []struct {
+		limit, offset int
+		want          []uint
+	}{
+		{2, 0, []uint{1, 2}},
+		{2, 4, []uint{5}},
+		{10, 0, []uint{1, 2, 3, 4, 5}},
+		{2, 5, []uint{}},
+		{2, 9, []uint{}},
+	}
+	for _, tt := range tests {
+		got := page(reviews, tt.limit, tt.offset)
+		if got == nil || !slices.Equal(idsOf(got), tt.want) {
+			t.Errorf("page(%d, %d) = %v, want %v", tt.limit, tt.offset, idsOf(got), tt.want)
+		}
+	}
+}
+
+func TestListingCache(t *testing.T) {
+	ctx := context.Background()
+	c := NewListingCache(cache.New(cache.NewLRU(10), time.Minute), 3)
+
+	loads := 0
+	load := func(size int) ([]*Review, error) {
+		loads++
+		if size != 3 {
+			t.Fatalf("load size = %d, want 3", size)
+		}
+		return reviewsWithIDs(1, 2, 3), nil
+	}
+
+	for i := 0; i < 2; i++ {
+		got, err := c.listing(ctx, 7, SortNewest, load)
+		if err != nil || !slices.Equal(idsOf(got), []uint{1, 2, 3}) {
+			t.Fatalf("listing %d: got %v, %v", i+1, idsOf(got), err)
+		}
+	}
+	if loads != 1 {
+		t.Fatalf("loaded %d times, want 1", loads)
+	}
+
+	c.setRating(ctx, &RatingSummary{ProductID: 7, Count: 3, Average: 4})
+	byProduct := make(map[uint]*RatingSummary)
+	if missing := c.ratings(ctx, []uint{7, 8}, byProduct); !slices.Equal(missing, []uint{8}) || byProduct[7].Count != 3 {
+		t.Fatalf("ratings: missing %v, cached %+v", missing, byProduct)
+	}
+
+	c.invalidate(ctx, 7)
+	if _, err := c.listing(ctx, 7, SortNewest, load); err != nil || loads != 2 {
+		t.Fatalf("after invalidate: loads %d, %v", loads, err)
+	}
+	if missing := c.ratings(ctx, []uint{7}, map[uint]*RatingSummary{}); !slices.Equal(missing, []uint{7}) {
+		t.Fatalf("rating survived invalidate: missing %v", missing)
+	}
+}
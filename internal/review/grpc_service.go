@@ -4,6 +4,7 @@ import (
 	"context"
 
 	pb "github.com/ShopOnGO/review-proto/pkg/service"
+	"github.com/ShopOnGO/review-service/internal/auth"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -16,34 +17,187 @@ func NewGrpcReviewService(svc *ReviewService) *GrpcReviewService {
 	return &GrpcReviewService{reviewSvc: svc}
 }
 
+func (g *GrpcReviewService) GetReview(ctx context.Context, req *pb.GetReviewRequest) (*pb.Review, error) {
+	review, err := g.reviewSvc.GetReviewByID(ctx, uint(req.ReviewId))
+	if err != nil {
+		return nil, err
+	}
+	return toProtoReview(review), nil
+}
+
+func (g *GrpcReviewService) CreateReview(ctx context.Context, req *pb.CreateReviewRequest) (*pb.Review, error) {
+	userID, guestID := auth.RequestAuthor(req.GetUserId(), req.GetGuestId())
+	review, err := g.reviewSvc.AddReview(ctx, uint(req.ProductId), userID, guestID, int16(req.Rating), req.Comment)
+	if err != nil {
+		return nil, err
+	}
+	return toProtoReview(review), nil
+}
+
+// UpdateReview правит отзыв. Отзыв должен принадлежать автору запроса (см. CheckAuthor).
+func (g *GrpcReviewService) UpdateReview(ctx context.Context, req *pb.UpdateReviewRequest) (*pb.Review, error) {
+	userID, _ := auth.RequestAuthor(req.GetUserId(), req.GetGuestId())
+	if _, err := g.reviewSvc.CheckAuthor(ctx, uint(req.ReviewId), userID, req.GetGuestId()); err != nil {
+		return nil, err
+	}
+
+	review, err := g.reviewSvc.UpdateReview(ctx, uint(req.ReviewId), int16(req.Rating), req.Comment)
+	if err != nil {
+		return nil, err
+	}
+	return toProtoReview(review), nil
+}
+
+func (g *GrpcReviewService) DeleteReview(ctx context.Context, req *pb.DeleteReviewRequest) (*pb.DeleteReviewResponse, error) {
+	// в запросе нет автора: без проверки токенов (нет Principal) удаление, как и из Kafka,
+	// автора не проверяет, с токеном отзыв может удалить только его автор или модератор
+	if _, ok := auth.FromContext(ctx); ok {
+		if _, err := g.reviewSvc.CheckAuthor(ctx, uint(req.ReviewId), nil, nil); err != nil {
+			return nil, err
+		}
+	}
+	if err := g.reviewSvc.DeleteReview(ctx, uint(req.ReviewId)); err != nil {
+		return nil, err
+	}
+	return &pb.DeleteReviewResponse{}, nil
+}
+
+func (g *GrpcReviewService) LikeReview(ctx context.Context, req *pb.LikeReviewRequest) (*pb.LikeReviewResponse, error) {
+	like := g.reviewSvc.AddLikeToReview
+	if req.Remove {
+		like = g.reviewSvc.RemoveLikeToReview
+	}
+
+	likes, err := like(ctx, uint(req.ReviewId), uint(req.UserId))
+	if err != nil {
+		return nil, err
+	}
+	return &pb.LikeReviewResponse{LikesCount: int32(likes)}, nil
+}
+
 func (g *GrpcReviewService) GetReviewsForProduct(ctx context.Context, req *pb.GetReviewsRequest) (*pb.ReviewListResponse, error) {
-	reviews, err := g.reviewSvc.GetReviewsForProduct(uint(req.ProductId), int(req.Limit), int(req.Offset))
+	reviews, err := g.reviewSvc.GetReviewsForProduct(ctx, uint(req.ProductId), int(req.Limit), int(req.Offset), req.Sort)
 	if err != nil {
 		return nil, err
 	}
 
 	resp := &pb.ReviewListResponse{}
 	for _, r := range reviews {
-		resp.Reviews = append(resp.Reviews, &pb.Review{
-			Model: &pb.Model{
-			  Id:        uint32(r.ID),
-			  CreatedAt: timestamppb.New(r.CreatedAt),
-			  UpdatedAt: timestamppb.New(r.UpdatedAt),
-			  DeletedAt: func() *timestamppb.Timestamp {
+		resp.Reviews = append(resp.Reviews, toProtoReview(r))
+	}
+	return resp, nil
+}
+
+func (g *GrpcReviewService) GetReviewsByUser(ctx context.Context, req *pb.GetReviewsByUserRequest) (*pb.UserReviewListResponse, error) {
+	reviews, err := g.reviewSvc.GetReviewsByUser(ctx, uint(req.UserId), uint(req.ViewerId), int(req.Limit), int(req.Offset))
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pb.UserReviewListResponse{}
+	for _, r := range reviews {
+		resp.Reviews = append(resp.Reviews, &pb.UserReview{
+			Review: toProtoReview(&r.Review),
+			Status: r.State,
+		})
+	}
+	return resp, nil
+}
+
+func (g *GrpcReviewService) SearchReviews(ctx context.Context, req *pb.SearchReviewsRequest) (*pb.SearchReviewsResponse, error) {
+	results, err := g.reviewSvc.SearchReviews(ctx, uint(req.ProductId), req.Query, int(req.Limit), int(req.Offset))
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pb.SearchReviewsResponse{}
+	for _, r := range results {
+		resp.Hits = append(resp.Hits, &pb.ReviewSearchHit{
+			Review:  toProtoReview(&r.Review),
+			Rank:    r.Rank,
+			Snippet: r.Snippet,
+		})
+	}
+	return resp, nil
+}
+
+func (g *GrpcReviewService) GetReviewHighlights(ctx context.Context, req *pb.GetReviewHighlightsRequest) (*pb.ReviewHighlightsResponse, error) {
+	highlights, err := g.reviewSvc.GetReviewHighlights(ctx, uint(req.ProductId))
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pb.ReviewHighlightsResponse{
+		PositivePinned: highlights.PositivePinned,
+		CriticalPinned: highlights.CriticalPinned,
+	}
+	if highlights.TopPositive != nil {
+		resp.TopPositive = toProtoReview(highlights.TopPositive)
+	}
+	if highlights.TopCritical != nil {
+		resp.TopCritical = toProtoReview(highlights.TopCritical)
+	}
+	return resp, nil
+}
+
+func (g *GrpcReviewService) GetRatingSummaries(ctx context.Context, req *pb.GetRatingSummariesRequest) (*pb.RatingSummariesResponse, error) {
+	productIDs := make([]uint, 0, len(req.ProductIds))
+	for _, id := range req.ProductIds {
+		productIDs = append(productIDs, uint(id))
+	}
+
+	summaries, err := g.reviewSvc.GetRatingSummaries(ctx, productIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pb.RatingSummariesResponse{}
+	for _, s := range summaries {
+		resp.Summaries = append(resp.Summaries, &pb.RatingSummary{
+			ProductId: uint32(s.ProductID),
+			Count:     s.Count,
+			Average:   s.Average,
+			Histogram: s.Histogram[:],
+		})
+	}
+	return resp, nil
+}
+
+func (g *GrpcReviewService) ClaimGuestReviews(ctx context.Context, req *pb.ClaimGuestReviewsRequest) (*pb.ClaimGuestReviewsResponse, error) {
+	claimed, err := g.reviewSvc.ClaimGuestReviews(ctx, string(req.GuestId), uint(req.UserId))
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ClaimGuestReviewsResponse{ReviewsClaimed: claimed}, nil
+}
+
+func toProtoReview(r *Review) *pb.Review {
+	protoReview := &pb.Review{
+		Model: &pb.Model{
+			Id:        uint32(r.ID),
+			CreatedAt: timestamppb.New(r.CreatedAt),
+			UpdatedAt: timestamppb.New(r.UpdatedAt),
+			DeletedAt: func() *timestamppb.Timestamp {
 				if r.DeletedAt.Valid {
 					return timestamppb.New(r.DeletedAt.Time)
 				}
 				return nil
 			}(),
-			},
-			
-			ProductId:		uint32(r.ProductID),
-			UserId:       	uint32(r.UserID),
-			Rating:       	int32(r.Rating),
-			LikesCount:		int32(r.LikesCount),
-			Comment:      	r.Comment,
-		  })
-		  
+		},
+
+		ProductId:     uint32(r.ProductID),
+		Rating:        int32(r.Rating),
+		LikesCount:    int32(r.LikesCount),
+		DislikesCount: int32(r.DislikesCount),
+		Comment:       r.Comment,
+		Status:        r.Status,
 	}
-	return resp, nil
+
+	if r.UserID != nil {
+		protoReview.Author = &pb.Review_UserId{UserId: uint32(*r.UserID)}
+	} else if len(r.GuestID) > 0 {
+		protoReview.Author = &pb.Review_GuestId{GuestId: r.GuestID}
+	}
+
+	return protoReview
 }
@@ -3,32 +3,56 @@ package review
 import (
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ShopOnGO/review-service/internal/apperr"
+	"github.com/ShopOnGO/review-service/internal/httpcache"
 	"github.com/gin-gonic/gin"
 )
 
 type ReviewHandler struct {
 	reviewSvc *ReviewService
+	cache     httpcache.Policy
 }
 
-func NewReviewHandler(router *gin.Engine,reviewSvc *ReviewService) *ReviewHandler {
-	handler := &ReviewHandler{reviewSvc: reviewSvc}
+// NewReviewHandler регистрирует маршруты отзывов. Публичные GET-ответы отдаются с ETag,
+// Last-Modified и Cache-Control по политике cache.
+func NewReviewHandler(router *gin.Engine,reviewSvc *ReviewService, cache httpcache.Policy) *ReviewHandler {
+	handler := &ReviewHandler{reviewSvc: reviewSvc, cache: cache}
 
 	reviewGroup := router.Group("/reviews-service/reviews")
 	{
+		reviewGroup.GET("/search", handler.searchReviews)
+		reviewGroup.GET("/highlights", handler.getReviewHighlights)
+		reviewGroup.GET("/ratings", handler.getRatingSummaries)
+		reviewGroup.GET("/user/:user_id", handler.getReviewsByUser)
+		reviewGroup.GET("/pending", handler.getPendingReviews)
 		reviewGroup.GET("/:id", handler.getReviewByID)
 	}
 
+	adminGroup := router.Group("/reviews-service/admin/reviews")
+	{
+		adminGroup.POST("/:id/restore", handler.restoreReview)
+	}
+
 	return handler
 }
 
 // getReviewByID godoc
 // @Summary Получить отзыв по ID
-// @Description Возвращает отзыв по его уникальному идентификатору
+// @Description Возвращает отзыв по его уникальному идентификатору. Отзыв на модерации или отклонённый видят только автор с токеном пользователя, модератор и API-шлюз; остальным, в том числе гостю без токена, возвращается 404
 // @Tags Отзывы
 // @Param id path int true "ID отзыва"
+// @Param If-None-Match header string false "ETag из предыдущего ответа"
+// @Param If-Modified-Since header string false "Last-Modified из предыдущего ответа"
 // @Success 200 {object} review.Review
+// @Header 200 {string} ETag "Версия ответа"
+// @Header 200 {string} Last-Modified "Время последнего изменения"
+// @Success 304 "Не изменилось с прошлого запроса"
 // @Failure 400 {object} gin.H "Некорректный ID"
 // @Failure 404 {object} gin.H "Отзыв не найден"
+// @Failure 500 {object} gin.H "Ошибка получения отзыва"
 // @Router /reviews-service/reviews/{id} [get]
 func (h *ReviewHandler) getReviewByID(c *gin.Context) {
 	idParam := c.Param("id")
@@ -38,11 +62,215 @@ func (h *ReviewHandler) getReviewByID(c *gin.Context) {
 		return
 	}
 
-	review, err := h.reviewSvc.GetReviewByID(uint(id))
+	review, err := h.reviewSvc.GetReviewByID(c.Request.Context(), uint(id))
+	if err != nil {
+		apperr.Respond(c, err, "Ошибка получения отзыва")
+		return
+	}
+
+	// Неопубликованный отзыв видят только автор и модератор: CDN его хранить не должен.
+	cacheControl := h.cache.Public()
+	if review.Status != StatusPublished {
+		cacheControl = httpcache.Private
+	}
+	httpcache.JSON(c, review, review.UpdatedAt, cacheControl)
+}
+
+// searchReviews godoc
+// @Summary Поиск по отзывам товара
+// @Description Полнотекстовый поиск (русский и английский) по тексту отзывов товара. Результаты отсортированы по релевантности, совпадения в snippet выделены тегом <b>
+// @Tags Отзывы
+// @Param product_id query int true "ID товара"
+// @Param q query string true "Поисковый запрос"
+// @Param limit query int false "Количество результатов (не более 50)"
+// @Param offset query int false "Смещение"
+// @Param If-None-Match header string false "ETag из предыдущего ответа"
+// @Param If-Modified-Since header string false "Last-Modified из предыдущего ответа"
+// @Success 200 {array} review.ReviewSearchResult
+// @Header 200 {string} ETag "Версия ответа"
+// @Header 200 {string} Last-Modified "Время последнего изменения"
+// @Success 304 "Не изменилось с прошлого запроса"
+// @Failure 400 {object} gin.H "Некорректные параметры поиска"
+// @Failure 500 {object} gin.H "Ошибка поиска отзывов"
+// @Router /reviews-service/reviews/search [get]
+func (h *ReviewHandler) searchReviews(c *gin.Context) {
+	productID, err := strconv.ParseUint(c.Query("product_id"), 10, 64)
+	if err != nil || productID == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Некорректный ID товара"})
+		return
+	}
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	results, err := h.reviewSvc.SearchReviews(c.Request.Context(), uint(productID), c.Query("q"), limit, offset)
+	if err != nil {
+		apperr.Respond(c, err, "Ошибка поиска отзывов")
+		return
+	}
+
+	h.respondProducts(c, results, []uint{uint(productID)})
+}
+
+// getReviewHighlights godoc
+// @Summary Выделенные отзывы товара
+// @Description Возвращает лучший положительный и лучший критический отзывы товара (с учётом закрепления модератором)
+// @Tags Отзывы
+// @Param product_id query int true "ID товара"
+// @Param If-None-Match header string false "ETag из предыдущего ответа"
+// @Param If-Modified-Since header string false "Last-Modified из предыдущего ответа"
+// @Success 200 {object} review.ReviewHighlights
+// @Header 200 {string} ETag "Версия ответа"
+// @Header 200 {string} Last-Modified "Время последнего изменения"
+// @Success 304 "Не изменилось с прошлого запроса"
+// @Failure 400 {object} gin.H "Некорректный ID товара"
+// @Failure 500 {object} gin.H "Ошибка получения отзывов"
+// @Router /reviews-service/reviews/highlights [get]
+func (h *ReviewHandler) getReviewHighlights(c *gin.Context) {
+	productID, err := strconv.ParseUint(c.Query("product_id"), 10, 64)
+	if err != nil || productID == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Некорректный ID товара"})
+		return
+	}
+
+	highlights, err := h.reviewSvc.GetReviewHighlights(c.Request.Context(), uint(productID))
+	if err != nil {
+		apperr.Respond(c, err, "Ошибка получения выделенных отзывов")
+		return
+	}
+
+	h.respondProducts(c, highlights, []uint{uint(productID)})
+}
+
+// getRatingSummaries godoc
+// @Summary Сводки оценок для нескольких товаров
+// @Description Возвращает количество отзывов, среднюю оценку и гистограмму оценок для списка товаров (не более 100 за запрос)
+// @Tags Отзывы
+// @Param product_ids query string true "ID товаров через запятую"
+// @Param If-None-Match header string false "ETag из предыдущего ответа"
+// @Param If-Modified-Since header string false "Last-Modified из предыдущего ответа"
+// @Success 200 {array} review.RatingSummary
+// @Header 200 {string} ETag "Версия ответа"
+// @Header 200 {string} Last-Modified "Время последнего изменения"
+// @Success 304 "Не изменилось с прошлого запроса"
+// @Failure 400 {object} gin.H "Некорректный список товаров"
+// @Failure 500 {object} gin.H "Ошибка получения сводок оценок"
+// @Router /reviews-service/reviews/ratings [get]
+func (h *ReviewHandler) getRatingSummaries(c *gin.Context) {
+	var productIDs []uint
+	for _, raw := range c.QueryArray("product_ids") {
+		for _, part := range strings.Split(raw, ",") {
+			id, err := strconv.ParseUint(strings.TrimSpace(part), 10, 64)
+			if err != nil || id == 0 {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Некорректный ID товара: " + part})
+				return
+			}
+			productIDs = append(productIDs, uint(id))
+		}
+	}
+
+	summaries, err := h.reviewSvc.GetRatingSummaries(c.Request.Context(), productIDs)
+	if err != nil {
+		apperr.Respond(c, err, "Ошибка получения сводок оценок")
+		return
+	}
+
+	h.respondProducts(c, summaries, productIDs)
+}
+
+// getReviewsByUser godoc
+// @Summary Отзывы пользователя
+// @Description Возвращает отзывы пользователя новые первыми: state — published, pending, rejected или deleted. Удалённые отзывы возвращаются, только если viewer_id совпадает с автором
+// @Tags Отзывы
+// @Security BearerAuth
+// @Param user_id path int true "ID пользователя"
+// @Param viewer_id query int false "ID пользователя, запрашивающего список; при запросе с токеном берётся из токена"
+// @Param limit query int false "Количество отзывов"
+// @Param offset query int false "Смещение"
+// @Param If-None-Match header string false "ETag из предыдущего ответа"
+// @Success 200 {array} review.UserReview
+// @Header 200 {string} ETag "Версия ответа"
+// @Success 304 "Не изменилось с прошлого запроса"
+// @Failure 400 {object} gin.H "Некорректный ID пользователя"
+// @Failure 500 {object} gin.H "Ошибка получения отзывов"
+// @Router /reviews-service/reviews/user/{user_id} [get]
+func (h *ReviewHandler) getReviewsByUser(c *gin.Context) {
+	userID, err := strconv.ParseUint(c.Param("user_id"), 10, 64)
+	if err != nil || userID == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Некорректный ID пользователя"})
+		return
+	}
+	viewerID, _ := strconv.ParseUint(c.Query("viewer_id"), 10, 64)
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	reviews, err := h.reviewSvc.GetReviewsByUser(c.Request.Context(), uint(userID), uint(viewerID), limit, offset)
+	if err != nil {
+		apperr.Respond(c, err, "Ошибка получения отзывов")
+		return
+	}
+
+	// Список зависит от того, кто его запрашивает: удалённые отзывы видит только автор.
+	httpcache.JSON(c, reviews, time.Time{}, httpcache.Private)
+}
+
+// getPendingReviews godoc
+// @Summary Очередь модерации
+// @Description Возвращает гостевые отзывы, ожидающие модерации, старые первыми
+// @Tags Отзывы
+// @Security BearerAuth
+// @Param limit query int false "Количество отзывов"
+// @Param offset query int false "Смещение"
+// @Success 200 {array} review.Review
+// @Failure 401 {object} gin.H "Нужен токен"
+// @Failure 403 {object} gin.H "Нужна роль модератора"
+// @Failure 500 {object} gin.H "Ошибка получения отзывов"
+// @Router /reviews-service/reviews/pending [get]
+func (h *ReviewHandler) getPendingReviews(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	reviews, err := h.reviewSvc.GetPendingReviews(c.Request.Context(), limit, offset)
+	if err != nil {
+		apperr.Respond(c, err, "Ошибка получения отзывов")
+		return
+	}
+
+	c.JSON(http.StatusOK, reviews)
+}
+
+// restoreReview godoc
+// @Summary Восстановить удалённый отзыв
+// @Description Снимает пометку об удалении с отзыва. Опубликованный отзыв снова учитывается в рейтинге товара
+// @Tags Администрирование
+// @Security BearerAuth
+// @Param id path int true "ID отзыва"
+// @Success 200 {object} review.Review
+// @Failure 400 {object} gin.H "Некорректный ID"
+// @Failure 401 {object} gin.H "Нужен токен"
+// @Failure 403 {object} gin.H "Нужна роль модератора"
+// @Failure 404 {object} gin.H "Отзыв не найден"
+// @Failure 409 {object} gin.H "Отзыв не удалён"
+// @Failure 500 {object} gin.H "Ошибка восстановления отзыва"
+// @Router /reviews-service/admin/reviews/{id}/restore [post]
+func (h *ReviewHandler) restoreReview(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil || id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Некорректный ID"})
+		return
+	}
+
+	review, err := h.reviewSvc.RestoreReview(c.Request.Context(), uint(id))
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Отзыв не найден"})
+		apperr.Respond(c, err, "Ошибка восстановления отзыва")
 		return
 	}
 
 	c.JSON(http.StatusOK, review)
-}
\ No newline at end of file
+}
+
+// respondProducts отдаёт публичный ответ по отзывам товаров. Last-Modified — последнее
+// изменение их отзывов; если его не удалось получить, ответ проверяется только по ETag.
+func (h *ReviewHandler) respondProducts(c *gin.Context, body interface{}, productIDs []uint) {
+	lastModified, _ := h.reviewSvc.LastModified(c.Request.Context(), productIDs)
+	httpcache.JSON(c, body, lastModified, h.cache.Public())
+}
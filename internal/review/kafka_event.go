@@ -1,127 +1,156 @@
 package review
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/ShopOnGO/ShopOnGO/pkg/logger"
+	"github.com/ShopOnGO/review-service/internal/apperr"
+	"github.com/ShopOnGO/review-service/internal/metrics"
+	"github.com/ShopOnGO/review-service/internal/tracing"
 )
 
-func HandleReviewEvent(msg []byte, key string, reviewSvc *ReviewService) error {
+func HandleReviewEvent(ctx context.Context, msg []byte, key string, reviewSvc *ReviewService) error {
+	start := time.Now()
 	logger.Infof("Получено сообщение: %s", string(msg))
 
 	var base BaseReviewEvent
 	if err := json.Unmarshal(msg, &base); err != nil {
+		metrics.ObserveKafkaEvent("review", metrics.ActionInvalid, start, err)
 		return fmt.Errorf("ошибка десериализации базового сообщения: %w", err)
 	}
 
-	eventHandlers := map[string]func([]byte, *ReviewService) error{
+	eventHandlers := map[string]func(context.Context, []byte, *ReviewService) error{
 		"create":  		HandleCreateReviewEvent,
 		"update":  		HandleUpdateReviewEvent,
 		"delete":  		HandleDeleteReviewEvent,
+		"restore":		HandleRestoreReviewEvent,
 		"addLike": 		HandleAddLikeReviewEvent,
 		"removeLike":	HandleRemoveLikeReviewEvent,
+		"addDislike":	HandleAddDislikeReviewEvent,
+		"removeDislike":	HandleRemoveDislikeReviewEvent,
+		"pinHighlight":	HandlePinHighlightReviewEvent,
+		"unpinHighlight":	HandleUnpinHighlightReviewEvent,
+		"approve":		HandleApproveReviewEvent,
+		"reject":		HandleRejectReviewEvent,
+		"claim":		HandleClaimReviewsEvent,
 	}
 
+	tracing.SetEventAction(ctx, base.Action)
 	handler, exists := eventHandlers[base.Action]
 	if !exists {
-		return fmt.Errorf("неизвестное действие для отзыва: %s", base.Action)
+		err := fmt.Errorf("неизвестное действие для отзыва: %s", base.Action)
+		metrics.ObserveKafkaEvent("review", metrics.ActionUnknown, start, err)
+		return err
 	}
 
-	return handler(msg, reviewSvc)
+	err := handler(ctx, msg, reviewSvc)
+	metrics.ObserveKafkaEvent("review", base.Action, start, err)
+	return err
 }
 
-func HandleCreateReviewEvent(msg []byte, reviewSvc *ReviewService) error {
+func HandleCreateReviewEvent(ctx context.Context, msg []byte, reviewSvc *ReviewService) error {
 	var base BaseReviewEvent
 	if err := json.Unmarshal(msg, &base); err != nil {
 		return fmt.Errorf("ошибка десериализации базового сообщения: %w", err)
 	}
 
 	event := base.Review
-	logger.Infof("Получены данные для создания отзыва: product_id=%d, user_id=%d, rating=%d, likes_count=%d, comment=%q",
-		event.ProductID, base.UserID, event.Rating, event.LikesCount, event.Comment)
+	author := base.Author
+	if author == nil {
+		author = &Author{UserID: &base.UserID}
+	}
+	logger.Infof("Получены данные для создания отзыва: product_id=%d, user=%v, guest=%v, rating=%d, comment=%q",
+		event.ProductID, author.UserID, author.GuestID, event.Rating, event.Comment)
 
-	reviewCreated, err := reviewSvc.AddReview(event.ProductID, base.UserID, event.Rating, event.LikesCount, event.Comment)
+	reviewCreated, err := reviewSvc.AddReview(ctx, event.ProductID, author.UserID, author.GuestID, event.Rating, event.Comment)
 	if err != nil {
 		logger.Errorf("Ошибка при создании отзыва: %v", err)
 		return err
 	}
 
-	if err := reviewSvc.UpdateRatingAfterCreate(reviewCreated.ProductID, reviewCreated.Rating); err != nil {
-		logger.Errorf("Ошибка при обновлении агрегатов рейтинга после создания: %v", err)
-	}
-
 	logger.Infof("Отзыв успешно создан: %+v", reviewCreated)
 	return nil
 }
 
-func HandleUpdateReviewEvent(msg []byte, reviewSvc *ReviewService) error {
+func HandleUpdateReviewEvent(ctx context.Context, msg []byte, reviewSvc *ReviewService) error {
 	var event ReviewUpdatedEvent
 	if err := json.Unmarshal(msg, &event); err != nil {
 		logger.Errorf("Ошибка десериализации события обновления отзыва: %v", err)
 		return err
 	}
 
-	oldReview, err := reviewSvc.GetReviewByID(event.ReviewID)
-	if err != nil {
-		return err
+	// событие без автора отклоняется: консьюмер работает без Principal, и иначе любой
+	// продюсер мог бы переписать чужой отзыв
+	var userID *uint
+	var guestID []byte
+	switch {
+	case event.GuestID != nil && *event.GuestID != "":
+		guestID = []byte(*event.GuestID)
+	case event.UserID != 0:
+		userID = &event.UserID
+	default:
+		logger.Warnf("Событие обновления отзыва %d без автора отклонено", event.ReviewID)
+		return apperr.InvalidArgument("user_id", "user_id or guest_id is required")
 	}
-
-	if oldReview.UserID != event.UserID {
-		logger.Warnf("Попытка обновить отзыв не его создателем user_id: %d, expected user_id: %d", event.UserID, oldReview.UserID)
-		return fmt.Errorf("user %d is not the author of review %d", event.UserID, event.ReviewID)
+	if _, err := reviewSvc.CheckAuthor(ctx, event.ReviewID, userID, guestID); err != nil {
+		logger.Warnf("Попытка обновить отзыв не его создателем user_id: %d, guest_id: %v, review_id: %d", event.UserID, event.GuestID, event.ReviewID)
+		return err
 	}
 
-	var newRating int16 = oldReview.Rating
+	var newRating int16
 	if event.Rating != nil {
 		newRating = *event.Rating
 	}
-	var newComment string = oldReview.Comment
+	var newComment string
 	if event.Comment != nil {
 		newComment = *event.Comment
 	}
 
-	if err := reviewSvc.UpdateReview(event.ReviewID, newRating, newComment); err != nil {
+	if _, err := reviewSvc.UpdateReview(ctx, event.ReviewID, newRating, newComment); err != nil {
 		logger.Errorf("Ошибка при обновлении отзыва: %v", err)
 		return err
 	}
 
-	if event.Rating != nil {
-		if err := reviewSvc.UpdateRatingAfterUpdate(oldReview.ProductID, int(oldReview.Rating), int(newRating)); err != nil {
-			logger.Errorf("Ошибка при обновлении агрегатов рейтинга после редактирования: %v", err)
-		}
-	}
-
 	logger.Infof("Отзыв успешно обновлён. review_id: %d", event.ReviewID)
 	return nil
 }
 
-func HandleDeleteReviewEvent(msg []byte, reviewSvc *ReviewService) error {
+func HandleDeleteReviewEvent(ctx context.Context, msg []byte, reviewSvc *ReviewService) error {
 	var event ReviewDeletedEvent
 	if err := json.Unmarshal(msg, &event); err != nil {
 		logger.Errorf("Ошибка десериализации события удаления отзыва: %v", err)
 		return err
 	}
 
-	oldReview, err := reviewSvc.GetReviewByID(event.ReviewID)
-	if err != nil {
+	if err := reviewSvc.DeleteReview(ctx, event.ReviewID); err != nil {
+		logger.Errorf("Ошибка при удалении отзыва: %v", err)
 		return err
 	}
 
-	if err := reviewSvc.DeleteReview(event.ReviewID); err != nil {
-		logger.Errorf("Ошибка при удалении отзыва: %v", err)
+	logger.Infof("Отзыв успешно удалён. review_id: %d", event.ReviewID)
+	return nil
+}
+
+func HandleRestoreReviewEvent(ctx context.Context, msg []byte, reviewSvc *ReviewService) error {
+	var event ReviewRestoredEvent
+	if err := json.Unmarshal(msg, &event); err != nil {
+		logger.Errorf("Ошибка десериализации события восстановления отзыва: %v", err)
 		return err
 	}
 
-	if err := reviewSvc.UpdateRatingAfterDelete(oldReview.ProductID, int(oldReview.Rating)); err != nil {
-		logger.Errorf("Ошибка при обновлении агрегатов рейтинга после удаления: %v", err)
+	if _, err := reviewSvc.RestoreReview(ctx, event.ReviewID); err != nil {
+		logger.Errorf("Ошибка при восстановлении отзыва: %v", err)
+		return err
 	}
 
-	logger.Infof("Отзыв успешно удалён. review_id: %d", event.ReviewID)
+	logger.Infof("Отзыв успешно восстановлен. review_id: %d", event.ReviewID)
 	return nil
 }
 
-func HandleAddLikeReviewEvent(msg []byte, reviewSvc *ReviewService) error {
+func HandleAddLikeReviewEvent(ctx context.Context, msg []byte, reviewSvc *ReviewService) error {
     logger.Infof("Получено сообщение для лайка: %s", string(msg))
 
     var event struct {
@@ -135,7 +164,7 @@ func HandleAddLikeReviewEvent(msg []byte, reviewSvc *ReviewService) error {
 
     logger.Infof("Добавляем лайк к отзыву: review_id=%d, от user_id=%d", event.ReviewID, event.UserID)
 
-    newLikes, err := reviewSvc.AddLikeToReview(event.ReviewID, event.UserID)
+    newLikes, err := reviewSvc.AddLikeToReview(ctx, event.ReviewID, event.UserID)
     if err != nil {
         logger.Errorf("Ошибка при добавлении лайка: %v", err)
         return err
@@ -146,7 +175,7 @@ func HandleAddLikeReviewEvent(msg []byte, reviewSvc *ReviewService) error {
     return nil
 }
 
-func HandleRemoveLikeReviewEvent(msg []byte, reviewSvc *ReviewService) error {
+func HandleRemoveLikeReviewEvent(ctx context.Context, msg []byte, reviewSvc *ReviewService) error {
     logger.Infof("Получено сообщение для удаления лайка: %s", string(msg))
 
     var event struct {
@@ -160,7 +189,7 @@ func HandleRemoveLikeReviewEvent(msg []byte, reviewSvc *ReviewService) error {
 
     logger.Infof("Удаляем лайк у отзыва: review_id=%d, от user_id=%d", event.ReviewID, event.UserID)
 
-    newLikes, err := reviewSvc.RemoveLikeToReview(event.ReviewID, event.UserID)
+    newLikes, err := reviewSvc.RemoveLikeToReview(ctx, event.ReviewID, event.UserID)
     if err != nil {
         logger.Errorf("Ошибка при удалении лайка к отзыву: %v", err)
         return err
@@ -168,4 +197,123 @@ func HandleRemoveLikeReviewEvent(msg []byte, reviewSvc *ReviewService) error {
 
 	logger.Infof("Лайк успешно удален. review_id: %d, user_id: %d, new_likes: %d", event.ReviewID, event.UserID, newLikes)
     return nil
-}
\ No newline at end of file
+}
+
+func HandleAddDislikeReviewEvent(ctx context.Context, msg []byte, reviewSvc *ReviewService) error {
+    logger.Infof("Получено сообщение для голоса «бесполезно»: %s", string(msg))
+
+    var event ReviewVoteEvent
+    if err := json.Unmarshal(msg, &event); err != nil {
+        logger.Errorf("Ошибка десериализации события добавления дизлайка: %v", err)
+        return err
+    }
+
+    newDislikes, err := reviewSvc.AddDislikeToReview(ctx, event.ReviewID, event.UserID)
+    if err != nil {
+        logger.Errorf("Ошибка при добавлении дизлайка к отзыву: %v", err)
+        return err
+    }
+
+    logger.Infof("Дизлайк успешно добавлен. review_id: %d, user_id: %d, new_dislikes: %d", event.ReviewID, event.UserID, newDislikes)
+    return nil
+}
+
+func HandleRemoveDislikeReviewEvent(ctx context.Context, msg []byte, reviewSvc *ReviewService) error {
+    logger.Infof("Получено сообщение для удаления голоса «бесполезно»: %s", string(msg))
+
+    var event ReviewVoteEvent
+    if err := json.Unmarshal(msg, &event); err != nil {
+        logger.Errorf("Ошибка десериализации события удаления дизлайка: %v", err)
+        return err
+    }
+
+    newDislikes, err := reviewSvc.RemoveDislikeToReview(ctx, event.ReviewID, event.UserID)
+    if err != nil {
+        logger.Errorf("Ошибка при удалении дизлайка у отзыва: %v", err)
+        return err
+    }
+
+    logger.Infof("Дизлайк успешно удален. review_id: %d, user_id: %d, new_dislikes: %d", event.ReviewID, event.UserID, newDislikes)
+    return nil
+}
+
+func HandlePinHighlightReviewEvent(ctx context.Context, msg []byte, reviewSvc *ReviewService) error {
+	var event ReviewHighlightEvent
+	if err := json.Unmarshal(msg, &event); err != nil {
+		logger.Errorf("Ошибка десериализации события закрепления отзыва: %v", err)
+		return err
+	}
+
+	if err := reviewSvc.PinHighlight(ctx, event.ProductID, event.ReviewID, event.Kind); err != nil {
+		logger.Errorf("Ошибка при закреплении отзыва: %v", err)
+		return err
+	}
+
+	logger.Infof("Отзыв закреплён. review_id: %d, kind: %s", event.ReviewID, event.Kind)
+	return nil
+}
+
+func HandleUnpinHighlightReviewEvent(ctx context.Context, msg []byte, reviewSvc *ReviewService) error {
+	var event ReviewHighlightEvent
+	if err := json.Unmarshal(msg, &event); err != nil {
+		logger.Errorf("Ошибка десериализации события открепления отзыва: %v", err)
+		return err
+	}
+
+	if err := reviewSvc.UnpinHighlight(ctx, event.ProductID, event.Kind); err != nil {
+		logger.Errorf("Ошибка при откреплении отзыва: %v", err)
+		return err
+	}
+
+	logger.Infof("Закрепление снято. product_id: %d, kind: %s", event.ProductID, event.Kind)
+	return nil
+}
+
+func HandleApproveReviewEvent(ctx context.Context, msg []byte, reviewSvc *ReviewService) error {
+	var event ReviewModerationEvent
+	if err := json.Unmarshal(msg, &event); err != nil {
+		logger.Errorf("Ошибка десериализации события одобрения отзыва: %v", err)
+		return err
+	}
+
+	if _, err := reviewSvc.ApproveReview(ctx, event.ReviewID); err != nil {
+		logger.Errorf("Ошибка при одобрении отзыва: %v", err)
+		return err
+	}
+
+	logger.Infof("Отзыв одобрен модератором. review_id: %d", event.ReviewID)
+	return nil
+}
+
+func HandleRejectReviewEvent(ctx context.Context, msg []byte, reviewSvc *ReviewService) error {
+	var event ReviewModerationEvent
+	if err := json.Unmarshal(msg, &event); err != nil {
+		logger.Errorf("Ошибка десериализации события отклонения отзыва: %v", err)
+		return err
+	}
+
+	if _, err := reviewSvc.RejectReview(ctx, event.ReviewID); err != nil {
+		logger.Errorf("Ошибка при отклонении отзыва: %v", err)
+		return err
+	}
+
+	logger.Infof("Отзыв отклонён модератором. review_id: %d", event.ReviewID)
+	return nil
+}
+
+func HandleClaimReviewsEvent(ctx context.Context, msg []byte, reviewSvc *ReviewService) error {
+	var event ReviewClaimEvent
+	if err := json.Unmarshal(msg, &event); err != nil {
+		logger.Errorf("Ошибка десериализации события привязки гостевых отзывов: %v", err)
+		return err
+	}
+
+	claimed, err := reviewSvc.ClaimGuestReviews(ctx, event.GuestID, event.UserID)
+	if err != nil {
+		logger.Errorf("Ошибка при привязке гостевых отзывов: %v", err)
+		return err
+	}
+
+	logger.Infof("Гостевые отзывы привязаны к пользователю. user_id: %d, claimed: %d", event.UserID, claimed)
+	return nil
+}
@@ -0,0 +1,25 @@
+package review
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ShopOnGO/review-service/internal/apperr"
+)
+
+func TestHandleUpdateReviewEventRequiresAuthor(t *testing.T) {
+	tests := map[string]string{
+		"no author":      `{"action":"update","review_id":1,"comment":"spam"}`,
+		"zero user":      `{"action":"update","review_id":1,"user_id":0,"comment":"spam"}`,
+		"empty guest id": `{"action":"update","review_id":1,"guest_id":"","comment":"spam"}`,
+	}
+	for name, msg := range tests {
+		t.Run(name, func(t *testing.T) {
+			// событие отклоняется до обращения к сервису
+			err := HandleUpdateReviewEvent(context.Background(), []byte(msg), nil)
+			if apperr.KindOf(err) != apperr.KindInvalidArgument {
+				t.Fatalf("got %v, want an invalid argument", err)
+			}
+		})
+	}
+}
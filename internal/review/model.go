@@ -1,14 +1,107 @@
 package review
 
 import (
+	"bytes"
+	"time"
+
 	"gorm.io/gorm"
 )
 
 type Review struct {
 	gorm.Model
-	UserID             	uint      	`gorm:"not null" json:"user_id"`
+	UserID             	*uint      	`gorm:"index" json:"user_id"`
+	GuestID            	[]byte    	`gorm:"type:bytea;index" json:"guest_id"`
 	ProductID   	uint      	`gorm:"not null" json:"product_variant_id"`
 	Rating             	int16     	`gorm:"not null;check:rating >= 1 AND rating <= 5" json:"rating"`
 	LikesCount			int    		`gorm:"default:0" json:"likes_count"`
+	DislikesCount		int    		`gorm:"default:0" json:"dislikes_count"`
 	Comment            	string		`gorm:"not null" json:"comment"`
+	Status             	string    	`gorm:"size:16;not null;default:'published';index" json:"status"`
+}
+
+// IsAuthor сообщает, написан ли отзыв указанным пользователем или гостем.
+func (r *Review) IsAuthor(userID *uint, guestID []byte) bool {
+	if userID != nil {
+		return r.UserID != nil && *r.UserID == *userID
+	}
+	return len(guestID) > 0 && bytes.Equal(r.GuestID, guestID)
+}
+
+// Статусы отзыва. Pending и Rejected — результат модерации гостевых отзывов,
+// Deleted вычисляется по DeletedAt и в базе не хранится.
+const (
+	StatusPublished = "published"
+	StatusPending   = "pending"
+	StatusRejected  = "rejected"
+	StatusDeleted   = "deleted"
+)
+
+// UserReview — отзыв в списке «мои отзывы». State — состояние для автора: статус модерации
+// из Review.Status или StatusDeleted для удалённого отзыва.
+type UserReview struct {
+	Review
+	State string `json:"state"`
+}
+
+// ReviewVote — голос пользователя «полезно» (Helpful = true) или «бесполезно» за отзыв.
+// У пользователя может быть только один голос за отзыв, счётчики LikesCount/DislikesCount
+// в Review пересчитываются вместе с ним.
+type ReviewVote struct {
+	ID        uint      `gorm:"primarykey" json:"id"`
+	ReviewID  uint      `gorm:"not null;uniqueIndex:idx_review_votes_review_user" json:"review_id"`
+	UserID    uint      `gorm:"not null;uniqueIndex:idx_review_votes_review_user;index" json:"user_id"`
+	Helpful   bool      `gorm:"not null" json:"helpful"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Виды выделенных отзывов товара.
+const (
+	HighlightPositive = "positive"
+	HighlightCritical = "critical"
+)
+
+// positiveMinRating — минимальная оценка положительного отзыва; отзывы ниже — критические.
+const positiveMinRating = 4
+
+// matchesHighlight сообщает, подходит ли оценка отзыва для выделенного отзыва вида kind.
+func matchesHighlight(rating int16, kind string) bool {
+	if kind == HighlightPositive {
+		return rating >= positiveMinRating
+	}
+	return rating < positiveMinRating
+}
+
+// ReviewHighlight — выделенный отзыв товара («лучший положительный» / «лучший критический»).
+// Pinned означает, что отзыв закреплён модератором и не заменяется автоматическим пересчётом.
+type ReviewHighlight struct {
+	ProductID uint      `gorm:"primaryKey;autoIncrement:false" json:"product_id"`
+	Kind      string    `gorm:"primaryKey;size:16" json:"kind"`
+	ReviewID  uint      `gorm:"not null;index" json:"review_id"`
+	Pinned    bool      `gorm:"not null;default:false" json:"pinned"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ReviewHighlights — выделенные отзывы товара в том виде, в котором их отдаёт API.
+type ReviewHighlights struct {
+	ProductID      uint    `json:"product_id"`
+	TopPositive    *Review `json:"top_positive"`
+	TopCritical    *Review `json:"top_critical"`
+	PositivePinned bool    `json:"positive_pinned"`
+	CriticalPinned bool    `json:"critical_pinned"`
+}
+
+// RatingSummary — агрегаты оценок товара. Histogram[i] — количество отзывов с оценкой i+1.
+type RatingSummary struct {
+	ProductID uint     `json:"product_id"`
+	Count     int64    `json:"count"`
+	Average   float64  `json:"average"`
+	Histogram [5]int64 `json:"histogram"`
+}
+
+// ReviewSearchResult — отзыв, найденный полнотекстовым поиском, с релевантностью и подсвеченным фрагментом.
+type ReviewSearchResult struct {
+	Review
+	Rank    float32 `json:"rank"`
+	Snippet string  `json:"snippet"`
 }
@@ -4,12 +4,19 @@ type BaseReviewEvent struct {
 	Action string 				`json:"action"`
 	Review ReviewCreatedEvent   `json:"product"`
 	UserID uint   				`json:"user_id"`
+	Author *Author 				`json:"author,omitempty"`
+}
+
+// Author — автор отзыва: зарегистрированный пользователь или гость. Если не задан,
+// автором считается BaseReviewEvent.UserID.
+type Author struct {
+	UserID  *uint   `json:"user_id,omitempty"`
+	GuestID *string `json:"guest_id,omitempty"`
 }
 
 type ReviewCreatedEvent struct {
 	ProductID 	uint   `json:"product_id"`
 	Rating      int16  `json:"rating"`
-	LikesCount	int    `gorm:"default:0" json:"likes_count"`
 	Comment     string `json:"comment"`
 }
 
@@ -17,6 +24,7 @@ type ReviewUpdatedEvent struct {
 	Action   string  `json:"action"`
 	ReviewID uint    `json:"review_id"`
 	UserID   uint    `json:"user_id"`
+	GuestID  *string `json:"guest_id,omitempty"`
 	Rating   *int16  `json:"rating,omitempty"`
 	Comment  *string `json:"comment,omitempty"`
 }
@@ -24,4 +32,36 @@ type ReviewUpdatedEvent struct {
 type ReviewDeletedEvent struct {
 	Action   string `json:"action"`
 	ReviewID uint   `json:"review_id"`
-}
\ No newline at end of file
+}
+
+type ReviewRestoredEvent struct {
+	Action   string `json:"action"`
+	ReviewID uint   `json:"review_id"`
+}
+
+type ReviewVoteEvent struct {
+	Action   string `json:"action"`
+	ReviewID uint   `json:"review_id"`
+	UserID   uint   `json:"user_id"`
+}
+
+// ReviewHighlightEvent — действие модератора: pinHighlight (по review_id; product_id, если задан,
+// проверяется на совпадение с товаром отзыва) или unpinHighlight (по product_id).
+type ReviewHighlightEvent struct {
+	Action    string `json:"action"`
+	ReviewID  uint   `json:"review_id,omitempty"`
+	ProductID uint   `json:"product_id,omitempty"`
+	Kind      string `json:"kind"`
+}
+
+// ReviewModerationEvent — решение модератора по гостевому отзыву: approve или reject.
+type ReviewModerationEvent struct {
+	Action   string `json:"action"`
+	ReviewID uint   `json:"review_id"`
+}
+
+type ReviewClaimEvent struct {
+	Action  string `json:"action"`
+	GuestID string `json:"guest_id"`
+	UserID  uint   `json:"user_id"`
+}
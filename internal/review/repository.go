@@ -1,13 +1,24 @@
 package review
 
 import (
+	"context"
+	"database/sql"
 	"errors"
-	"fmt"
+	"time"
 
+	"github.com/ShopOnGO/review-service/internal/apperr"
 	"github.com/ShopOnGO/review-service/pkg/db"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// wilsonLowerBoundSQL — нижняя граница доверительного интервала Уилсона (z = 1.96) для доли
+// голосов «полезно». В отличие от сырого likes_count, отзыв 3/3 не проигрывает навсегда отзыву 10/40.
+const wilsonLowerBoundSQL = `CASE WHEN likes_count + dislikes_count = 0 THEN 0 ELSE
+	((likes_count + 1.9208) / (likes_count + dislikes_count)
+		- 1.96 * sqrt(likes_count::numeric * dislikes_count / (likes_count + dislikes_count) + 0.9604) / (likes_count + dislikes_count))
+	/ (1 + 3.8416 / (likes_count + dislikes_count)) END`
+
 type ReviewRepository struct {
 	Db *db.Db
 }
@@ -18,36 +29,53 @@ func NewReviewRepository(db *db.Db) *ReviewRepository {
 	}
 }
 
-func (r *ReviewRepository) CreateReview(review *Review) error {
-	return r.Db.Create(review).Error
+func (r *ReviewRepository) CreateReview(ctx context.Context, review *Review) error {
+	return r.Db.WithContext(ctx).Create(review).Error
 }
 
-func (r *ReviewRepository) GetReviewByID(id uint) (*Review, error) {
+func (r *ReviewRepository) GetReviewByID(ctx context.Context, id uint) (*Review, error) {
 	var review Review
-	err := r.Db.First(&review, id).Error
+	err := r.Db.WithContext(ctx).First(&review, id).Error
 	if err != nil {
 		return nil, err
 	}
 	return &review, nil
 }
 
-func (r *ReviewRepository) GetReviewsByProductID(productID uint) ([]Review, error) {
+// GetReviewByIDUnscoped возвращает отзыв по ID, в том числе мягко удалённый.
+func (r *ReviewRepository) GetReviewByIDUnscoped(ctx context.Context, id uint) (*Review, error) {
+	var review Review
+	err := r.Db.WithContext(ctx).Unscoped().First(&review, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &review, nil
+}
+
+func (r *ReviewRepository) GetReviewsByProductID(ctx context.Context, productID uint) ([]Review, error) {
 	var reviews []Review
-	err := r.Db.Where("product_id = ?", productID).Find(&reviews).Error
+	err := r.Db.WithContext(ctx).Where("product_id = ?", productID).Find(&reviews).Error
 	if err != nil {
 		return nil, err
 	}
 	return reviews, nil
 }
 
-func (r *ReviewRepository) GetReviewsByProductIDPaginated(productID uint, limit, offset int) ([]*Review, error) {
+func (r *ReviewRepository) GetReviewsByProductIDPaginated(ctx context.Context, productID uint, limit, offset int, sort string) ([]*Review, error) {
 	var reviews []*Review
-	result := r.Db.
-		Where("product_id = ?", productID).
+	query := r.Db.WithContext(ctx).
+		Where("product_id = ? AND status = ?", productID, StatusPublished).
 		Limit(limit).
-		Offset(offset).
-		Order("created_at DESC"). //сначала новые отзывы, можно изменить
-		Find(&reviews)
+		Offset(offset)
+
+	switch sort {
+	case SortHelpful:
+		query = query.Order(wilsonLowerBoundSQL + " DESC").Order("created_at DESC")
+	default:
+		query = query.Order("created_at DESC") //сначала новые отзывы
+	}
+
+	result := query.Find(&reviews)
 
 	if result.Error != nil {
 		return nil, result.Error
@@ -56,21 +84,105 @@ func (r *ReviewRepository) GetReviewsByProductIDPaginated(productID uint, limit,
 	return reviews, nil
 }
 
-func (r *ReviewRepository) getLikesCount(reviewID uint) (uint, error) {
-    var likesCount uint
-    err := r.Db.Raw(`SELECT likes_count FROM reviews WHERE id = ?`, reviewID).Scan(&likesCount).Error
-    if err != nil {
-        if errors.Is(err, gorm.ErrRecordNotFound) {
-            return 0, fmt.Errorf("review not found")
-        }
-        return 0, err
-    }
+// GetReviewsByUserIDPaginated возвращает отзывы пользователя, новые первыми.
+// ownerView включает мягко удалённые и неопубликованные отзывы.
+func (r *ReviewRepository) GetReviewsByUserIDPaginated(ctx context.Context, userID uint, limit, offset int, ownerView bool) ([]*Review, error) {
+	query := r.Db.WithContext(ctx)
+	if ownerView {
+		query = query.Unscoped()
+	} else {
+		query = query.Where("status = ?", StatusPublished)
+	}
 
-    return likesCount, nil
+	var reviews []*Review
+	err := query.
+		Where("user_id = ?", userID).
+		Limit(limit).
+		Offset(offset).
+		Order("created_at DESC").
+		Find(&reviews).Error
+	if err != nil {
+		return nil, err
+	}
+	return reviews, nil
+}
+
+// GetRatingSummaries считает количество, среднюю оценку и гистограмму оценок для всех
+// товаров одним запросом. Товары без отзывов в результат не попадают.
+func (r *ReviewRepository) GetRatingSummaries(ctx context.Context, productIDs []uint) ([]*RatingSummary, error) {
+	var rows []struct {
+		ProductID uint
+		Count     int64
+		Average   float64
+		Rating1   int64
+		Rating2   int64
+		Rating3   int64
+		Rating4   int64
+		Rating5   int64
+	}
+	err := r.Db.WithContext(ctx).Model(&Review{}).
+		Select(`product_id,
+			COUNT(*) AS count,
+			AVG(rating)::float8 AS average,
+			COUNT(*) FILTER (WHERE rating = 1) AS rating1,
+			COUNT(*) FILTER (WHERE rating = 2) AS rating2,
+			COUNT(*) FILTER (WHERE rating = 3) AS rating3,
+			COUNT(*) FILTER (WHERE rating = 4) AS rating4,
+			COUNT(*) FILTER (WHERE rating = 5) AS rating5`).
+		Where("product_id IN ? AND status = ?", productIDs, StatusPublished).
+		Group("product_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	summaries := make([]*RatingSummary, 0, len(rows))
+	for _, row := range rows {
+		summaries = append(summaries, &RatingSummary{
+			ProductID: row.ProductID,
+			Count:     row.Count,
+			Average:   row.Average,
+			Histogram: [5]int64{row.Rating1, row.Rating2, row.Rating3, row.Rating4, row.Rating5},
+		})
+	}
+	return summaries, nil
+}
+
+// LastModified возвращает время последнего изменения отзывов товаров с учётом удалённых
+// отзывов и пересчёта выделенных; нулевое время — у товаров нет отзывов.
+func (r *ReviewRepository) LastModified(ctx context.Context, productIDs []uint) (time.Time, error) {
+	var lastModified sql.NullTime
+	err := r.Db.WithContext(ctx).Raw(`
+		SELECT GREATEST(
+			(SELECT MAX(GREATEST(updated_at, deleted_at)) FROM reviews WHERE product_id IN ?),
+			(SELECT MAX(updated_at) FROM review_highlights WHERE product_id IN ?))
+	`, productIDs, productIDs).Row().Scan(&lastModified)
+	return lastModified.Time, err
 }
 
-func (r *ReviewRepository) UpdateRating(productID uint, newRating int) error {
-    return r.Db.Transaction(func(tx *gorm.DB) error {
+func (r *ReviewRepository) SearchReviewsByProductID(ctx context.Context, productID uint, query string, limit, offset int) ([]*ReviewSearchResult, error) {
+	var results []*ReviewSearchResult
+	err := r.Db.WithContext(ctx).Raw(`
+		SELECT reviews.*,
+			ts_rank(search_vector, q.query) AS rank,
+			ts_headline('russian', comment, q.query, 'StartSel=<b>, StopSel=</b>, MaxFragments=2, MaxWords=25, MinWords=5') AS snippet
+		FROM reviews,
+			(SELECT websearch_to_tsquery('russian', ?) || websearch_to_tsquery('english', ?) AS query) q
+		WHERE product_id = ?
+			AND status = 'published'
+			AND deleted_at IS NULL
+			AND search_vector @@ q.query
+		ORDER BY rank DESC, created_at DESC
+		LIMIT ? OFFSET ?
+	`, query, query, productID, limit, offset).Scan(&results).Error
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func (r *ReviewRepository) UpdateRating(ctx context.Context, productID uint, newRating int) error {
+    return r.Db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
         res := tx.Exec(`
             UPDATE products
             SET 
@@ -88,9 +200,9 @@ func (r *ReviewRepository) UpdateRating(productID uint, newRating int) error {
 }
 
 // UpdateRatingDelta — корректируем сумму при update (count не меняется)
-func (r *ReviewRepository) UpdateRatingDelta(productID uint, oldRating, newRating int) error {
+func (r *ReviewRepository) UpdateRatingDelta(ctx context.Context, productID uint, oldRating, newRating int) error {
     delta := newRating - oldRating
-    return r.Db.Transaction(func(tx *gorm.DB) error {
+    return r.Db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
         res := tx.Exec(`
             UPDATE products
             SET 
@@ -103,8 +215,8 @@ func (r *ReviewRepository) UpdateRatingDelta(productID uint, oldRating, newRatin
     })
 }
 
-func (r *ReviewRepository) UpdateRatingDelete(productID uint, oldRating int) error {
-    return r.Db.Transaction(func(tx *gorm.DB) error {
+func (r *ReviewRepository) UpdateRatingDelete(ctx context.Context, productID uint, oldRating int) error {
+    return r.Db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
         res := tx.Exec(`
             UPDATE products
             SET 
@@ -122,58 +234,225 @@ func (r *ReviewRepository) UpdateRatingDelete(productID uint, oldRating int) err
     })
 }
 
-func (r *ReviewRepository) IncrementLikes(reviewID uint) (uint, error) {
-    var newLikes uint
+// SetVote сохраняет голос пользователя за отзыв и пересчитывает счётчики в одной транзакции.
+// Повторный такой же голос ничего не меняет, противоположный — переносит голос из одного счётчика в другой.
+func (r *ReviewRepository) SetVote(ctx context.Context, reviewID, userID uint, helpful bool) (*Review, error) {
+    var review Review
+    err := r.Db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+        if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&review, reviewID).Error; err != nil {
+            if errors.Is(err, gorm.ErrRecordNotFound) {
+                return apperr.NotFound("review", reviewID)
+            }
+            return err
+        }
 
-    err := r.Db.Raw(`
-        UPDATE reviews
-        SET likes_count = likes_count + 1
-        WHERE id = ?
-        RETURNING likes_count
-    `, reviewID).Scan(&newLikes).Error
+        var vote ReviewVote
+        err := tx.Where("review_id = ? AND user_id = ?", reviewID, userID).First(&vote).Error
+        switch {
+        case errors.Is(err, gorm.ErrRecordNotFound):
+            vote = ReviewVote{ReviewID: reviewID, UserID: userID, Helpful: helpful}
+            if err := tx.Create(&vote).Error; err != nil {
+                return err
+            }
+            return applyVoteDelta(tx, &review, helpful, 1)
+        case err != nil:
+            return err
+        case vote.Helpful == helpful:
+            return nil
+        }
 
-    if err != nil {
-        if errors.Is(err, gorm.ErrRecordNotFound) || newLikes == 0 {
-            return 0, fmt.Errorf("review not found")
+        if err := tx.Model(&vote).Update("helpful", helpful).Error; err != nil {
+            return err
         }
-        return 0, err
+        if err := applyVoteDelta(tx, &review, !helpful, -1); err != nil {
+            return err
+        }
+        return applyVoteDelta(tx, &review, helpful, 1)
+    })
+    if err != nil {
+        return nil, err
     }
-
-    return newLikes, nil
+    return &review, nil
 }
 
+// RemoveVote снимает голос пользователя указанного типа и уменьшает соответствующий счётчик.
+// Если такого голоса нет (уже снят или поставлен до появления review_votes и не привязан
+// к пользователю), ничего не меняет и возвращает текущие счётчики: повтор запроса безопасен.
+func (r *ReviewRepository) RemoveVote(ctx context.Context, reviewID, userID uint, helpful bool) (*Review, error) {
+    var review Review
+    err := r.Db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+        if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&review, reviewID).Error; err != nil {
+            if errors.Is(err, gorm.ErrRecordNotFound) {
+                return apperr.NotFound("review", reviewID)
+            }
+            return err
+        }
 
-func (r *ReviewRepository) DecrementLikes(reviewID uint) (uint, error) {
-    currentLikes, err := r.getLikesCount(reviewID)
+        res := tx.Where("review_id = ? AND user_id = ? AND helpful = ?", reviewID, userID, helpful).Delete(&ReviewVote{})
+        if res.Error != nil {
+            return res.Error
+        }
+        if res.RowsAffected == 0 {
+            return nil
+        }
+        return applyVoteDelta(tx, &review, helpful, -1)
+    })
     if err != nil {
-        return 0, err
+        return nil, err
     }
-    if currentLikes < 1 {
-        return 0, fmt.Errorf("likes count cannot be less than 1")
+    return &review, nil
+}
+
+func applyVoteDelta(tx *gorm.DB, review *Review, helpful bool, delta int) error {
+    column := "dislikes_count"
+    if helpful {
+        column = "likes_count"
     }
+    err := tx.Model(review).
+        Clauses(clause.Returning{Columns: []clause.Column{{Name: "likes_count"}, {Name: "dislikes_count"}, {Name: "updated_at"}}}).
+        Update(column, gorm.Expr("GREATEST("+column+" + ?, 0)", delta)).Error
+    return err
+}
 
-    var newLikes uint
-    err = r.Db.Raw(`
-        UPDATE reviews
-        SET likes_count = likes_count - 1
-        WHERE id = ?
-        RETURNING likes_count
-    `, reviewID).Scan(&newLikes).Error
+// GetPendingReviews возвращает очередь отзывов на модерацию, старые первыми.
+func (r *ReviewRepository) GetPendingReviews(ctx context.Context, limit, offset int) ([]*Review, error) {
+	var reviews []*Review
+	err := r.Db.WithContext(ctx).
+		Where("status = ?", StatusPending).
+		Limit(limit).
+		Offset(offset).
+		Order("created_at ASC").
+		Find(&reviews).Error
+	return reviews, err
+}
 
-    if err != nil {
-        if errors.Is(err, gorm.ErrRecordNotFound) || newLikes == 0 {
-            return 0, fmt.Errorf("review not found")
-        }
-        return 0, err
-    }
+// ModerateReview переводит отзыв из очереди модерации в status. Возвращает false, если отзыв
+// уже не ожидает модерации: одновременный или повторный вызов не меняет статус второй раз.
+func (r *ReviewRepository) ModerateReview(ctx context.Context, review *Review, status string) (bool, error) {
+	res := r.Db.WithContext(ctx).Model(&Review{}).
+		Where("id = ? AND status = ?", review.ID, StatusPending).
+		Update("status", status)
+	return res.RowsAffected > 0, res.Error
+}
+
+// ClaimGuestReviews привязывает все отзывы гостя (включая удалённые) к пользователю.
+func (r *ReviewRepository) ClaimGuestReviews(ctx context.Context, guestID []byte, userID uint) (int64, error) {
+	res := r.Db.WithContext(ctx).Unscoped().Model(&Review{}).
+		Where("guest_id = ?", guestID).
+		Updates(map[string]interface{}{"user_id": userID, "guest_id": nil})
+	return res.RowsAffected, res.Error
+}
 
-    return newLikes, nil
+func (r *ReviewRepository) UpdateReview(ctx context.Context, review *Review) error {
+	return r.Db.WithContext(ctx).Save(review).Error
 }
 
-func (r *ReviewRepository) UpdateReview(review *Review) error {
-	return r.Db.Save(review).Error
+func (r *ReviewRepository) DeleteReview(ctx context.Context, review *Review) error {
+	return r.Db.WithContext(ctx).Delete(review).Error
+}
+
+// RestoreReview снимает пометку об удалении. Возвращает false, если отзыв уже не был удалён
+// (например, его восстановил параллельный запрос).
+func (r *ReviewRepository) RestoreReview(ctx context.Context, review *Review) (bool, error) {
+	res := r.Db.WithContext(ctx).Unscoped().Model(&Review{}).
+		Where("id = ? AND deleted_at IS NOT NULL", review.ID).
+		Update("deleted_at", nil)
+	return res.RowsAffected > 0, res.Error
+}
+
+func (r *ReviewRepository) GetHighlights(ctx context.Context, productID uint) ([]ReviewHighlight, error) {
+	var highlights []ReviewHighlight
+	err := r.Db.WithContext(ctx).Where("product_id = ?", productID).Find(&highlights).Error
+	return highlights, err
+}
+
+// RefreshHighlights пересчитывает выделенные отзывы товара. Закреплённые модератором
+// отзывы сохраняются, пока сами отзывы существуют и опубликованы.
+func (r *ReviewRepository) RefreshHighlights(ctx context.Context, productID uint) error {
+	return r.Db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, kind := range []string{HighlightPositive, HighlightCritical} {
+			var current ReviewHighlight
+			err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+				Where("product_id = ? AND kind = ?", productID, kind).
+				First(&current).Error
+			if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+				return err
+			}
+			if err == nil && current.Pinned {
+				var count int64
+				err := tx.Model(&Review{}).
+					Where("id = ? AND status = ?", current.ReviewID, StatusPublished).
+					Count(&count).Error
+				if err != nil {
+					return err
+				}
+				if count > 0 {
+					continue
+				}
+			}
+
+			candidate, err := findHighlightCandidate(tx, productID, kind)
+			if err != nil {
+				return err
+			}
+			if candidate == nil {
+				if err := tx.Where("product_id = ? AND kind = ?", productID, kind).Delete(&ReviewHighlight{}).Error; err != nil {
+					return err
+				}
+				continue
+			}
+			if err := upsertHighlight(tx, &ReviewHighlight{ProductID: productID, Kind: kind, ReviewID: candidate.ID}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *ReviewRepository) PinHighlight(ctx context.Context, review *Review, kind string) error {
+	return upsertHighlight(r.Db.WithContext(ctx), &ReviewHighlight{
+		ProductID: review.ProductID,
+		Kind:      kind,
+		ReviewID:  review.ID,
+		Pinned:    true,
+	})
+}
+
+func (r *ReviewRepository) UnpinHighlight(ctx context.Context, productID uint, kind string) error {
+	return r.Db.WithContext(ctx).Model(&ReviewHighlight{}).
+		Where("product_id = ? AND kind = ?", productID, kind).
+		Update("pinned", false).Error
+}
+
+// HighlightCandidate выбирает лучший отзыв вида kind без сохранения — для товаров, выделенные
+// отзывы которых ещё не пересчитывались. nil — подходящих отзывов нет.
+func (r *ReviewRepository) HighlightCandidate(ctx context.Context, productID uint, kind string) (*Review, error) {
+	return findHighlightCandidate(r.Db.WithContext(ctx), productID, kind)
+}
+
+// findHighlightCandidate выбирает лучший отзыв вида kind: сначала по полезности (нижняя граница Уилсона),
+// затем по крайности оценки и свежести. Возвращает nil, если подходящих отзывов нет.
+func findHighlightCandidate(tx *gorm.DB, productID uint, kind string) (*Review, error) {
+	query := tx.Where("product_id = ? AND status = ? AND comment <> ''", productID, StatusPublished)
+	if kind == HighlightPositive {
+		query = query.Where("rating >= ?", positiveMinRating).Order(wilsonLowerBoundSQL + " DESC").Order("rating DESC")
+	} else {
+		query = query.Where("rating < ?", positiveMinRating).Order(wilsonLowerBoundSQL + " DESC").Order("rating ASC")
+	}
+
+	var reviews []*Review
+	if err := query.Order("created_at DESC").Limit(1).Find(&reviews).Error; err != nil {
+		return nil, err
+	}
+	if len(reviews) == 0 {
+		return nil, nil
+	}
+	return reviews[0], nil
 }
 
-func (r *ReviewRepository) DeleteReview(review *Review) error {
-	return r.Db.Delete(review).Error
+func upsertHighlight(tx *gorm.DB, highlight *ReviewHighlight) error {
+	return tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "product_id"}, {Name: "kind"}},
+		DoUpdates: clause.AssignmentColumns([]string{"review_id", "pinned", "updated_at"}),
+	}).Create(highlight).Error
 }
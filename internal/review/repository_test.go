@@ -0,0 +1,387 @@
+package review
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"github.com/ShopOnGO/review-service/internal/apperr"
+	"github.com/ShopOnGO/review-service/internal/auth"
+	"github.com/ShopOnGO/review-service/migrations"
+	"github.com/ShopOnGO/review-service/pkg/db"
+)
+
+// testDB открывает базу из REVIEW_TEST_DSN в отдельной схеме с применёнными миграциями
+// и таблицей products, которую в проде ведёт сервис товаров. Схема удаляется после теста;
+// без REVIEW_TEST_DSN тесты базы пропускаются.
+func testDB(t *testing.T) *db.Db {
+	t.Helper()
+	dsn := os.Getenv("REVIEW_TEST_DSN")
+	if dsn == "" {
+		t.Skip("REVIEW_TEST_DSN is not set")
+	}
+	gormDB, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open test database: %v", err)
+	}
+	sqlDB, err := gormDB.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// одно соединение, чтобы search_path действовал на все запросы теста
+	sqlDB.SetMaxOpenConns(1)
+
+	schema := fmt.Sprintf("review_test_%d", time.Now().UnixNano())
+	mustExec(t, gormDB, "CREATE SCHEMA "+schema)
+	t.Cleanup(func() {
+		gormDB.Exec("DROP SCHEMA " + schema + " CASCADE")
+		sqlDB.Close()
+	})
+	mustExec(t, gormDB, "SET search_path TO "+schema)
+
+	ctx := context.Background()
+	migrator, err := migrations.NewMigrator(ctx, gormDB)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := migrator.Up(ctx); err != nil {
+		t.Fatal(err)
+	}
+	mustExec(t, gormDB, `CREATE TABLE products (
+		id bigint PRIMARY KEY,
+		review_count bigint NOT NULL DEFAULT 0,
+		rating_sum bigint NOT NULL DEFAULT 0,
+		rating numeric NOT NULL DEFAULT 0)`)
+	return &db.Db{DB: gormDB}
+}
+
+func mustExec(t *testing.T, gormDB *gorm.DB, sql string, args ...interface{}) {
+	t.Helper()
+	if err := gormDB.Exec(sql, args...).Error; err != nil {
+		t.Fatalf("%s: %v", sql, err)
+	}
+}
+
+// createReview сохраняет опубликованный отзыв пользователя userID о товаре productID.
+func createReview(t *testing.T, database *db.Db, productID, userID uint, rating int16) *Review {
+	t.Helper()
+	review := &Review{UserID: &userID, ProductID: productID, Rating: rating, Comment: "review", Status: StatusPublished}
+	if err := database.Create(review).Error; err != nil {
+		t.Fatal(err)
+	}
+	return review
+}
+
+// wilsonLowerBound — та же формула, что в wilsonLowerBoundSQL.
+func wilsonLowerBound(likes, dislikes float64) float64 {
+	n := likes + dislikes
+	if n == 0 {
+		return 0
+	}
+	const z2 = 1.96 * 1.96
+	return (likes/n + z2/(2*n) - 1.96*math.Sqrt(likes*dislikes/n+z2/4)/n) / (1 + z2/n)
+}
+
+func TestWilsonLowerBoundSQL(t *testing.T) {
+	database := testDB(t)
+
+	tests := []struct {
+		likes, dislikes int
+	}{
+		{0, 0},
+		{1, 0},
+		{0, 1},
+		{3, 0},
+		{10, 40},
+		{100, 5},
+		{500, 500},
+	}
+	for _, tt := range tests {
+		var got float64
+		err := database.Raw(`SELECT (`+wilsonLowerBoundSQL+`)::float8 FROM (SELECT ?::bigint AS likes_count, ?::bigint AS dislikes_count) r`,
+			tt.likes, tt.dislikes).Scan(&got).Error
+		if err != nil {
+			t.Fatalf("likes=%d dislikes=%d: %v", tt.likes, tt.dislikes, err)
+		}
+		if want := wilsonLowerBound(float64(tt.likes), float64(tt.dislikes)); math.Abs(got-want) > 1e-9 {
+			t.Errorf("likes=%d dislikes=%d: got %v, want %v", tt.likes, tt.dislikes, got, want)
+		}
+	}
+}
+
+func TestWilsonLowerBoundSQLOrder(t *testing.T) {
+	database := testDB(t)
+
+	tests := []struct {
+		name string
+		// votes[i] — {likes, dislikes} отзыва с id i+1
+		votes [][2]int
+		want  []int
+	}{
+		{"confident beats small sample", [][2]int{{3, 0}, {100, 5}}, []int{2, 1}},
+		{"small unanimous beats large poor", [][2]int{{10, 40}, {3, 0}}, []int{2, 1}},
+		{"no votes ranks below any likes", [][2]int{{0, 0}, {1, 1}, {5, 0}}, []int{3, 2, 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query := database.Table(valuesTable(tt.votes)).
+				Order(wilsonLowerBoundSQL + " DESC").
+				Order("id")
+			var got []int
+			if err := query.Pluck("id", &got).Error; err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("got %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+// valuesTable строит таблицу r(id, likes_count, dislikes_count) из голосов.
+func valuesTable(votes [][2]int) string {
+	rows := make([]string, len(votes))
+	for i, v := range votes {
+		rows[i] = fmt.Sprintf("(%d, %d::bigint, %d::bigint)", i+1, v[0], v[1])
+	}
+	return "(VALUES " + strings.Join(rows, ", ") + ") AS r(id, likes_count, dislikes_count)"
+}
+
+func TestVotes(t *testing.T) {
+	database := testDB(t)
+	repo := NewReviewRepository(database)
+	ctx := context.Background()
+	review := createReview(t, database, 1, 100, 5)
+
+	set := func(userID uint, helpful bool) func() (*Review, error) {
+		return func() (*Review, error) { return repo.SetVote(ctx, review.ID, userID, helpful) }
+	}
+	remove := func(userID uint, helpful bool) func() (*Review, error) {
+		return func() (*Review, error) { return repo.RemoveVote(ctx, review.ID, userID, helpful) }
+	}
+
+	steps := []struct {
+		name            string
+		op              func() (*Review, error)
+		likes, dislikes int
+	}{
+		{"like", set(1, true), 1, 0},
+		{"repeated like", set(1, true), 1, 0},
+		{"dislike by another user", set(2, false), 1, 1},
+		{"switch like to dislike", set(1, false), 0, 2},
+		{"remove a like the user does not have", remove(1, true), 0, 2},
+		{"remove dislike", remove(1, false), 0, 1},
+		{"repeated remove", remove(1, false), 0, 1},
+	}
+	for _, step := range steps {
+		got, err := step.op()
+		if err != nil {
+			t.Fatalf("%s: %v", step.name, err)
+		}
+		var stored Review
+		if err := database.First(&stored, review.ID).Error; err != nil {
+			t.Fatal(err)
+		}
+		if got.LikesCount != step.likes || got.DislikesCount != step.dislikes ||
+			stored.LikesCount != step.likes || stored.DislikesCount != step.dislikes {
+			t.Fatalf("%s: returned %d/%d, stored %d/%d, want %d/%d", step.name,
+				got.LikesCount, got.DislikesCount, stored.LikesCount, stored.DislikesCount, step.likes, step.dislikes)
+		}
+	}
+}
+
+func TestRemoveLegacyVote(t *testing.T) {
+	database := testDB(t)
+	repo := NewReviewRepository(database)
+	ctx := context.Background()
+	review := createReview(t, database, 1, 100, 5)
+	// лайки, набранные до review_votes: счётчик есть, голосов нет
+	mustExec(t, database.DB, "UPDATE reviews SET likes_count = 5 WHERE id = ?", review.ID)
+
+	got, err := repo.RemoveVote(ctx, review.ID, 1, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.LikesCount != 5 {
+		t.Fatalf("likes = %d, want the legacy 5 untouched", got.LikesCount)
+	}
+
+	if _, err := repo.RemoveVote(ctx, review.ID+1, 1, true); apperr.KindOf(err) != apperr.KindNotFound {
+		t.Fatalf("missing review: got %v, want not found", err)
+	}
+}
+
+func TestPinnedHighlightMustStayPublished(t *testing.T) {
+	tests := []struct {
+		name string
+		// unpublish меняет закреплённый отзыв перед пересчётом
+		unpublish  string
+		wantPinned bool
+	}{
+		{"published pin is kept", "", true},
+		{"rejected pin is replaced", "UPDATE reviews SET status = 'rejected' WHERE id = ?", false},
+		{"pending pin is replaced", "UPDATE reviews SET status = 'pending' WHERE id = ?", false},
+		{"deleted pin is replaced", "UPDATE reviews SET deleted_at = now() WHERE id = ?", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			database := testDB(t)
+			repo := NewReviewRepository(database)
+			svc := NewReviewService(repo, ModerationRules{}, nil, nil)
+			ctx := context.Background()
+
+			pinned := createReview(t, database, 1, 100, 4)
+			best := createReview(t, database, 1, 101, 5)
+			mustExec(t, database.DB, "UPDATE reviews SET likes_count = 10 WHERE id = ?", best.ID)
+			if err := repo.PinHighlight(ctx, pinned, HighlightPositive); err != nil {
+				t.Fatal(err)
+			}
+			if tt.unpublish != "" {
+				mustExec(t, database.DB, tt.unpublish, pinned.ID)
+			}
+
+			// до пересчёта GET не должен отдавать снятый с публикации отзыв
+			highlights, err := svc.GetReviewHighlights(ctx, 1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			wantID := best.ID
+			if tt.wantPinned {
+				wantID = pinned.ID
+			}
+			if highlights.TopPositive == nil || highlights.TopPositive.ID != wantID || highlights.PositivePinned != tt.wantPinned {
+				t.Fatalf("before refresh: got %+v, want review %d pinned=%v", highlights, wantID, tt.wantPinned)
+			}
+
+			if err := repo.RefreshHighlights(ctx, 1); err != nil {
+				t.Fatal(err)
+			}
+			stored, err := repo.GetHighlights(ctx, 1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var positive *ReviewHighlight
+			for i := range stored {
+				if stored[i].Kind == HighlightPositive {
+					positive = &stored[i]
+				}
+			}
+			if positive == nil || positive.ReviewID != wantID || positive.Pinned != tt.wantPinned {
+				t.Fatalf("after refresh: got %+v, want review %d pinned=%v", positive, wantID, tt.wantPinned)
+			}
+		})
+	}
+}
+
+func TestModerateReviewOnce(t *testing.T) {
+	tests := []struct {
+		name string
+		// first и second — два решения модератора по одному отзыву подряд
+		first, second  func(s *ReviewService, ctx context.Context, id uint) (*Review, error)
+		wantStatus     string
+		wantRatedCount int64
+	}{
+		{"approve twice", (*ReviewService).ApproveReview, (*ReviewService).ApproveReview, StatusPublished, 1},
+		{"reject twice", (*ReviewService).RejectReview, (*ReviewService).RejectReview, StatusRejected, 0},
+		{"approve then reject", (*ReviewService).ApproveReview, (*ReviewService).RejectReview, StatusPublished, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			database := testDB(t)
+			svc := NewReviewService(NewReviewRepository(database), ModerationRules{}, nil, nil)
+			ctx := context.Background()
+			mustExec(t, database.DB, "INSERT INTO products (id) VALUES (1)")
+			review := createReview(t, database, 1, 100, 4)
+			mustExec(t, database.DB, "UPDATE reviews SET status = ? WHERE id = ?", StatusPending, review.ID)
+
+			got, err := tt.first(svc, ctx, review.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Status != tt.wantStatus {
+				t.Fatalf("status = %q, want %q", got.Status, tt.wantStatus)
+			}
+			if _, err := tt.second(svc, ctx, review.ID); apperr.KindOf(err) != apperr.KindConflict {
+				t.Fatalf("second decision: got %v, want a conflict", err)
+			}
+
+			var count int64
+			if err := database.Raw("SELECT review_count FROM products WHERE id = 1").Scan(&count).Error; err != nil {
+				t.Fatal(err)
+			}
+			if count != tt.wantRatedCount {
+				t.Fatalf("review_count = %d, want %d", count, tt.wantRatedCount)
+			}
+		})
+	}
+
+	t.Run("concurrent decision loses", func(t *testing.T) {
+		database := testDB(t)
+		repo := NewReviewRepository(database)
+		ctx := context.Background()
+		review := createReview(t, database, 1, 100, 4)
+		mustExec(t, database.DB, "UPDATE reviews SET status = ? WHERE id = ?", StatusPending, review.ID)
+
+		// оба вызова прочитали отзыв на модерации до того, как кто-то из них его обработал
+		first, err := repo.ModerateReview(ctx, review, StatusPublished)
+		if err != nil || !first {
+			t.Fatalf("first: %v, %v", first, err)
+		}
+		second, err := repo.ModerateReview(ctx, review, StatusRejected)
+		if err != nil || second {
+			t.Fatalf("second: %v, %v", second, err)
+		}
+	})
+}
+
+func TestCheckAuthor(t *testing.T) {
+	database := testDB(t)
+	svc := NewReviewService(NewReviewRepository(database), ModerationRules{}, nil, nil)
+	review := createReview(t, database, 1, 100, 4)
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		userID *uint
+		want   apperr.Kind
+	}{
+		{"internal call as author", ctx, uintPtr(100), -1},
+		{"internal call as another user", ctx, uintPtr(200), apperr.KindPermissionDenied},
+		// без токена автор обязателен: иначе любой продюсер Kafka правит чужие отзывы
+		{"internal call without author", ctx, nil, apperr.KindPermissionDenied},
+		{"internal call with zero author", ctx, uintPtr(0), apperr.KindPermissionDenied},
+		{"moderator without author", auth.WithPrincipal(ctx, &auth.Principal{UserID: 1, Admin: true}), nil, -1},
+		{"service without author", auth.WithPrincipal(ctx, &auth.Principal{Service: true}), nil, -1},
+		{"author token", auth.WithPrincipal(ctx, &auth.Principal{UserID: 100}), nil, -1},
+		{"another user token", auth.WithPrincipal(ctx, &auth.Principal{UserID: 200}), nil, apperr.KindPermissionDenied},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.CheckAuthor(tt.ctx, review.ID, tt.userID, nil)
+			if tt.want < 0 {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if apperr.KindOf(err) != tt.want {
+				t.Fatalf("got %v, want kind %d", err, tt.want)
+			}
+		})
+	}
+}
+
+func uintPtr(v uint) *uint { return &v }
@@ -1,106 +1,343 @@
 package review
 
 import (
-	"fmt"
+	"context"
+	"errors"
+	"strings"
+	"time"
 
 	"github.com/ShopOnGO/ShopOnGO/pkg/logger"
+	"github.com/ShopOnGO/review-service/internal/apperr"
+	"github.com/ShopOnGO/review-service/internal/auth"
+	"github.com/ShopOnGO/review-service/internal/ratelimit"
+	"gorm.io/gorm"
 )
 
+// Варианты сортировки отзывов товара.
+const (
+	SortNewest  = "newest"
+	SortHelpful = "helpful"
+)
+
+const (
+	maxSearchQueryLength = 200
+	maxSearchLimit       = 50
+
+	// MaxRatingSummaryBatch — максимальное число товаров в одном запросе GetRatingSummaries.
+	MaxRatingSummaryBatch = 100
+)
+
+// ErrReviewNotDeleted — попытка восстановить отзыв, который не удалён.
+var ErrReviewNotDeleted = apperr.Conflict("REVIEW_NOT_DELETED", "review is not deleted")
+
+// ModerationRules — правила приёма новых отзывов.
+type ModerationRules struct {
+	// AllowGuestReviews — принимать отзывы от гостей.
+	AllowGuestReviews bool
+	// ModerateGuestReviews — гостевые отзывы публикуются только после одобрения модератором.
+	ModerateGuestReviews bool
+	// MinCommentLength и MaxCommentLength — допустимая длина комментария в символах, 0 — без ограничения.
+	MinCommentLength int
+	MaxCommentLength int
+	// StopWords — новые отзывы, содержащие одно из слов (без учёта регистра), уходят на модерацию.
+	StopWords []string
+}
+
 type ReviewService struct {
 	ReviewRepository *ReviewRepository
+	Rules            ModerationRules
+	// Limiter ограничивает частоту отзывов и голосов; nil — без ограничений
+	Limiter *ratelimit.Limiter
+	// Cache — кэш списков отзывов и сводок оценок; nil — без кэша
+	Cache *ListingCache
 }
 
-func NewReviewService(reviewRepo *ReviewRepository) *ReviewService {
+func NewReviewService(reviewRepo *ReviewRepository, rules ModerationRules, limiter *ratelimit.Limiter, listingCache *ListingCache) *ReviewService {
+	stopWords := make([]string, 0, len(rules.StopWords))
+	for _, word := range rules.StopWords {
+		stopWords = append(stopWords, strings.ToLower(word))
+	}
+	rules.StopWords = stopWords
 	return &ReviewService{
 		ReviewRepository: reviewRepo,
+		Rules:            rules,
+		Limiter:          limiter,
+		Cache:            listingCache,
+	}
+}
+
+func validateRating(rating int16) error {
+	if rating < 1 || rating > 5 {
+		return apperr.InvalidArgument("rating", "rating must be between 1 and 5, got %d", rating)
+	}
+	return nil
+}
+
+func (s *ReviewService) validateComment(comment string) error {
+	length := len([]rune(comment))
+	if s.Rules.MinCommentLength > 0 && length < s.Rules.MinCommentLength {
+		return apperr.InvalidArgument("comment", "comment is shorter than %d characters", s.Rules.MinCommentLength)
+	}
+	if s.Rules.MaxCommentLength > 0 && length > s.Rules.MaxCommentLength {
+		return apperr.InvalidArgument("comment", "comment is longer than %d characters", s.Rules.MaxCommentLength)
+	}
+	return nil
+}
+
+func (s *ReviewService) hasStopWord(comment string) bool {
+	comment = strings.ToLower(comment)
+	for _, word := range s.Rules.StopWords {
+		if strings.Contains(comment, word) {
+			return true
+		}
 	}
+	return false
 }
 
-func (s *ReviewService) AddReview(productID, userID uint, rating int16, likesCount int, comment string) (*Review, error) {
-	if productID == 0 || userID == 0 {
-		return nil, fmt.Errorf("invalid product_id or user_id")
+// AddReview создаёт отзыв от пользователя (userID) или гостя (guestID) — ровно один из них должен быть задан.
+// Пользователь с токеном всегда пишет от своего имени (auth.Author). Опубликованный сразу отзыв
+// учитывается в рейтинге товара. Счётчики голосов нового отзыва нулевые и меняются только голосами.
+func (s *ReviewService) AddReview(ctx context.Context, productID uint, userID *uint, guestID *string, rating int16, comment string) (*Review, error) {
+	var guest []byte
+	if guestID != nil {
+		guest = []byte(*guestID)
+	}
+	userID, guest, err := auth.Author(ctx, userID, guest)
+	if err != nil {
+		return nil, err
+	}
+	hasUser := userID != nil
+	hasGuest := len(guest) > 0
+	if productID == 0 || hasUser == hasGuest {
+		return nil, apperr.InvalidArgument("", "invalid product_id or author")
+	}
+	if hasGuest && !s.Rules.AllowGuestReviews {
+		return nil, apperr.PermissionDenied("GUEST_REVIEWS_DISABLED", "guest reviews are disabled")
+	}
+	if err := validateRating(rating); err != nil {
+		return nil, err
+	}
+	if err := s.validateComment(comment); err != nil {
+		return nil, err
 	}
 
 	review := &Review{
 		ProductID: 	productID,
-		UserID:     userID,
 		Rating:     rating,
-		LikesCount:	likesCount,
 		Comment:    comment,
+		Status:     StatusPublished,
+	}
+	if hasUser {
+		review.UserID = userID
+	} else {
+		review.GuestID = guest
+		if s.Rules.ModerateGuestReviews {
+			review.Status = StatusPending
+		}
+	}
+	if s.hasStopWord(comment) {
+		review.Status = StatusPending
+	}
+	if err := s.Limiter.Allow(ctx, ratelimit.ActionReview, userID, guest); err != nil {
+		return nil, err
 	}
 
-	if err := s.ReviewRepository.CreateReview(review); err != nil {
+	if err := s.ReviewRepository.CreateReview(ctx, review); err != nil {
 		logger.Errorf("Error creating review: %v", err)
 		return nil, err
 	}
+	if review.Status == StatusPublished {
+		if err := s.UpdateRatingAfterCreate(ctx, review.ProductID, review.Rating); err != nil {
+			logger.Errorf("Error updating rating aggregates after creating review %d: %v", review.ID, err)
+		}
+	}
+	s.ProductChanged(ctx, review.ProductID)
 
 	return review, nil
 }
 
-func (s *ReviewService) GetReviewByID(reviewID uint) (*Review, error) {
+// GetReviewByID возвращает отзыв. Неопубликованный отзыв (на модерации или отклонённый)
+// видят только автор-пользователь по своему токену и привилегированные вызовы, остальным
+// он не найден. Гость без токена не может подтвердить, что он автор, поэтому свои отзывы
+// на модерации видит только через API-шлюз (роль auth.service_roles), который сам проверяет гостя.
+func (s *ReviewService) GetReviewByID(ctx context.Context, reviewID uint) (*Review, error) {
 	if reviewID == 0 {
-		return nil, fmt.Errorf("review ID is required")
+		return nil, apperr.InvalidArgument("review_id", "review ID is required")
 	}
-	review, err := s.ReviewRepository.GetReviewByID(reviewID)
+	review, err := s.ReviewRepository.GetReviewByID(ctx, reviewID)
 	if err != nil {
-		logger.Errorf("Error getting review by ID: %v", err)
-		return nil, fmt.Errorf("review not found")
+		return nil, reviewNotFound(err, reviewID)
+	}
+	if !visible(ctx, review) {
+		return nil, apperr.NotFound("review", reviewID)
 	}
 	return review, nil
 }
 
+// visible сообщает, можно ли показать отзыв вызывающему. guest_id из запроса не учитывается:
+// анонимному запросу нельзя доверить, что он пришёл от этого гостя.
+func visible(ctx context.Context, review *Review) bool {
+	if review.Status == StatusPublished || auth.Privileged(ctx) {
+		return true
+	}
+	p, _ := auth.FromContext(ctx)
+	return p.UserID != 0 && review.IsAuthor(&p.UserID, nil)
+}
+
+
+// CheckAuthor возвращает отзыв, если его автор — указанный пользователь или гость. Автор
+// определяется через auth.Author. Без автора проверку пропускают только модератор и внутренний
+// сервис с токеном; вызов без токена обязан указать автора, иначе он может править чужой отзыв.
+func (s *ReviewService) CheckAuthor(ctx context.Context, reviewID uint, userID *uint, guestID []byte) (*Review, error) {
+	review, err := s.GetReviewByID(ctx, reviewID)
+	if err != nil {
+		return nil, err
+	}
+	if (userID == nil || *userID == 0) && len(guestID) == 0 {
+		if p, ok := auth.FromContext(ctx); ok && (p.Admin || p.Service) {
+			return review, nil
+		}
+	}
+	userID, guestID, err = auth.Author(ctx, userID, guestID)
+	if err != nil {
+		return nil, err
+	}
+	if !review.IsAuthor(userID, guestID) {
+		logger.Warnf("Review %d is not written by user %v / guest %q", reviewID, userID, guestID)
+		return nil, apperr.PermissionDenied("NOT_REVIEW_AUTHOR", "review %d is not written by the requester", reviewID)
+	}
+	return review, nil
+}
 
-func (s *ReviewService) UpdateReview(reviewID uint, rating int16, comment string) error {
+// UpdateReview меняет оценку (0 — без изменений) и комментарий (пустой — без изменений).
+// Изменение оценки опубликованного отзыва сразу учитывается в рейтинге товара.
+func (s *ReviewService) UpdateReview(ctx context.Context, reviewID uint, rating int16, comment string) (*Review, error) {
 	if reviewID == 0 {
-		return fmt.Errorf("review ID is required")
+		return nil, apperr.InvalidArgument("review_id", "review ID is required")
+	}
+	if rating != 0 {
+		if err := validateRating(rating); err != nil {
+			return nil, err
+		}
 	}
 
-	review, err := s.ReviewRepository.GetReviewByID(reviewID)
+	review, err := s.ReviewRepository.GetReviewByID(ctx, reviewID)
 	if err != nil {
-		logger.Errorf("Error getting review: %v", err)
-		return fmt.Errorf("review not found")
+		return nil, reviewNotFound(err, reviewID)
 	}
 
+	oldRating := review.Rating
 	if rating != 0 {
 		review.Rating = rating
 	}
 	if comment != "" {
+		if err := s.validateComment(comment); err != nil {
+			return nil, err
+		}
 		review.Comment = comment
 	}
 
-	if err := s.ReviewRepository.UpdateReview(review); err != nil {
+	if err := s.ReviewRepository.UpdateReview(ctx, review); err != nil {
 		logger.Errorf("Error updating review: %v", err)
-		return err
+		return nil, err
+	}
+	if review.Rating != oldRating && review.Status == StatusPublished {
+		if err := s.UpdateRatingAfterUpdate(ctx, review.ProductID, int(oldRating), int(review.Rating)); err != nil {
+			logger.Errorf("Error updating rating aggregates after editing review %d: %v", reviewID, err)
+		}
 	}
+	s.ProductChanged(ctx, review.ProductID)
 
-	return nil
+	return review, nil
 }
 
-func (s *ReviewService) DeleteReview(reviewID uint) error {
+// DeleteReview мягко удаляет отзыв и убирает опубликованный отзыв из рейтинга товара.
+func (s *ReviewService) DeleteReview(ctx context.Context, reviewID uint) error {
 	if reviewID == 0 {
-		return fmt.Errorf("review ID is required")
+		return apperr.InvalidArgument("review_id", "review ID is required")
 	}
 
-	review, err := s.ReviewRepository.GetReviewByID(reviewID)
+	review, err := s.ReviewRepository.GetReviewByID(ctx, reviewID)
 	if err != nil {
-		logger.Errorf("Error getting review: %v", err)
-		return fmt.Errorf("review not found")
+		return reviewNotFound(err, reviewID)
 	}
 
-	if err := s.ReviewRepository.DeleteReview(review); err != nil {
+	if err := s.ReviewRepository.DeleteReview(ctx, review); err != nil {
 		logger.Errorf("Error deleting review: %v", err)
 		return err
 	}
+	if review.Status == StatusPublished {
+		if err := s.UpdateRatingAfterDelete(ctx, review.ProductID, int(review.Rating)); err != nil {
+			logger.Errorf("Error updating rating aggregates after deleting review %d: %v", reviewID, err)
+		}
+	}
+	s.ProductChanged(ctx, review.ProductID)
 
 	return nil
 }
 
-func (s *ReviewService) GetReviewsForProduct(productID uint, limit, offset int) ([]*Review, error) {
+// RestoreReview восстанавливает мягко удалённый отзыв. Опубликованный отзыв снова учитывается
+// в рейтинге товара так же, как при создании.
+func (s *ReviewService) RestoreReview(ctx context.Context, reviewID uint) (*Review, error) {
+	if err := auth.RequirePrivileged(ctx); err != nil {
+		return nil, err
+	}
+	if reviewID == 0 {
+		return nil, apperr.InvalidArgument("review_id", "review ID is required")
+	}
+
+	review, err := s.ReviewRepository.GetReviewByIDUnscoped(ctx, reviewID)
+	if err != nil {
+		return nil, reviewNotFound(err, reviewID)
+	}
+	if !review.DeletedAt.Valid {
+		return nil, ErrReviewNotDeleted
+	}
+
+	restored, err := s.ReviewRepository.RestoreReview(ctx, review)
+	if err != nil {
+		logger.Errorf("Error restoring review %d: %v", reviewID, err)
+		return nil, err
+	}
+	if !restored {
+		return nil, ErrReviewNotDeleted
+	}
+	review.DeletedAt = gorm.DeletedAt{}
+
+	if review.Status == StatusPublished {
+		if err := s.UpdateRatingAfterCreate(ctx, review.ProductID, review.Rating); err != nil {
+			logger.Errorf("Error updating rating aggregates after restoring review %d: %v", reviewID, err)
+		}
+	}
+	s.ProductChanged(ctx, review.ProductID)
+
+	return review, nil
+}
+
+func (s *ReviewService) GetReviewsForProduct(ctx context.Context, productID uint, limit, offset int, sort string) ([]*Review, error) {
 	if productID == 0 {
-		return nil, fmt.Errorf("productID is required")
+		return nil, apperr.InvalidArgument("product_id", "productID is required")
+	}
+	switch sort {
+	case "":
+		sort = SortNewest
+	case SortNewest, SortHelpful:
+	default:
+		return nil, apperr.InvalidArgument("sort", "unknown sort option: %s", sort)
+	}
+
+	if s.Cache.covers(limit, offset) {
+		first, err := s.Cache.listing(ctx, productID, sort, func(size int) ([]*Review, error) {
+			return s.ReviewRepository.GetReviewsByProductIDPaginated(ctx, productID, size, 0, sort)
+		})
+		if err != nil {
+			logger.Errorf("Error getting paginated reviews: %v", err)
+			return nil, err
+		}
+		return page(first, limit, offset), nil
 	}
 
-	reviews, err := s.ReviewRepository.GetReviewsByProductIDPaginated(productID, limit, offset)
+	reviews, err := s.ReviewRepository.GetReviewsByProductIDPaginated(ctx, productID, limit, offset, sort)
 	if err != nil {
 		logger.Errorf("Error getting paginated reviews: %v", err)
 		return nil, err
@@ -109,44 +346,420 @@ func (s *ReviewService) GetReviewsForProduct(productID uint, limit, offset int)
 	return reviews, nil
 }
 
-func (s *ReviewService) UpdateRatingAfterCreate(productID uint, rating int16) error {
-    return s.ReviewRepository.UpdateRating(productID, int(rating))
+// GetReviewsByUser возвращает отзывы пользователя userID. Удалённые отзывы видны,
+// только если список запрашивает сам автор (viewerID == userID).
+func (s *ReviewService) GetReviewsByUser(ctx context.Context, userID, viewerID uint, limit, offset int) ([]*UserReview, error) {
+	if userID == 0 {
+		return nil, apperr.InvalidArgument("user_id", "userID is required")
+	}
+	isOwner := auth.Viewer(ctx, viewerID) == userID
+
+	reviews, err := s.ReviewRepository.GetReviewsByUserIDPaginated(ctx, userID, limit, offset, isOwner)
+	if err != nil {
+		logger.Errorf("Error getting reviews of user %d: %v", userID, err)
+		return nil, err
+	}
+
+	result := make([]*UserReview, 0, len(reviews))
+	for _, r := range reviews {
+		result = append(result, &UserReview{Review: *r, State: reviewStatus(r)})
+	}
+	return result, nil
 }
 
-func (s *ReviewService) UpdateRatingAfterUpdate(productID uint, oldRating, newRating int) error {
-    return s.ReviewRepository.UpdateRatingDelta(productID, oldRating, newRating)
+func (s *ReviewService) SearchReviews(ctx context.Context, productID uint, query string, limit, offset int) ([]*ReviewSearchResult, error) {
+	if productID == 0 {
+		return nil, apperr.InvalidArgument("product_id", "productID is required")
+	}
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, apperr.InvalidArgument("q", "search query is required")
+	}
+	if len([]rune(query)) > maxSearchQueryLength {
+		return nil, apperr.InvalidArgument("q", "search query is too long")
+	}
+	if limit <= 0 || limit > maxSearchLimit {
+		limit = maxSearchLimit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	results, err := s.ReviewRepository.SearchReviewsByProductID(ctx, productID, query, limit, offset)
+	if err != nil {
+		logger.Errorf("Error searching reviews for product %d: %v", productID, err)
+		return nil, err
+	}
+
+	return results, nil
 }
 
-func (s *ReviewService) UpdateRatingAfterDelete(productID uint, oldRating int) error {
-    return s.ReviewRepository.UpdateRatingDelete(productID, oldRating)
+// GetRatingSummaries возвращает агрегаты оценок для товаров в порядке запроса.
+// Дубликаты отбрасываются, товары без отзывов получают нулевую сводку.
+func (s *ReviewService) GetRatingSummaries(ctx context.Context, productIDs []uint) ([]*RatingSummary, error) {
+	if len(productIDs) == 0 {
+		return nil, apperr.InvalidArgument("product_ids", "product_ids are required")
+	}
+
+	ids := make([]uint, 0, len(productIDs))
+	seen := make(map[uint]bool, len(productIDs))
+	for _, id := range productIDs {
+		if id == 0 {
+			return nil, apperr.InvalidArgument("product_ids", "invalid product_id: 0")
+		}
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) > MaxRatingSummaryBatch {
+		return nil, apperr.InvalidArgument("product_ids", "too many product_ids: %d, max %d", len(ids), MaxRatingSummaryBatch)
+	}
+
+	byProduct := make(map[uint]*RatingSummary, len(ids))
+	missing := s.Cache.ratings(ctx, ids, byProduct)
+	if len(missing) > 0 {
+		found, err := s.ReviewRepository.GetRatingSummaries(ctx, missing)
+		if err != nil {
+			logger.Errorf("Error getting rating summaries: %v", err)
+			return nil, err
+		}
+		for _, summary := range found {
+			byProduct[summary.ProductID] = summary
+		}
+		for _, id := range missing {
+			if _, ok := byProduct[id]; !ok {
+				byProduct[id] = &RatingSummary{ProductID: id}
+			}
+			s.Cache.setRating(ctx, byProduct[id])
+		}
+	}
+
+	summaries := make([]*RatingSummary, 0, len(ids))
+	for _, id := range ids {
+		summaries = append(summaries, byProduct[id])
+	}
+	return summaries, nil
+}
+
+// LastModified возвращает время последнего изменения отзывов товаров — Last-Modified
+// списков, выделенных отзывов и сводок оценок.
+func (s *ReviewService) LastModified(ctx context.Context, productIDs []uint) (time.Time, error) {
+	lastModified, err := s.ReviewRepository.LastModified(ctx, productIDs)
+	if err != nil {
+		logger.Errorf("Error getting last modification time of reviews for products %v: %v", productIDs, err)
+		return time.Time{}, err
+	}
+	return lastModified, nil
+}
+
+func (s *ReviewService) UpdateRatingAfterCreate(ctx context.Context, productID uint, rating int16) error {
+    return s.ReviewRepository.UpdateRating(ctx, productID, int(rating))
 }
 
-func (s *ReviewService) AddLikeToReview(reviewID, user_id uint) (uint, error) {
-    if reviewID == 0 {
-        return 0, fmt.Errorf("invalid review id")
+func (s *ReviewService) UpdateRatingAfterUpdate(ctx context.Context, productID uint, oldRating, newRating int) error {
+    return s.ReviewRepository.UpdateRatingDelta(ctx, productID, oldRating, newRating)
+}
+
+func (s *ReviewService) UpdateRatingAfterDelete(ctx context.Context, productID uint, oldRating int) error {
+    return s.ReviewRepository.UpdateRatingDelete(ctx, productID, oldRating)
+}
+
+func (s *ReviewService) AddLikeToReview(ctx context.Context, reviewID, userID uint) (uint, error) {
+    userID, err := voter(ctx, userID)
+    if err != nil {
+        return 0, err
+    }
+    if reviewID == 0 || userID == 0 {
+        return 0, apperr.InvalidArgument("", "invalid review id or user id")
+    }
+    if err := s.Limiter.Allow(ctx, ratelimit.ActionLike, &userID, nil); err != nil {
+        return 0, err
     }
 
-    // Можно здесь добавить дополнительную логику:
-    // – проверка, что пользователь ещё не лайкал (если нужна такая фича)
+    review, err := s.ReviewRepository.SetVote(ctx, reviewID, userID, true)
+    if err != nil {
+        return 0, err
+    }
+    s.ProductChanged(ctx, review.ProductID)
+    return uint(review.LikesCount), nil
+}
 
-    newCount, err := s.ReviewRepository.IncrementLikes(reviewID)
+func (s *ReviewService) RemoveLikeToReview(ctx context.Context, reviewID, userID uint) (uint, error) {
+    userID, err := voter(ctx, userID)
     if err != nil {
         return 0, err
     }
-    return newCount, nil
+    if reviewID == 0 || userID == 0 {
+        return 0, apperr.InvalidArgument("", "invalid review id or user id")
+    }
+
+    review, err := s.ReviewRepository.RemoveVote(ctx, reviewID, userID, true)
+    if err != nil {
+        return 0, err
+    }
+    s.ProductChanged(ctx, review.ProductID)
+    return uint(review.LikesCount), nil
 }
 
-func (s *ReviewService) RemoveLikeToReview(reviewID, userID uint) (uint, error) {
-    if reviewID == 0 {
-        return 0, fmt.Errorf("invalid review id")
+func (s *ReviewService) AddDislikeToReview(ctx context.Context, reviewID, userID uint) (uint, error) {
+    userID, err := voter(ctx, userID)
+    if err != nil {
+        return 0, err
+    }
+    if reviewID == 0 || userID == 0 {
+        return 0, apperr.InvalidArgument("", "invalid review id or user id")
+    }
+    if err := s.Limiter.Allow(ctx, ratelimit.ActionLike, &userID, nil); err != nil {
+        return 0, err
+    }
+
+    review, err := s.ReviewRepository.SetVote(ctx, reviewID, userID, false)
+    if err != nil {
+        return 0, err
     }
+    s.ProductChanged(ctx, review.ProductID)
+    return uint(review.DislikesCount), nil
+}
 
-    // Можно здесь добавить дополнительную логику:
-    // – проверка, что пользователь ещё не лайкал (если нужна такая фича)
+func (s *ReviewService) RemoveDislikeToReview(ctx context.Context, reviewID, userID uint) (uint, error) {
+    userID, err := voter(ctx, userID)
+    if err != nil {
+        return 0, err
+    }
+    if reviewID == 0 || userID == 0 {
+        return 0, apperr.InvalidArgument("", "invalid review id or user id")
+    }
 
-    newCount, err := s.ReviewRepository.DecrementLikes(reviewID)
+    review, err := s.ReviewRepository.RemoveVote(ctx, reviewID, userID, false)
     if err != nil {
         return 0, err
     }
-    return newCount, nil
-}
\ No newline at end of file
+    s.ProductChanged(ctx, review.ProductID)
+    return uint(review.DislikesCount), nil
+}
+
+// GetReviewHighlights возвращает лучший положительный и лучший критический отзывы товара.
+// Метод только читает: сохранённые выделенные отзывы пересчитываются при изменениях отзывов
+// (ProductChanged), а для вида, который ещё не сохранён или указывает на снятый с публикации
+// отзыв, кандидат выбирается запросом без записи.
+func (s *ReviewService) GetReviewHighlights(ctx context.Context, productID uint) (*ReviewHighlights, error) {
+	if productID == 0 {
+		return nil, apperr.InvalidArgument("product_id", "productID is required")
+	}
+
+	highlights, err := s.ReviewRepository.GetHighlights(ctx, productID)
+	if err != nil {
+		logger.Errorf("Error getting review highlights for product %d: %v", productID, err)
+		return nil, err
+	}
+	stored := make(map[string]ReviewHighlight, len(highlights))
+	for _, h := range highlights {
+		stored[h.Kind] = h
+	}
+
+	result := &ReviewHighlights{ProductID: productID}
+	for _, kind := range []string{HighlightPositive, HighlightCritical} {
+		var review *Review
+		var pinned bool
+		if h, ok := stored[kind]; ok {
+			review, err = s.ReviewRepository.GetReviewByID(ctx, h.ReviewID)
+			switch {
+			case err != nil:
+				logger.Warnf("Highlighted review %d of product %d is unavailable: %v", h.ReviewID, productID, err)
+				review = nil
+			case review.Status != StatusPublished:
+				// отзыв сняли с публикации, а выделенные ещё не пересчитаны
+				logger.Warnf("Highlighted review %d of product %d is %s", h.ReviewID, productID, review.Status)
+				review = nil
+			default:
+				pinned = h.Pinned
+			}
+		}
+		if review == nil {
+			review, err = s.ReviewRepository.HighlightCandidate(ctx, productID, kind)
+			if err != nil {
+				logger.Errorf("Error computing %s highlight of product %d: %v", kind, productID, err)
+				return nil, err
+			}
+		}
+		switch kind {
+		case HighlightPositive:
+			result.TopPositive, result.PositivePinned = review, pinned
+		case HighlightCritical:
+			result.TopCritical, result.CriticalPinned = review, pinned
+		}
+	}
+
+	return result, nil
+}
+
+// PinHighlight закрепляет отзыв модератором как выделенный отзыв вида kind. Закрепить можно только
+// опубликованный отзыв с подходящей оценкой; productID, если задан, должен совпадать с товаром отзыва.
+func (s *ReviewService) PinHighlight(ctx context.Context, productID, reviewID uint, kind string) error {
+	if err := auth.RequirePrivileged(ctx); err != nil {
+		return err
+	}
+	if kind != HighlightPositive && kind != HighlightCritical {
+		return apperr.InvalidArgument("kind", "unknown highlight kind: %s", kind)
+	}
+
+	review, err := s.GetReviewByID(ctx, reviewID)
+	if err != nil {
+		return err
+	}
+	if productID != 0 && review.ProductID != productID {
+		return apperr.InvalidArgument("review_id", "review %d does not belong to product %d", reviewID, productID)
+	}
+	if review.Status != StatusPublished {
+		return apperr.InvalidArgument("review_id", "review %d is not published", reviewID)
+	}
+	if !matchesHighlight(review.Rating, kind) {
+		return apperr.InvalidArgument("kind", "review %d with rating %d cannot be a %s highlight", reviewID, review.Rating, kind)
+	}
+
+	if err := s.ReviewRepository.PinHighlight(ctx, review, kind); err != nil {
+		logger.Errorf("Error pinning review %d as %s highlight: %v", reviewID, kind, err)
+		return err
+	}
+	return nil
+}
+
+// UnpinHighlight снимает закрепление и сразу пересчитывает выделенный отзыв.
+func (s *ReviewService) UnpinHighlight(ctx context.Context, productID uint, kind string) error {
+	if err := auth.RequirePrivileged(ctx); err != nil {
+		return err
+	}
+	if productID == 0 {
+		return apperr.InvalidArgument("product_id", "productID is required")
+	}
+	if kind != HighlightPositive && kind != HighlightCritical {
+		return apperr.InvalidArgument("kind", "unknown highlight kind: %s", kind)
+	}
+
+	if err := s.ReviewRepository.UnpinHighlight(ctx, productID, kind); err != nil {
+		logger.Errorf("Error unpinning %s highlight of product %d: %v", kind, productID, err)
+		return err
+	}
+	return s.ReviewRepository.RefreshHighlights(ctx, productID)
+}
+
+// voter возвращает пользователя, от имени которого голосуют: для запросов с токеном — из токена.
+func voter(ctx context.Context, userID uint) (uint, error) {
+	author, _, err := auth.Author(ctx, &userID, nil)
+	if err != nil || author == nil {
+		return 0, err
+	}
+	return *author, nil
+}
+
+// reviewNotFound заменяет gorm.ErrRecordNotFound ошибкой сервиса, остальные ошибки логирует как есть.
+func reviewNotFound(err error, reviewID uint) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return apperr.NotFound("review", reviewID)
+	}
+	logger.Errorf("Error getting review %d: %v", reviewID, err)
+	return err
+}
+
+func reviewStatus(r *Review) string {
+	if r.DeletedAt.Valid {
+		return StatusDeleted
+	}
+	if r.Status == "" {
+		return StatusPublished
+	}
+	return r.Status
+}
+
+// ProductChanged вызывается после любого изменения отзывов товара: сбрасывает кэш товара
+// и пересчитывает выделенные отзывы.
+func (s *ReviewService) ProductChanged(ctx context.Context, productID uint) {
+	s.Cache.invalidate(ctx, productID)
+	if err := s.ReviewRepository.RefreshHighlights(ctx, productID); err != nil {
+		logger.Errorf("Error refreshing review highlights for product %d: %v", productID, err)
+	}
+}
+
+// GetPendingReviews возвращает очередь гостевых отзывов, ожидающих модерации.
+func (s *ReviewService) GetPendingReviews(ctx context.Context, limit, offset int) ([]*Review, error) {
+	if err := auth.RequirePrivileged(ctx); err != nil {
+		return nil, err
+	}
+	reviews, err := s.ReviewRepository.GetPendingReviews(ctx, limit, offset)
+	if err != nil {
+		logger.Errorf("Error getting pending reviews: %v", err)
+		return nil, err
+	}
+	return reviews, nil
+}
+
+// ApproveReview публикует отзыв из очереди модерации и учитывает его в рейтинге товара.
+// Рейтинг меняется только тем вызовом, который действительно снял отзыв с модерации,
+// поэтому повторное событие одобрения не учтёт отзыв дважды.
+func (s *ReviewService) ApproveReview(ctx context.Context, reviewID uint) (*Review, error) {
+	review, err := s.moderate(ctx, reviewID, StatusPublished)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.UpdateRatingAfterCreate(ctx, review.ProductID, review.Rating); err != nil {
+		logger.Errorf("Error updating rating aggregates after approving review %d: %v", reviewID, err)
+	}
+	s.ProductChanged(ctx, review.ProductID)
+
+	return review, nil
+}
+
+// RejectReview отклоняет отзыв из очереди модерации. Отзыв остаётся виден только
+// привилегированным вызовам и автору-пользователю (см. GetReviewByID).
+func (s *ReviewService) RejectReview(ctx context.Context, reviewID uint) (*Review, error) {
+	return s.moderate(ctx, reviewID, StatusRejected)
+}
+
+// moderate переводит отзыв из очереди модерации в status.
+func (s *ReviewService) moderate(ctx context.Context, reviewID uint, status string) (*Review, error) {
+	if err := auth.RequirePrivileged(ctx); err != nil {
+		return nil, err
+	}
+	review, err := s.GetReviewByID(ctx, reviewID)
+	if err != nil {
+		return nil, err
+	}
+	if review.Status != StatusPending {
+		return nil, reviewNotPending(reviewID)
+	}
+
+	moderated, err := s.ReviewRepository.ModerateReview(ctx, review, status)
+	if err != nil {
+		logger.Errorf("Error moving review %d to %s: %v", reviewID, status, err)
+		return nil, err
+	}
+	if !moderated {
+		return nil, reviewNotPending(reviewID)
+	}
+	review.Status = status
+	return review, nil
+}
+
+func reviewNotPending(reviewID uint) error {
+	return apperr.Conflict("REVIEW_NOT_PENDING", "review %d is not pending moderation", reviewID)
+}
+
+// ClaimGuestReviews привязывает отзывы гостя к зарегистрировавшемуся пользователю.
+// Статус модерации отзывов при этом не меняется. Кэш списков не сбрасывается: меняется
+// только автор, и старая запись проживёт не дольше cache.ttl.
+func (s *ReviewService) ClaimGuestReviews(ctx context.Context, guestID string, userID uint) (int64, error) {
+	if err := auth.RequirePrivileged(ctx); err != nil {
+		return 0, err
+	}
+	if guestID == "" || userID == 0 {
+		return 0, apperr.InvalidArgument("", "guest_id and user_id are required")
+	}
+
+	claimed, err := s.ReviewRepository.ClaimGuestReviews(ctx, []byte(guestID), userID)
+	if err != nil {
+		logger.Errorf("Error claiming reviews of guest %q for user %d: %v", guestID, userID, err)
+		return 0, err
+	}
+	return claimed, nil
+}
@@ -0,0 +1,22 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/ShopOnGO/ShopOnGO/pkg/logger"
+	"gorm.io/gorm"
+)
+
+// RunMigrations применяет все неприменённые миграции.
+func RunMigrations(ctx context.Context, db *gorm.DB) error {
+	migrator, err := NewMigrator(ctx, db)
+	if err != nil {
+		return err
+	}
+	if err := migrator.Up(ctx); err != nil {
+		return err
+	}
+
+	logger.Info("✅")
+	return nil
+}
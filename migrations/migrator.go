@@ -0,0 +1,317 @@
+package migrations
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ShopOnGO/ShopOnGO/pkg/logger"
+	"gorm.io/gorm"
+)
+
+//go:embed sql/*.sql
+var sqlFiles embed.FS
+
+// migrationLockID — ключ pg_advisory_xact_lock, под которым применяются миграции,
+// чтобы несколько реплик, стартующих одновременно, не выполняли одну миграцию дважды.
+const migrationLockID = 727001
+
+var fileNameRe = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// последняя встроенная миграция для CheckVersion
+var (
+	latestOnce sync.Once
+	latest     int
+	latestErr  error
+)
+
+// Migration — пара SQL-скриптов sql/NNNN_name.up.sql и sql/NNNN_name.down.sql.
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+// SchemaMigration — строка таблицы schema_migrations о применённой миграции.
+type SchemaMigration struct {
+	Version   int `gorm:"primaryKey;autoIncrement:false"`
+	Name      string
+	AppliedAt time.Time
+}
+
+// MigrationStatus — миграция и время её применения (nil, если не применена).
+type MigrationStatus struct {
+	Version   int
+	Name      string
+	AppliedAt *time.Time
+}
+
+type Migrator struct {
+	db         *gorm.DB
+	migrations []Migration
+}
+
+func NewMigrator(ctx context.Context, db *gorm.DB) (*Migrator, error) {
+	migrations, err := loadMigrations()
+	if err != nil {
+		return nil, err
+	}
+	err = db.WithContext(ctx).Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+		version    bigint      PRIMARY KEY,
+		name       text        NOT NULL,
+		applied_at timestamptz NOT NULL
+	)`).Error
+	if err != nil {
+		return nil, fmt.Errorf("create schema_migrations: %w", err)
+	}
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+func loadMigrations() ([]Migration, error) {
+	sub, err := fs.Sub(sqlFiles, "sql")
+	if err != nil {
+		return nil, err
+	}
+	return parseMigrations(sub)
+}
+
+// parseMigrations собирает миграции из скриптов в корне fsys, отсортированные по версии.
+func parseMigrations(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int]*Migration)
+	for _, entry := range entries {
+		m := fileNameRe.FindStringSubmatch(entry.Name())
+		if m == nil {
+			return nil, fmt.Errorf("unexpected migration file name: %s", entry.Name())
+		}
+		version, _ := strconv.Atoi(m[1])
+		body, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: m[2]}
+			byVersion[version] = migration
+		} else if migration.Name != m[2] {
+			return nil, fmt.Errorf("migration %d has two names: %s and %s", version, migration.Name, m[2])
+		}
+		if m[3] == "up" {
+			migration.Up = string(body)
+		} else {
+			migration.Down = string(body)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" || m.Down == "" {
+			return nil, fmt.Errorf("migration %04d_%s must have both up and down scripts", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// Latest возвращает номер последней встроенной в бинарник миграции.
+func (m *Migrator) Latest() int {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// Version возвращает номер последней применённой миграции (0 — база пустая).
+func (m *Migrator) Version(ctx context.Context) (int, error) {
+	var version int
+	err := m.db.WithContext(ctx).Model(&SchemaMigration{}).Select("COALESCE(MAX(version), 0)").Scan(&version).Error
+	return version, err
+}
+
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	var applied []SchemaMigration
+	if err := m.db.WithContext(ctx).Order("version").Find(&applied).Error; err != nil {
+		return nil, err
+	}
+	appliedAt := make(map[int]time.Time, len(applied))
+	for _, a := range applied {
+		appliedAt[a.Version] = a.AppliedAt
+	}
+
+	statuses := make([]MigrationStatus, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := MigrationStatus{Version: migration.Version, Name: migration.Name}
+		if t, ok := appliedAt[migration.Version]; ok {
+			status.AppliedAt = &t
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// Up применяет все неприменённые миграции.
+func (m *Migrator) Up(ctx context.Context) error {
+	return m.To(ctx, m.Latest())
+}
+
+// Down откатывает последнюю применённую миграцию.
+func (m *Migrator) Down(ctx context.Context) error {
+	current, err := m.Version(ctx)
+	if err != nil {
+		return err
+	}
+	if current == 0 {
+		return nil
+	}
+	return m.To(ctx, previous(m.migrations, current))
+}
+
+// previous возвращает версию миграции, предшествующей current (0 — current первая).
+func previous(migrations []Migration, current int) int {
+	target := 0
+	for _, migration := range migrations {
+		if migration.Version < current {
+			target = migration.Version
+		}
+	}
+	return target
+}
+
+// To применяет или откатывает миграции так, чтобы версия схемы стала равна target.
+func (m *Migrator) To(ctx context.Context, target int) error {
+	if target != 0 && m.find(target) == nil {
+		return fmt.Errorf("unknown migration version %d", target)
+	}
+	current, err := m.Version(ctx)
+	if err != nil {
+		return err
+	}
+
+	steps, up := plan(m.migrations, current, target)
+	for _, migration := range steps {
+		if err := m.apply(ctx, migration, up); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// plan возвращает миграции для перехода с версии current на target в порядке выполнения:
+// при up — неприменённые по возрастанию, иначе — откатываемые по убыванию.
+func plan(migrations []Migration, current, target int) (steps []Migration, up bool) {
+	if target >= current {
+		for _, migration := range migrations {
+			if migration.Version > current && migration.Version <= target {
+				steps = append(steps, migration)
+			}
+		}
+		return steps, true
+	}
+
+	for i := len(migrations) - 1; i >= 0; i-- {
+		migration := migrations[i]
+		if migration.Version <= current && migration.Version > target {
+			steps = append(steps, migration)
+		}
+	}
+	return steps, false
+}
+
+func (m *Migrator) find(version int) *Migration {
+	for i := range m.migrations {
+		if m.migrations[i].Version == version {
+			return &m.migrations[i]
+		}
+	}
+	return nil
+}
+
+// apply выполняет up- или down-скрипт миграции и обновляет schema_migrations в одной транзакции.
+// Если миграцию уже применила (или откатила) другая реплика, ничего не делает.
+func (m *Migrator) apply(ctx context.Context, migration Migration, up bool) error {
+	direction := "down"
+	if up {
+		direction = "up"
+	}
+
+	err := m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", migrationLockID).Error; err != nil {
+			return err
+		}
+		// построение индексов на больших таблицах не должно упираться в statement_timeout пула
+		if err := tx.Exec("SET LOCAL statement_timeout = 0").Error; err != nil {
+			return err
+		}
+
+		var applied int64
+		if err := tx.Model(&SchemaMigration{}).Where("version = ?", migration.Version).Count(&applied).Error; err != nil {
+			return err
+		}
+		if up == (applied > 0) {
+			return nil
+		}
+
+		script := migration.Down
+		if up {
+			script = migration.Up
+		}
+		if err := tx.Exec(script).Error; err != nil {
+			return err
+		}
+
+		if up {
+			return tx.Create(&SchemaMigration{
+				Version:   migration.Version,
+				Name:      migration.Name,
+				AppliedAt: time.Now(),
+			}).Error
+		}
+		return tx.Delete(&SchemaMigration{}, migration.Version).Error
+	})
+	if err != nil {
+		return fmt.Errorf("migration %04d_%s %s: %w", migration.Version, migration.Name, direction, err)
+	}
+
+	logger.Infof("Migration %04d_%s %s applied", migration.Version, migration.Name, direction)
+	return nil
+}
+
+// CheckVersion проверяет, что к базе применены все встроенные миграции — для проверки готовности.
+func CheckVersion(ctx context.Context, db *gorm.DB) error {
+	latestOnce.Do(func() {
+		migrations, err := loadMigrations()
+		if err != nil {
+			latestErr = err
+			return
+		}
+		if len(migrations) > 0 {
+			latest = migrations[len(migrations)-1].Version
+		}
+	})
+	if latestErr != nil {
+		return latestErr
+	}
+
+	var version int
+	err := db.WithContext(ctx).Raw("SELECT COALESCE(MAX(version), 0) FROM schema_migrations").Scan(&version).Error
+	if err != nil {
+		return err
+	}
+	if version != latest {
+		return fmt.Errorf("schema version %d, expected %d", version, latest)
+	}
+	return nil
+}
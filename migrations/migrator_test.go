@@ -0,0 +1,227 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestEmbeddedMigrations(t *testing.T) {
+	migrations, err := loadMigrations()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(migrations) == 0 {
+		t.Fatal("no embedded migrations")
+	}
+	for i, m := range migrations {
+		if m.Version != i+1 {
+			t.Errorf("migration %04d_%s: versions must go 1, 2, 3... without gaps, want %d", m.Version, m.Name, i+1)
+		}
+		if strings.TrimSpace(m.Up) == "" || strings.TrimSpace(m.Down) == "" {
+			t.Errorf("migration %04d_%s has an empty script", m.Version, m.Name)
+		}
+	}
+}
+
+func TestParseMigrations(t *testing.T) {
+	file := func(body string) *fstest.MapFile { return &fstest.MapFile{Data: []byte(body)} }
+
+	tests := []struct {
+		name     string
+		files    fstest.MapFS
+		versions []int
+		wantErr  string
+	}{
+		{
+			name: "sorted by version",
+			files: fstest.MapFS{
+				"0010_ten.up.sql":   file("up 10"),
+				"0010_ten.down.sql": file("down 10"),
+				"0002_two.up.sql":   file("up 2"),
+				"0002_two.down.sql": file("down 2"),
+				"0001_one.up.sql":   file("up 1"),
+				"0001_one.down.sql": file("down 1"),
+			},
+			versions: []int{1, 2, 10},
+		},
+		{
+			name:    "missing down script",
+			files:   fstest.MapFS{"0001_one.up.sql": file("up")},
+			wantErr: "must have both up and down scripts",
+		},
+		{
+			name: "two names for one version",
+			files: fstest.MapFS{
+				"0001_one.up.sql":   file("up"),
+				"0001_uno.down.sql": file("down"),
+			},
+			wantErr: "has two names",
+		},
+		{
+			name:    "unexpected file name",
+			files:   fstest.MapFS{"init.sql": file("up")},
+			wantErr: "unexpected migration file name",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			migrations, err := parseMigrations(tt.files)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("got error %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := versionsOf(migrations); !slices.Equal(got, tt.versions) {
+				t.Fatalf("got versions %v, want %v", got, tt.versions)
+			}
+			for _, m := range migrations {
+				if m.Up != "up "+strconv.Itoa(m.Version) || m.Down != "down "+strconv.Itoa(m.Version) {
+					t.Errorf("migration %d: scripts mixed up: %q / %q", m.Version, m.Up, m.Down)
+				}
+			}
+		})
+	}
+}
+
+func TestPlan(t *testing.T) {
+	migrations := []Migration{{Version: 1}, {Version: 2}, {Version: 3}, {Version: 5}}
+
+	tests := []struct {
+		name            string
+		current, target int
+		want            []int
+		up              bool
+	}{
+		{"up from empty", 0, 5, []int{1, 2, 3, 5}, true},
+		{"up part way", 1, 3, []int{2, 3}, true},
+		{"already there", 3, 3, nil, true},
+		{"down one", 5, 3, []int{5}, false},
+		{"down to empty", 3, 0, []int{3, 2, 1}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			steps, up := plan(migrations, tt.current, tt.target)
+			if got := versionsOf(steps); !slices.Equal(got, tt.want) || up != tt.up {
+				t.Fatalf("got %v up=%v, want %v up=%v", got, up, tt.want, tt.up)
+			}
+		})
+	}
+}
+
+func TestPrevious(t *testing.T) {
+	migrations := []Migration{{Version: 1}, {Version: 2}, {Version: 5}}
+
+	tests := []struct {
+		current, want int
+	}{
+		{5, 2},
+		{2, 1},
+		{1, 0},
+		// версия из более нового бинарника: откатываемся на последнюю известную
+		{7, 5},
+	}
+	for _, tt := range tests {
+		if got := previous(migrations, tt.current); got != tt.want {
+			t.Errorf("previous(%d) = %d, want %d", tt.current, got, tt.want)
+		}
+	}
+}
+
+func versionsOf(migrations []Migration) []int {
+	var versions []int
+	for _, m := range migrations {
+		versions = append(versions, m.Version)
+	}
+	return versions
+}
+
+// TestUpgradeFromBaseline применяет все миграции к базе в том виде, в котором её оставил
+// AutoMigrate исходной версии сервиса, и откатывает их. Нужна PostgreSQL из REVIEW_TEST_DSN:
+// тест работает в отдельной схеме и удаляет её.
+func TestUpgradeFromBaseline(t *testing.T) {
+	dsn := os.Getenv("REVIEW_TEST_DSN")
+	if dsn == "" {
+		t.Skip("REVIEW_TEST_DSN is not set")
+	}
+	ctx := context.Background()
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// одно соединение, чтобы search_path действовал на все запросы теста
+	sqlDB.SetMaxOpenConns(1)
+	defer sqlDB.Close()
+
+	schema := fmt.Sprintf("migrations_test_%d", os.Getpid())
+	mustExec(t, db, "CREATE SCHEMA "+schema)
+	defer db.Exec("DROP SCHEMA " + schema + " CASCADE")
+	mustExec(t, db, "SET search_path TO "+schema)
+
+	// схема AutoMigrate исходной версии: user_id NOT NULL, без guest_id, dislikes_count и status
+	mustExec(t, db, `CREATE TABLE reviews (
+		id bigserial PRIMARY KEY, created_at timestamptz, updated_at timestamptz, deleted_at timestamptz,
+		user_id bigint NOT NULL, product_id bigint NOT NULL, rating smallint NOT NULL,
+		likes_count bigint DEFAULT 0, comment text NOT NULL)`)
+	mustExec(t, db, `CREATE TABLE questions (
+		id bigserial PRIMARY KEY, created_at timestamptz, updated_at timestamptz, deleted_at timestamptz,
+		user_id bigint, guest_id bytea, product_id bigint NOT NULL, question_text text NOT NULL,
+		answer_text text, likes_count bigint DEFAULT 0)`)
+	mustExec(t, db, `INSERT INTO reviews (user_id, product_id, rating, comment) VALUES (1, 1, 5, 'ok')`)
+
+	migrator, err := NewMigrator(ctx, db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := migrator.Up(ctx); err != nil {
+		t.Fatalf("up: %v", err)
+	}
+
+	var status string
+	if err := db.Raw("SELECT status FROM reviews").Scan(&status).Error; err != nil {
+		t.Fatal(err)
+	}
+	if status != "published" {
+		t.Errorf("existing review status = %q, want published", status)
+	}
+	mustExec(t, db, `INSERT INTO reviews (guest_id, product_id, rating, comment) VALUES ('g', 1, 4, 'guest')`)
+
+	// откат 0002 не удаляет гостевые отзывы молча и останавливается на ней
+	if err := migrator.To(ctx, 0); err == nil {
+		t.Fatal("down with guest reviews succeeded")
+	}
+	if version, err := migrator.Version(ctx); err != nil || version != 2 {
+		t.Fatalf("version after failed down = %d, %v", version, err)
+	}
+	mustExec(t, db, `DELETE FROM reviews WHERE user_id IS NULL`)
+
+	if err := migrator.To(ctx, 0); err != nil {
+		t.Fatalf("down: %v", err)
+	}
+	if version, err := migrator.Version(ctx); err != nil || version != 0 {
+		t.Fatalf("version after down = %d, %v", version, err)
+	}
+}
+
+func mustExec(t *testing.T, db *gorm.DB, sql string) {
+	t.Helper()
+	if err := db.Exec(sql).Error; err != nil {
+		t.Fatalf("%s: %v", sql, err)
+	}
+}
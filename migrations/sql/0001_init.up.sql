@@ -0,0 +1,32 @@
+-- Исходная схема сервиса в том виде, в котором её создавал AutoMigrate до перехода на версионные
+-- миграции: таблицы отзывов и вопросов. Объекты создаются с IF NOT EXISTS, поэтому на
+-- развёрнутой базе миграция ничего не меняет, а недостающее добавляет 0002.
+
+CREATE TABLE IF NOT EXISTS reviews (
+    id          bigserial PRIMARY KEY,
+    created_at  timestamptz,
+    updated_at  timestamptz,
+    deleted_at  timestamptz,
+    user_id     bigint   NOT NULL,
+    product_id  bigint   NOT NULL,
+    rating      smallint NOT NULL,
+    likes_count bigint   DEFAULT 0,
+    comment     text     NOT NULL,
+    CONSTRAINT chk_reviews_rating CHECK (rating >= 1 AND rating <= 5)
+);
+CREATE INDEX IF NOT EXISTS idx_reviews_deleted_at ON reviews (deleted_at);
+
+CREATE TABLE IF NOT EXISTS questions (
+    id            bigserial PRIMARY KEY,
+    created_at    timestamptz,
+    updated_at    timestamptz,
+    deleted_at    timestamptz,
+    user_id       bigint,
+    guest_id      bytea,
+    product_id    bigint NOT NULL,
+    question_text text   NOT NULL,
+    answer_text   text,
+    likes_count   bigint DEFAULT 0
+);
+CREATE INDEX IF NOT EXISTS idx_questions_deleted_at ON questions (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_questions_guest_id ON questions (guest_id);
@@ -0,0 +1,67 @@
+-- Переход с исходной схемы: гостевые отзывы и модерация, голоса «полезно/бесполезно»,
+-- выделенные отзывы, лайки вопросов и объединение гостей. Все шаги идемпотентны: базы,
+-- на которых новые таблицы уже создал AutoMigrate, проходят миграцию без ошибок.
+
+ALTER TABLE reviews ALTER COLUMN user_id DROP NOT NULL;
+ALTER TABLE reviews ADD COLUMN IF NOT EXISTS guest_id bytea;
+ALTER TABLE reviews ADD COLUMN IF NOT EXISTS dislikes_count bigint DEFAULT 0;
+ALTER TABLE reviews ADD COLUMN IF NOT EXISTS status varchar(16) NOT NULL DEFAULT 'published';
+CREATE INDEX IF NOT EXISTS idx_reviews_user_id ON reviews (user_id);
+CREATE INDEX IF NOT EXISTS idx_reviews_guest_id ON reviews (guest_id);
+CREATE INDEX IF NOT EXISTS idx_reviews_status ON reviews (status);
+
+ALTER TABLE questions ALTER COLUMN user_id DROP NOT NULL;
+ALTER TABLE questions ADD COLUMN IF NOT EXISTS guest_id bytea;
+CREATE INDEX IF NOT EXISTS idx_questions_guest_id ON questions (guest_id);
+
+-- Голоса до этой миграции хранились только в reviews.likes_count и остаются анонимными:
+-- строк review_votes для них нет, поэтому пользователь не может снять такой лайк (снятие
+-- несуществующего голоса ничего не меняет). reconcile -likes пересчитывает счётчики
+-- по review_votes и обнуляет такие лайки.
+CREATE TABLE IF NOT EXISTS review_votes (
+    id         bigserial PRIMARY KEY,
+    review_id  bigint  NOT NULL,
+    user_id    bigint  NOT NULL,
+    helpful    boolean NOT NULL,
+    created_at timestamptz,
+    updated_at timestamptz
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_review_votes_review_user ON review_votes (review_id, user_id);
+CREATE INDEX IF NOT EXISTS idx_review_votes_user_id ON review_votes (user_id);
+
+CREATE TABLE IF NOT EXISTS review_highlights (
+    product_id bigint      NOT NULL,
+    kind       varchar(16) NOT NULL,
+    review_id  bigint      NOT NULL,
+    pinned     boolean     NOT NULL DEFAULT false,
+    updated_at timestamptz,
+    PRIMARY KEY (product_id, kind)
+);
+CREATE INDEX IF NOT EXISTS idx_review_highlights_review_id ON review_highlights (review_id);
+
+CREATE TABLE IF NOT EXISTS question_likes (
+    id          bigserial PRIMARY KEY,
+    question_id bigint NOT NULL,
+    user_id     bigint,
+    guest_id    bytea,
+    created_at  timestamptz
+);
+ALTER TABLE question_likes ALTER COLUMN user_id DROP NOT NULL;
+ALTER TABLE question_likes ADD COLUMN IF NOT EXISTS guest_id bytea;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_question_likes_question_user ON question_likes (question_id, user_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_question_likes_question_guest ON question_likes (question_id, guest_id);
+CREATE INDEX IF NOT EXISTS idx_question_likes_user_id ON question_likes (user_id);
+CREATE INDEX IF NOT EXISTS idx_question_likes_guest_id ON question_likes (guest_id);
+
+CREATE TABLE IF NOT EXISTS guest_merges (
+    id              bigserial PRIMARY KEY,
+    guest_id        bytea  NOT NULL,
+    user_id         bigint NOT NULL,
+    questions_moved bigint NOT NULL DEFAULT 0,
+    likes_moved     bigint NOT NULL DEFAULT 0,
+    likes_dropped   bigint NOT NULL DEFAULT 0,
+    created_at      timestamptz,
+    updated_at      timestamptz
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_guest_merges_guest_id ON guest_merges (guest_id);
+CREATE INDEX IF NOT EXISTS idx_guest_merges_user_id ON guest_merges (user_id);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_questions_search_vector;
+ALTER TABLE questions DROP COLUMN IF EXISTS search_vector;
+
+DROP INDEX IF EXISTS idx_reviews_search_vector;
+ALTER TABLE reviews DROP COLUMN IF EXISTS search_vector;
@@ -0,0 +1,17 @@
+-- Полнотекстовый поиск: generated-колонки tsvector (russian + english) и GIN-индексы к ним.
+
+ALTER TABLE reviews ADD COLUMN IF NOT EXISTS search_vector tsvector
+    GENERATED ALWAYS AS (
+        to_tsvector('russian', coalesce(comment, '')) ||
+        to_tsvector('english', coalesce(comment, ''))
+    ) STORED;
+CREATE INDEX IF NOT EXISTS idx_reviews_search_vector ON reviews USING GIN (search_vector);
+
+ALTER TABLE questions ADD COLUMN IF NOT EXISTS search_vector tsvector
+    GENERATED ALWAYS AS (
+        setweight(to_tsvector('russian', coalesce(question_text, '')), 'A') ||
+        setweight(to_tsvector('english', coalesce(question_text, '')), 'A') ||
+        setweight(to_tsvector('russian', coalesce(answer_text, '')), 'B') ||
+        setweight(to_tsvector('english', coalesce(answer_text, '')), 'B')
+    ) STORED;
+CREATE INDEX IF NOT EXISTS idx_questions_search_vector ON questions USING GIN (search_vector);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_reviews_pending_created;
+DROP INDEX IF EXISTS idx_questions_user_created;
+DROP INDEX IF EXISTS idx_questions_product_created;
+DROP INDEX IF EXISTS idx_reviews_user_created;
+DROP INDEX IF EXISTS idx_reviews_product_created;
@@ -0,0 +1,15 @@
+-- Индексы под списки «новые первыми»: отзывы и вопросы товара, «мои отзывы» и «мои вопросы».
+-- Частичные индексы по живым строкам: удалённые строки в публичные списки не попадают.
+
+CREATE INDEX IF NOT EXISTS idx_reviews_product_created
+    ON reviews (product_id, created_at DESC) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_reviews_user_created
+    ON reviews (user_id, created_at DESC);
+
+CREATE INDEX IF NOT EXISTS idx_questions_product_created
+    ON questions (product_id, created_at DESC) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_questions_user_created
+    ON questions (user_id, created_at DESC);
+
+CREATE INDEX IF NOT EXISTS idx_reviews_pending_created
+    ON reviews (created_at) WHERE status = 'pending' AND deleted_at IS NULL;
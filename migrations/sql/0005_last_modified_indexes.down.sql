@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_questions_product_modified;
+DROP INDEX IF EXISTS idx_reviews_product_modified;
@@ -0,0 +1,8 @@
+-- Индексы под Last-Modified списков товара: время последнего изменения считается по всем
+-- отзывам и вопросам товара, включая удалённые, поэтому частичные индексы 0004 не подходят.
+
+CREATE INDEX IF NOT EXISTS idx_reviews_product_modified
+    ON reviews (product_id) INCLUDE (updated_at, deleted_at);
+
+CREATE INDEX IF NOT EXISTS idx_questions_product_modified
+    ON questions (product_id) INCLUDE (updated_at, deleted_at);
@@ -0,0 +1,30 @@
+package db
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+// registerAround вешает колбэки плагина до и после каждой операции GORM: create, query,
+// update, delete, row и raw. before и after получают имя операции и возвращают колбэк.
+func registerAround(db *gorm.DB, plugin string, before, after func(operation string) func(*gorm.DB)) error {
+	cb := db.Callback()
+	name := func(when, operation string) string {
+		return plugin + ":" + when + "_" + operation
+	}
+	return errors.Join(
+		cb.Create().Before("gorm:create").Register(name("before", "create"), before("create")),
+		cb.Create().After("gorm:create").Register(name("after", "create"), after("create")),
+		cb.Query().Before("gorm:query").Register(name("before", "query"), before("query")),
+		cb.Query().After("gorm:query").Register(name("after", "query"), after("query")),
+		cb.Update().Before("gorm:update").Register(name("before", "update"), before("update")),
+		cb.Update().After("gorm:update").Register(name("after", "update"), after("update")),
+		cb.Delete().Before("gorm:delete").Register(name("before", "delete"), before("delete")),
+		cb.Delete().After("gorm:delete").Register(name("after", "delete"), after("delete")),
+		cb.Row().Before("gorm:row").Register(name("before", "row"), before("row")),
+		cb.Row().After("gorm:row").Register(name("after", "row"), after("row")),
+		cb.Raw().Before("gorm:raw").Register(name("before", "raw"), before("raw")),
+		cb.Raw().After("gorm:raw").Register(name("after", "raw"), after("raw")),
+	)
+}
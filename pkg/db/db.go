@@ -1,19 +1,106 @@
 package db
 
 import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/ShopOnGO/ShopOnGO/pkg/logger"
 	"github.com/ShopOnGO/review-service/configs"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
 
+const (
+	initialRetryDelay = 500 * time.Millisecond
+	maxRetryDelay     = 10 * time.Second
+)
+
 type Db struct {
 	*gorm.DB
 }
 
-func NewDB(conf *configs.Config) *Db {
-	db, err := gorm.Open(postgres.Open(conf.Db.Dsn), &gorm.Config{})
+// NewDB открывает пул соединений с настройками из conf.Db и ждёт, пока база начнёт
+// принимать запросы: повторяет попытки с экспоненциальной задержкой до conf.Db.ConnectTimeout.
+func NewDB(conf *configs.Config) (*Db, error) {
+	sqlDB, err := openPool(conf.Db)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := waitForDB(sqlDB, conf.Db.ConnectTimeout); err != nil {
+		sqlDB.Close()
+		return nil, err
+	}
+
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{})
+	if err != nil {
+		sqlDB.Close()
+		return nil, err
+	}
+	return &Db{db}, nil
+}
+
+// openPool настраивает pgx: statement_timeout на уровне сессии и кэш подготовленных выражений.
+// Нулевая ёмкость кэша отключает подготовленные выражения — это нужно за PgBouncer в режиме transaction.
+func openPool(conf configs.DbConfig) (*sql.DB, error) {
+	connConfig, err := pgx.ParseConfig(conf.Dsn)
+	if err != nil {
+		return nil, fmt.Errorf("parse DSN: %w", err)
+	}
+	if conf.StatementTimeout > 0 {
+		connConfig.RuntimeParams["statement_timeout"] = strconv.FormatInt(conf.StatementTimeout.Milliseconds(), 10)
+	}
+	if conf.StatementCacheCapacity > 0 {
+		connConfig.StatementCacheCapacity = conf.StatementCacheCapacity
+		connConfig.DefaultQueryExecMode = pgx.QueryExecModeCacheStatement
+	} else {
+		connConfig.DefaultQueryExecMode = pgx.QueryExecModeExec
+	}
+
+	sqlDB := stdlib.OpenDB(*connConfig)
+	sqlDB.SetMaxOpenConns(conf.MaxOpenConns)
+	sqlDB.SetMaxIdleConns(conf.MaxIdleConns)
+	sqlDB.SetConnMaxLifetime(conf.ConnMaxLifetime)
+	sqlDB.SetConnMaxIdleTime(conf.ConnMaxIdleTime)
+	return sqlDB, nil
+}
+
+func waitForDB(sqlDB *sql.DB, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	delay := initialRetryDelay
+	for attempt := 1; ; attempt++ {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		err := sqlDB.PingContext(ctx)
+		cancel()
+		if err == nil {
+			if attempt > 1 {
+				logger.Infof("Database is ready after %d attempts", attempt)
+			}
+			return nil
+		}
+
+		if time.Now().Add(delay).After(deadline) {
+			return fmt.Errorf("database is not ready after %s: %w", timeout, err)
+		}
+		logger.Warnf("Database is not ready (attempt %d): %v; retrying in %s", attempt, err, delay)
+		time.Sleep(delay)
+		delay *= 2
+		if delay > maxRetryDelay {
+			delay = maxRetryDelay
+		}
+	}
+}
+
+// Close закрывает пул соединений.
+func (d *Db) Close() error {
+	sqlDB, err := d.DB.DB()
 	if err != nil {
-		panic(err)
+		return err
 	}
-	return &Db{db}
+	return sqlDB.Close()
 }
@@ -0,0 +1,81 @@
+package db
+
+import (
+	"errors"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"gorm.io/gorm"
+)
+
+const queryStartKey = "metrics:query_start"
+
+var (
+	queryDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "review_service_db_query_duration_seconds",
+		Help:    "Длительность запросов GORM по операции и таблице.",
+		Buckets: prometheus.ExponentialBuckets(0.0005, 2, 14),
+	}, []string{"operation", "table"})
+
+	queryErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "review_service_db_query_errors_total",
+		Help: "Количество ошибок запросов GORM по операции и таблице (без gorm.ErrRecordNotFound).",
+	}, []string{"operation", "table"})
+)
+
+// RegisterMetrics публикует статистику пула соединений (go_sql_*) и длительность запросов
+// в реестре Prometheus по умолчанию. Статистика пула снимается при каждом опросе /metrics.
+func (d *Db) RegisterMetrics() error {
+	sqlDB, err := d.DB.DB()
+	if err != nil {
+		return err
+	}
+	for _, c := range []prometheus.Collector{
+		collectors.NewDBStatsCollector(sqlDB, "review_service"),
+		queryDuration,
+		queryErrors,
+	} {
+		if err := prometheus.Register(c); err != nil {
+			return err
+		}
+	}
+	return d.Use(queryMetrics{})
+}
+
+// queryMetrics — плагин GORM, который замеряет каждый запрос колбэками до и после выполнения.
+type queryMetrics struct{}
+
+func (queryMetrics) Name() string {
+	return "review_service:query_metrics"
+}
+
+func (p queryMetrics) Initialize(db *gorm.DB) error {
+	return registerAround(db, p.Name(), func(string) func(*gorm.DB) { return startQuery }, finishQuery)
+}
+
+func startQuery(db *gorm.DB) {
+	db.InstanceSet(queryStartKey, time.Now())
+}
+
+func finishQuery(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		v, ok := db.InstanceGet(queryStartKey)
+		if !ok {
+			return
+		}
+		start, ok := v.(time.Time)
+		if !ok {
+			return
+		}
+
+		table := db.Statement.Table
+		if table == "" {
+			table = "unknown"
+		}
+		queryDuration.WithLabelValues(operation, table).Observe(time.Since(start).Seconds())
+		if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+			queryErrors.WithLabelValues(operation, table).Inc()
+		}
+	}
+}
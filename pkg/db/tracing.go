@@ -0,0 +1,67 @@
+package db
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+const querySpanKey = "tracing:query_span"
+
+// EnableTracing добавляет спан на каждый запрос GORM. Спан становится дочерним к контексту
+// запроса (Db.WithContext), поэтому запросы без контекста попадают в отдельные трассировки.
+func (d *Db) EnableTracing() error {
+	return d.Use(queryTracing{})
+}
+
+// queryTracing — плагин GORM, который открывает спан до запроса и закрывает после.
+type queryTracing struct{}
+
+func (queryTracing) Name() string {
+	return "review_service:query_tracing"
+}
+
+func (p queryTracing) Initialize(db *gorm.DB) error {
+	return registerAround(db, p.Name(), startSpan, endSpan)
+}
+
+func startSpan(operation string) func(*gorm.DB) {
+	tracer := otel.Tracer("gorm.io/gorm")
+	return func(db *gorm.DB) {
+		ctx, span := tracer.Start(db.Statement.Context, "gorm."+operation,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(semconv.DBSystemPostgreSQL, semconv.DBOperationName(operation)),
+		)
+		db.Statement.Context = ctx
+		db.InstanceSet(querySpanKey, span)
+	}
+}
+
+func endSpan(string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		v, ok := db.InstanceGet(querySpanKey)
+		if !ok {
+			return
+		}
+		span, ok := v.(trace.Span)
+		if !ok {
+			return
+		}
+		defer span.End()
+
+		span.SetAttributes(
+			semconv.DBCollectionName(db.Statement.Table),
+			semconv.DBQueryText(db.Statement.SQL.String()),
+			attribute.Int64("db.rows_affected", db.RowsAffected),
+		)
+		if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+			span.RecordError(db.Error)
+			span.SetStatus(codes.Error, db.Error.Error())
+		}
+	}
+}
@@ -0,0 +1,9 @@
+// Package reviewproto — описания gRPC API сервиса отзывов; сгенерированный код лежит в pkg/service.
+//
+// Код перегенерируется командой go generate из каталога модуля. Нужны protoc и плагины в PATH:
+//
+//	go install google.golang.org/protobuf/cmd/protoc-gen-go@v1.36.9
+//	go install google.golang.org/grpc/cmd/protoc-gen-go-grpc@v1.5.1
+package reviewproto
+
+//go:generate protoc --proto_path=proto --go_out=. --go-grpc_out=. proto/common.proto proto/reviews.proto proto/questions.proto
@@ -0,0 +1,1306 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        v3.12.4
+// source: questions.proto
+
+package service
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetQuestionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	QuestionId    uint32                 `protobuf:"varint,1,opt,name=question_id,json=questionId,proto3" json:"question_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetQuestionRequest) Reset() {
+	*x = GetQuestionRequest{}
+	mi := &file_questions_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetQuestionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetQuestionRequest) ProtoMessage() {}
+
+func (x *GetQuestionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_questions_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetQuestionRequest.ProtoReflect.Descriptor instead.
+func (*GetQuestionRequest) Descriptor() ([]byte, []int) {
+	return file_questions_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetQuestionRequest) GetQuestionId() uint32 {
+	if x != nil {
+		return x.QuestionId
+	}
+	return 0
+}
+
+type CreateQuestionRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ProductId uint32                 `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	// Types that are valid to be assigned to Author:
+	//
+	//	*CreateQuestionRequest_UserId
+	//	*CreateQuestionRequest_GuestId
+	Author        isCreateQuestionRequest_Author `protobuf_oneof:"author"`
+	QuestionText  string                         `protobuf:"bytes,4,opt,name=question_text,json=questionText,proto3" json:"question_text,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateQuestionRequest) Reset() {
+	*x = CreateQuestionRequest{}
+	mi := &file_questions_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateQuestionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateQuestionRequest) ProtoMessage() {}
+
+func (x *CreateQuestionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_questions_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateQuestionRequest.ProtoReflect.Descriptor instead.
+func (*CreateQuestionRequest) Descriptor() ([]byte, []int) {
+	return file_questions_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateQuestionRequest) GetProductId() uint32 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *CreateQuestionRequest) GetAuthor() isCreateQuestionRequest_Author {
+	if x != nil {
+		return x.Author
+	}
+	return nil
+}
+
+func (x *CreateQuestionRequest) GetUserId() uint32 {
+	if x != nil {
+		if x, ok := x.Author.(*CreateQuestionRequest_UserId); ok {
+			return x.UserId
+		}
+	}
+	return 0
+}
+
+func (x *CreateQuestionRequest) GetGuestId() []byte {
+	if x != nil {
+		if x, ok := x.Author.(*CreateQuestionRequest_GuestId); ok {
+			return x.GuestId
+		}
+	}
+	return nil
+}
+
+func (x *CreateQuestionRequest) GetQuestionText() string {
+	if x != nil {
+		return x.QuestionText
+	}
+	return ""
+}
+
+type isCreateQuestionRequest_Author interface {
+	isCreateQuestionRequest_Author()
+}
+
+type CreateQuestionRequest_UserId struct {
+	UserId uint32 `protobuf:"varint,2,opt,name=user_id,json=userId,proto3,oneof"`
+}
+
+type CreateQuestionRequest_GuestId struct {
+	GuestId []byte `protobuf:"bytes,3,opt,name=guest_id,json=guestId,proto3,oneof"`
+}
+
+func (*CreateQuestionRequest_UserId) isCreateQuestionRequest_Author() {}
+
+func (*CreateQuestionRequest_GuestId) isCreateQuestionRequest_Author() {}
+
+type UpdateQuestionRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	QuestionId uint32                 `protobuf:"varint,1,opt,name=question_id,json=questionId,proto3" json:"question_id,omitempty"`
+	// если автор задан, вопрос должен принадлежать ему; без автора — правка модератором
+	//
+	// Types that are valid to be assigned to Author:
+	//
+	//	*UpdateQuestionRequest_UserId
+	//	*UpdateQuestionRequest_GuestId
+	Author        isUpdateQuestionRequest_Author `protobuf_oneof:"author"`
+	QuestionText  string                         `protobuf:"bytes,4,opt,name=question_text,json=questionText,proto3" json:"question_text,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateQuestionRequest) Reset() {
+	*x = UpdateQuestionRequest{}
+	mi := &file_questions_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateQuestionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateQuestionRequest) ProtoMessage() {}
+
+func (x *UpdateQuestionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_questions_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateQuestionRequest.ProtoReflect.Descriptor instead.
+func (*UpdateQuestionRequest) Descriptor() ([]byte, []int) {
+	return file_questions_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *UpdateQuestionRequest) GetQuestionId() uint32 {
+	if x != nil {
+		return x.QuestionId
+	}
+	return 0
+}
+
+func (x *UpdateQuestionRequest) GetAuthor() isUpdateQuestionRequest_Author {
+	if x != nil {
+		return x.Author
+	}
+	return nil
+}
+
+func (x *UpdateQuestionRequest) GetUserId() uint32 {
+	if x != nil {
+		if x, ok := x.Author.(*UpdateQuestionRequest_UserId); ok {
+			return x.UserId
+		}
+	}
+	return 0
+}
+
+func (x *UpdateQuestionRequest) GetGuestId() []byte {
+	if x != nil {
+		if x, ok := x.Author.(*UpdateQuestionRequest_GuestId); ok {
+			return x.GuestId
+		}
+	}
+	return nil
+}
+
+func (x *UpdateQuestionRequest) GetQuestionText() string {
+	if x != nil {
+		return x.QuestionText
+	}
+	return ""
+}
+
+type isUpdateQuestionRequest_Author interface {
+	isUpdateQuestionRequest_Author()
+}
+
+type UpdateQuestionRequest_UserId struct {
+	UserId uint32 `protobuf:"varint,2,opt,name=user_id,json=userId,proto3,oneof"`
+}
+
+type UpdateQuestionRequest_GuestId struct {
+	GuestId []byte `protobuf:"bytes,3,opt,name=guest_id,json=guestId,proto3,oneof"`
+}
+
+func (*UpdateQuestionRequest_UserId) isUpdateQuestionRequest_Author() {}
+
+func (*UpdateQuestionRequest_GuestId) isUpdateQuestionRequest_Author() {}
+
+type DeleteQuestionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	QuestionId    uint32                 `protobuf:"varint,1,opt,name=question_id,json=questionId,proto3" json:"question_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteQuestionRequest) Reset() {
+	*x = DeleteQuestionRequest{}
+	mi := &file_questions_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteQuestionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteQuestionRequest) ProtoMessage() {}
+
+func (x *DeleteQuestionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_questions_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteQuestionRequest.ProtoReflect.Descriptor instead.
+func (*DeleteQuestionRequest) Descriptor() ([]byte, []int) {
+	return file_questions_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *DeleteQuestionRequest) GetQuestionId() uint32 {
+	if x != nil {
+		return x.QuestionId
+	}
+	return 0
+}
+
+type DeleteQuestionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteQuestionResponse) Reset() {
+	*x = DeleteQuestionResponse{}
+	mi := &file_questions_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteQuestionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteQuestionResponse) ProtoMessage() {}
+
+func (x *DeleteQuestionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_questions_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteQuestionResponse.ProtoReflect.Descriptor instead.
+func (*DeleteQuestionResponse) Descriptor() ([]byte, []int) {
+	return file_questions_proto_rawDescGZIP(), []int{4}
+}
+
+type LikeQuestionRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	QuestionId uint32                 `protobuf:"varint,1,opt,name=question_id,json=questionId,proto3" json:"question_id,omitempty"`
+	// Types that are valid to be assigned to Author:
+	//
+	//	*LikeQuestionRequest_UserId
+	//	*LikeQuestionRequest_GuestId
+	Author isLikeQuestionRequest_Author `protobuf_oneof:"author"`
+	// true — снять лайк
+	Remove        bool `protobuf:"varint,4,opt,name=remove,proto3" json:"remove,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LikeQuestionRequest) Reset() {
+	*x = LikeQuestionRequest{}
+	mi := &file_questions_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LikeQuestionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LikeQuestionRequest) ProtoMessage() {}
+
+func (x *LikeQuestionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_questions_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LikeQuestionRequest.ProtoReflect.Descriptor instead.
+func (*LikeQuestionRequest) Descriptor() ([]byte, []int) {
+	return file_questions_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *LikeQuestionRequest) GetQuestionId() uint32 {
+	if x != nil {
+		return x.QuestionId
+	}
+	return 0
+}
+
+func (x *LikeQuestionRequest) GetAuthor() isLikeQuestionRequest_Author {
+	if x != nil {
+		return x.Author
+	}
+	return nil
+}
+
+func (x *LikeQuestionRequest) GetUserId() uint32 {
+	if x != nil {
+		if x, ok := x.Author.(*LikeQuestionRequest_UserId); ok {
+			return x.UserId
+		}
+	}
+	return 0
+}
+
+func (x *LikeQuestionRequest) GetGuestId() []byte {
+	if x != nil {
+		if x, ok := x.Author.(*LikeQuestionRequest_GuestId); ok {
+			return x.GuestId
+		}
+	}
+	return nil
+}
+
+func (x *LikeQuestionRequest) GetRemove() bool {
+	if x != nil {
+		return x.Remove
+	}
+	return false
+}
+
+type isLikeQuestionRequest_Author interface {
+	isLikeQuestionRequest_Author()
+}
+
+type LikeQuestionRequest_UserId struct {
+	UserId uint32 `protobuf:"varint,2,opt,name=user_id,json=userId,proto3,oneof"`
+}
+
+type LikeQuestionRequest_GuestId struct {
+	GuestId []byte `protobuf:"bytes,3,opt,name=guest_id,json=guestId,proto3,oneof"`
+}
+
+func (*LikeQuestionRequest_UserId) isLikeQuestionRequest_Author() {}
+
+func (*LikeQuestionRequest_GuestId) isLikeQuestionRequest_Author() {}
+
+type LikeQuestionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	LikesCount    int32                  `protobuf:"varint,1,opt,name=likes_count,json=likesCount,proto3" json:"likes_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LikeQuestionResponse) Reset() {
+	*x = LikeQuestionResponse{}
+	mi := &file_questions_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LikeQuestionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LikeQuestionResponse) ProtoMessage() {}
+
+func (x *LikeQuestionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_questions_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LikeQuestionResponse.ProtoReflect.Descriptor instead.
+func (*LikeQuestionResponse) Descriptor() ([]byte, []int) {
+	return file_questions_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *LikeQuestionResponse) GetLikesCount() int32 {
+	if x != nil {
+		return x.LikesCount
+	}
+	return 0
+}
+
+type AnswerQuestionRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	QuestionId uint32                 `protobuf:"varint,1,opt,name=question_id,json=questionId,proto3" json:"question_id,omitempty"`
+	AnswerText string                 `protobuf:"bytes,2,opt,name=answer_text,json=answerText,proto3" json:"answer_text,omitempty"`
+	// true — заменить существующий ответ; иначе ответ на отвеченный вопрос отклоняется
+	Overwrite     bool `protobuf:"varint,3,opt,name=overwrite,proto3" json:"overwrite,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AnswerQuestionRequest) Reset() {
+	*x = AnswerQuestionRequest{}
+	mi := &file_questions_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AnswerQuestionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnswerQuestionRequest) ProtoMessage() {}
+
+func (x *AnswerQuestionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_questions_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnswerQuestionRequest.ProtoReflect.Descriptor instead.
+func (*AnswerQuestionRequest) Descriptor() ([]byte, []int) {
+	return file_questions_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *AnswerQuestionRequest) GetQuestionId() uint32 {
+	if x != nil {
+		return x.QuestionId
+	}
+	return 0
+}
+
+func (x *AnswerQuestionRequest) GetAnswerText() string {
+	if x != nil {
+		return x.AnswerText
+	}
+	return ""
+}
+
+func (x *AnswerQuestionRequest) GetOverwrite() bool {
+	if x != nil {
+		return x.Overwrite
+	}
+	return false
+}
+
+type GetQuestionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     uint32                 `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int32                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetQuestionsRequest) Reset() {
+	*x = GetQuestionsRequest{}
+	mi := &file_questions_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetQuestionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetQuestionsRequest) ProtoMessage() {}
+
+func (x *GetQuestionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_questions_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetQuestionsRequest.ProtoReflect.Descriptor instead.
+func (*GetQuestionsRequest) Descriptor() ([]byte, []int) {
+	return file_questions_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetQuestionsRequest) GetProductId() uint32 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *GetQuestionsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetQuestionsRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type QuestionListResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Questions     []*Question            `protobuf:"bytes,1,rep,name=questions,proto3" json:"questions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QuestionListResponse) Reset() {
+	*x = QuestionListResponse{}
+	mi := &file_questions_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QuestionListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuestionListResponse) ProtoMessage() {}
+
+func (x *QuestionListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_questions_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuestionListResponse.ProtoReflect.Descriptor instead.
+func (*QuestionListResponse) Descriptor() ([]byte, []int) {
+	return file_questions_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *QuestionListResponse) GetQuestions() []*Question {
+	if x != nil {
+		return x.Questions
+	}
+	return nil
+}
+
+type SearchQuestionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     uint32                 `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Query         string                 `protobuf:"bytes,2,opt,name=query,proto3" json:"query,omitempty"`
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int32                  `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchQuestionsRequest) Reset() {
+	*x = SearchQuestionsRequest{}
+	mi := &file_questions_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchQuestionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchQuestionsRequest) ProtoMessage() {}
+
+func (x *SearchQuestionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_questions_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchQuestionsRequest.ProtoReflect.Descriptor instead.
+func (*SearchQuestionsRequest) Descriptor() ([]byte, []int) {
+	return file_questions_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SearchQuestionsRequest) GetProductId() uint32 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *SearchQuestionsRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchQuestionsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *SearchQuestionsRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type QuestionSearchHit struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Question        *Question              `protobuf:"bytes,1,opt,name=question,proto3" json:"question,omitempty"`
+	Rank            float32                `protobuf:"fixed32,2,opt,name=rank,proto3" json:"rank,omitempty"`
+	QuestionSnippet string                 `protobuf:"bytes,3,opt,name=question_snippet,json=questionSnippet,proto3" json:"question_snippet,omitempty"`
+	AnswerSnippet   string                 `protobuf:"bytes,4,opt,name=answer_snippet,json=answerSnippet,proto3" json:"answer_snippet,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *QuestionSearchHit) Reset() {
+	*x = QuestionSearchHit{}
+	mi := &file_questions_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QuestionSearchHit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuestionSearchHit) ProtoMessage() {}
+
+func (x *QuestionSearchHit) ProtoReflect() protoreflect.Message {
+	mi := &file_questions_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuestionSearchHit.ProtoReflect.Descriptor instead.
+func (*QuestionSearchHit) Descriptor() ([]byte, []int) {
+	return file_questions_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *QuestionSearchHit) GetQuestion() *Question {
+	if x != nil {
+		return x.Question
+	}
+	return nil
+}
+
+func (x *QuestionSearchHit) GetRank() float32 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+func (x *QuestionSearchHit) GetQuestionSnippet() string {
+	if x != nil {
+		return x.QuestionSnippet
+	}
+	return ""
+}
+
+func (x *QuestionSearchHit) GetAnswerSnippet() string {
+	if x != nil {
+		return x.AnswerSnippet
+	}
+	return ""
+}
+
+type SearchQuestionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hits          []*QuestionSearchHit   `protobuf:"bytes,1,rep,name=hits,proto3" json:"hits,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchQuestionsResponse) Reset() {
+	*x = SearchQuestionsResponse{}
+	mi := &file_questions_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchQuestionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchQuestionsResponse) ProtoMessage() {}
+
+func (x *SearchQuestionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_questions_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchQuestionsResponse.ProtoReflect.Descriptor instead.
+func (*SearchQuestionsResponse) Descriptor() ([]byte, []int) {
+	return file_questions_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *SearchQuestionsResponse) GetHits() []*QuestionSearchHit {
+	if x != nil {
+		return x.Hits
+	}
+	return nil
+}
+
+type GetQuestionsByUserRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId uint32                 `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Limit  int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset int32                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	// пользователь, запрашивающий список; удалённые вопросы видны только автору
+	ViewerId      uint32 `protobuf:"varint,4,opt,name=viewer_id,json=viewerId,proto3" json:"viewer_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetQuestionsByUserRequest) Reset() {
+	*x = GetQuestionsByUserRequest{}
+	mi := &file_questions_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetQuestionsByUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetQuestionsByUserRequest) ProtoMessage() {}
+
+func (x *GetQuestionsByUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_questions_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetQuestionsByUserRequest.ProtoReflect.Descriptor instead.
+func (*GetQuestionsByUserRequest) Descriptor() ([]byte, []int) {
+	return file_questions_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetQuestionsByUserRequest) GetUserId() uint32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *GetQuestionsByUserRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetQuestionsByUserRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *GetQuestionsByUserRequest) GetViewerId() uint32 {
+	if x != nil {
+		return x.ViewerId
+	}
+	return 0
+}
+
+type UserQuestion struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Question      *Question              `protobuf:"bytes,1,opt,name=question,proto3" json:"question,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserQuestion) Reset() {
+	*x = UserQuestion{}
+	mi := &file_questions_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserQuestion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserQuestion) ProtoMessage() {}
+
+func (x *UserQuestion) ProtoReflect() protoreflect.Message {
+	mi := &file_questions_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserQuestion.ProtoReflect.Descriptor instead.
+func (*UserQuestion) Descriptor() ([]byte, []int) {
+	return file_questions_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *UserQuestion) GetQuestion() *Question {
+	if x != nil {
+		return x.Question
+	}
+	return nil
+}
+
+func (x *UserQuestion) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type UserQuestionListResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Questions     []*UserQuestion        `protobuf:"bytes,1,rep,name=questions,proto3" json:"questions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserQuestionListResponse) Reset() {
+	*x = UserQuestionListResponse{}
+	mi := &file_questions_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserQuestionListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserQuestionListResponse) ProtoMessage() {}
+
+func (x *UserQuestionListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_questions_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserQuestionListResponse.ProtoReflect.Descriptor instead.
+func (*UserQuestionListResponse) Descriptor() ([]byte, []int) {
+	return file_questions_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *UserQuestionListResponse) GetQuestions() []*UserQuestion {
+	if x != nil {
+		return x.Questions
+	}
+	return nil
+}
+
+type MergeGuestRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GuestId       []byte                 `protobuf:"bytes,1,opt,name=guest_id,json=guestId,proto3" json:"guest_id,omitempty"`
+	UserId        uint32                 `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MergeGuestRequest) Reset() {
+	*x = MergeGuestRequest{}
+	mi := &file_questions_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergeGuestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergeGuestRequest) ProtoMessage() {}
+
+func (x *MergeGuestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_questions_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergeGuestRequest.ProtoReflect.Descriptor instead.
+func (*MergeGuestRequest) Descriptor() ([]byte, []int) {
+	return file_questions_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *MergeGuestRequest) GetGuestId() []byte {
+	if x != nil {
+		return x.GuestId
+	}
+	return nil
+}
+
+func (x *MergeGuestRequest) GetUserId() uint32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type MergeGuestResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	QuestionsMoved int64                  `protobuf:"varint,1,opt,name=questions_moved,json=questionsMoved,proto3" json:"questions_moved,omitempty"`
+	LikesMoved     int64                  `protobuf:"varint,2,opt,name=likes_moved,json=likesMoved,proto3" json:"likes_moved,omitempty"`
+	LikesDropped   int64                  `protobuf:"varint,3,opt,name=likes_dropped,json=likesDropped,proto3" json:"likes_dropped,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *MergeGuestResponse) Reset() {
+	*x = MergeGuestResponse{}
+	mi := &file_questions_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergeGuestResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergeGuestResponse) ProtoMessage() {}
+
+func (x *MergeGuestResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_questions_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergeGuestResponse.ProtoReflect.Descriptor instead.
+func (*MergeGuestResponse) Descriptor() ([]byte, []int) {
+	return file_questions_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *MergeGuestResponse) GetQuestionsMoved() int64 {
+	if x != nil {
+		return x.QuestionsMoved
+	}
+	return 0
+}
+
+func (x *MergeGuestResponse) GetLikesMoved() int64 {
+	if x != nil {
+		return x.LikesMoved
+	}
+	return 0
+}
+
+func (x *MergeGuestResponse) GetLikesDropped() int64 {
+	if x != nil {
+		return x.LikesDropped
+	}
+	return 0
+}
+
+var File_questions_proto protoreflect.FileDescriptor
+
+const file_questions_proto_rawDesc = "" +
+	"\n" +
+	"\x0fquestions.proto\x12\x05proto\x1a\fcommon.proto\"5\n" +
+	"\x12GetQuestionRequest\x12\x1f\n" +
+	"\vquestion_id\x18\x01 \x01(\rR\n" +
+	"questionId\"\x9d\x01\n" +
+	"\x15CreateQuestionRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\rR\tproductId\x12\x19\n" +
+	"\auser_id\x18\x02 \x01(\rH\x00R\x06userId\x12\x1b\n" +
+	"\bguest_id\x18\x03 \x01(\fH\x00R\aguestId\x12#\n" +
+	"\rquestion_text\x18\x04 \x01(\tR\fquestionTextB\b\n" +
+	"\x06author\"\x9f\x01\n" +
+	"\x15UpdateQuestionRequest\x12\x1f\n" +
+	"\vquestion_id\x18\x01 \x01(\rR\n" +
+	"questionId\x12\x19\n" +
+	"\auser_id\x18\x02 \x01(\rH\x00R\x06userId\x12\x1b\n" +
+	"\bguest_id\x18\x03 \x01(\fH\x00R\aguestId\x12#\n" +
+	"\rquestion_text\x18\x04 \x01(\tR\fquestionTextB\b\n" +
+	"\x06author\"8\n" +
+	"\x15DeleteQuestionRequest\x12\x1f\n" +
+	"\vquestion_id\x18\x01 \x01(\rR\n" +
+	"questionId\"\x18\n" +
+	"\x16DeleteQuestionResponse\"\x90\x01\n" +
+	"\x13LikeQuestionRequest\x12\x1f\n" +
+	"\vquestion_id\x18\x01 \x01(\rR\n" +
+	"questionId\x12\x19\n" +
+	"\auser_id\x18\x02 \x01(\rH\x00R\x06userId\x12\x1b\n" +
+	"\bguest_id\x18\x03 \x01(\fH\x00R\aguestId\x12\x16\n" +
+	"\x06remove\x18\x04 \x01(\bR\x06removeB\b\n" +
+	"\x06author\"7\n" +
+	"\x14LikeQuestionResponse\x12\x1f\n" +
+	"\vlikes_count\x18\x01 \x01(\x05R\n" +
+	"likesCount\"w\n" +
+	"\x15AnswerQuestionRequest\x12\x1f\n" +
+	"\vquestion_id\x18\x01 \x01(\rR\n" +
+	"questionId\x12\x1f\n" +
+	"\vanswer_text\x18\x02 \x01(\tR\n" +
+	"answerText\x12\x1c\n" +
+	"\toverwrite\x18\x03 \x01(\bR\toverwrite\"b\n" +
+	"\x13GetQuestionsRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\rR\tproductId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x03 \x01(\x05R\x06offset\"E\n" +
+	"\x14QuestionListResponse\x12-\n" +
+	"\tquestions\x18\x01 \x03(\v2\x0f.proto.QuestionR\tquestions\"{\n" +
+	"\x16SearchQuestionsRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\rR\tproductId\x12\x14\n" +
+	"\x05query\x18\x02 \x01(\tR\x05query\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x04 \x01(\x05R\x06offset\"\xa6\x01\n" +
+	"\x11QuestionSearchHit\x12+\n" +
+	"\bquestion\x18\x01 \x01(\v2\x0f.proto.QuestionR\bquestion\x12\x12\n" +
+	"\x04rank\x18\x02 \x01(\x02R\x04rank\x12)\n" +
+	"\x10question_snippet\x18\x03 \x01(\tR\x0fquestionSnippet\x12%\n" +
+	"\x0eanswer_snippet\x18\x04 \x01(\tR\ranswerSnippet\"G\n" +
+	"\x17SearchQuestionsResponse\x12,\n" +
+	"\x04hits\x18\x01 \x03(\v2\x18.proto.QuestionSearchHitR\x04hits\"\x7f\n" +
+	"\x19GetQuestionsByUserRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\rR\x06userId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x03 \x01(\x05R\x06offset\x12\x1b\n" +
+	"\tviewer_id\x18\x04 \x01(\rR\bviewerId\"S\n" +
+	"\fUserQuestion\x12+\n" +
+	"\bquestion\x18\x01 \x01(\v2\x0f.proto.QuestionR\bquestion\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\"M\n" +
+	"\x18UserQuestionListResponse\x121\n" +
+	"\tquestions\x18\x01 \x03(\v2\x13.proto.UserQuestionR\tquestions\"G\n" +
+	"\x11MergeGuestRequest\x12\x19\n" +
+	"\bguest_id\x18\x01 \x01(\fR\aguestId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\rR\x06userId\"\x83\x01\n" +
+	"\x12MergeGuestResponse\x12'\n" +
+	"\x0fquestions_moved\x18\x01 \x01(\x03R\x0equestionsMoved\x12\x1f\n" +
+	"\vlikes_moved\x18\x02 \x01(\x03R\n" +
+	"likesMoved\x12#\n" +
+	"\rlikes_dropped\x18\x03 \x01(\x03R\flikesDropped2\xe8\x05\n" +
+	"\x0fQuestionService\x129\n" +
+	"\vGetQuestion\x12\x19.proto.GetQuestionRequest\x1a\x0f.proto.Question\x12?\n" +
+	"\x0eCreateQuestion\x12\x1c.proto.CreateQuestionRequest\x1a\x0f.proto.Question\x12?\n" +
+	"\x0eUpdateQuestion\x12\x1c.proto.UpdateQuestionRequest\x1a\x0f.proto.Question\x12M\n" +
+	"\x0eDeleteQuestion\x12\x1c.proto.DeleteQuestionRequest\x1a\x1d.proto.DeleteQuestionResponse\x12G\n" +
+	"\fLikeQuestion\x12\x1a.proto.LikeQuestionRequest\x1a\x1b.proto.LikeQuestionResponse\x12?\n" +
+	"\x0eAnswerQuestion\x12\x1c.proto.AnswerQuestionRequest\x1a\x0f.proto.Question\x12Q\n" +
+	"\x16GetQuestionsForProduct\x12\x1a.proto.GetQuestionsRequest\x1a\x1b.proto.QuestionListResponse\x12P\n" +
+	"\x0fSearchQuestions\x12\x1d.proto.SearchQuestionsRequest\x1a\x1e.proto.SearchQuestionsResponse\x12W\n" +
+	"\x12GetQuestionsByUser\x12 .proto.GetQuestionsByUserRequest\x1a\x1f.proto.UserQuestionListResponse\x12A\n" +
+	"\n" +
+	"MergeGuest\x12\x18.proto.MergeGuestRequest\x1a\x19.proto.MergeGuestResponseB\x0fZ\r./pkg/serviceb\x06proto3"
+
+var (
+	file_questions_proto_rawDescOnce sync.Once
+	file_questions_proto_rawDescData []byte
+)
+
+func file_questions_proto_rawDescGZIP() []byte {
+	file_questions_proto_rawDescOnce.Do(func() {
+		file_questions_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_questions_proto_rawDesc), len(file_questions_proto_rawDesc)))
+	})
+	return file_questions_proto_rawDescData
+}
+
+var file_questions_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
+var file_questions_proto_goTypes = []any{
+	(*GetQuestionRequest)(nil),        // 0: proto.GetQuestionRequest
+	(*CreateQuestionRequest)(nil),     // 1: proto.CreateQuestionRequest
+	(*UpdateQuestionRequest)(nil),     // 2: proto.UpdateQuestionRequest
+	(*DeleteQuestionRequest)(nil),     // 3: proto.DeleteQuestionRequest
+	(*DeleteQuestionResponse)(nil),    // 4: proto.DeleteQuestionResponse
+	(*LikeQuestionRequest)(nil),       // 5: proto.LikeQuestionRequest
+	(*LikeQuestionResponse)(nil),      // 6: proto.LikeQuestionResponse
+	(*AnswerQuestionRequest)(nil),     // 7: proto.AnswerQuestionRequest
+	(*GetQuestionsRequest)(nil),       // 8: proto.GetQuestionsRequest
+	(*QuestionListResponse)(nil),      // 9: proto.QuestionListResponse
+	(*SearchQuestionsRequest)(nil),    // 10: proto.SearchQuestionsRequest
+	(*QuestionSearchHit)(nil),         // 11: proto.QuestionSearchHit
+	(*SearchQuestionsResponse)(nil),   // 12: proto.SearchQuestionsResponse
+	(*GetQuestionsByUserRequest)(nil), // 13: proto.GetQuestionsByUserRequest
+	(*UserQuestion)(nil),              // 14: proto.UserQuestion
+	(*UserQuestionListResponse)(nil),  // 15: proto.UserQuestionListResponse
+	(*MergeGuestRequest)(nil),         // 16: proto.MergeGuestRequest
+	(*MergeGuestResponse)(nil),        // 17: proto.MergeGuestResponse
+	(*Question)(nil),                  // 18: proto.Question
+}
+var file_questions_proto_depIdxs = []int32{
+	18, // 0: proto.QuestionListResponse.questions:type_name -> proto.Question
+	18, // 1: proto.QuestionSearchHit.question:type_name -> proto.Question
+	11, // 2: proto.SearchQuestionsResponse.hits:type_name -> proto.QuestionSearchHit
+	18, // 3: proto.UserQuestion.question:type_name -> proto.Question
+	14, // 4: proto.UserQuestionListResponse.questions:type_name -> proto.UserQuestion
+	0,  // 5: proto.QuestionService.GetQuestion:input_type -> proto.GetQuestionRequest
+	1,  // 6: proto.QuestionService.CreateQuestion:input_type -> proto.CreateQuestionRequest
+	2,  // 7: proto.QuestionService.UpdateQuestion:input_type -> proto.UpdateQuestionRequest
+	3,  // 8: proto.QuestionService.DeleteQuestion:input_type -> proto.DeleteQuestionRequest
+	5,  // 9: proto.QuestionService.LikeQuestion:input_type -> proto.LikeQuestionRequest
+	7,  // 10: proto.QuestionService.AnswerQuestion:input_type -> proto.AnswerQuestionRequest
+	8,  // 11: proto.QuestionService.GetQuestionsForProduct:input_type -> proto.GetQuestionsRequest
+	10, // 12: proto.QuestionService.SearchQuestions:input_type -> proto.SearchQuestionsRequest
+	13, // 13: proto.QuestionService.GetQuestionsByUser:input_type -> proto.GetQuestionsByUserRequest
+	16, // 14: proto.QuestionService.MergeGuest:input_type -> proto.MergeGuestRequest
+	18, // 15: proto.QuestionService.GetQuestion:output_type -> proto.Question
+	18, // 16: proto.QuestionService.CreateQuestion:output_type -> proto.Question
+	18, // 17: proto.QuestionService.UpdateQuestion:output_type -> proto.Question
+	4,  // 18: proto.QuestionService.DeleteQuestion:output_type -> proto.DeleteQuestionResponse
+	6,  // 19: proto.QuestionService.LikeQuestion:output_type -> proto.LikeQuestionResponse
+	18, // 20: proto.QuestionService.AnswerQuestion:output_type -> proto.Question
+	9,  // 21: proto.QuestionService.GetQuestionsForProduct:output_type -> proto.QuestionListResponse
+	12, // 22: proto.QuestionService.SearchQuestions:output_type -> proto.SearchQuestionsResponse
+	15, // 23: proto.QuestionService.GetQuestionsByUser:output_type -> proto.UserQuestionListResponse
+	17, // 24: proto.QuestionService.MergeGuest:output_type -> proto.MergeGuestResponse
+	15, // [15:25] is the sub-list for method output_type
+	5,  // [5:15] is the sub-list for method input_type
+	5,  // [5:5] is the sub-list for extension type_name
+	5,  // [5:5] is the sub-list for extension extendee
+	0,  // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_questions_proto_init() }
+func file_questions_proto_init() {
+	if File_questions_proto != nil {
+		return
+	}
+	file_common_proto_init()
+	file_questions_proto_msgTypes[1].OneofWrappers = []any{
+		(*CreateQuestionRequest_UserId)(nil),
+		(*CreateQuestionRequest_GuestId)(nil),
+	}
+	file_questions_proto_msgTypes[2].OneofWrappers = []any{
+		(*UpdateQuestionRequest_UserId)(nil),
+		(*UpdateQuestionRequest_GuestId)(nil),
+	}
+	file_questions_proto_msgTypes[5].OneofWrappers = []any{
+		(*LikeQuestionRequest_UserId)(nil),
+		(*LikeQuestionRequest_GuestId)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_questions_proto_rawDesc), len(file_questions_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   18,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_questions_proto_goTypes,
+		DependencyIndexes: file_questions_proto_depIdxs,
+		MessageInfos:      file_questions_proto_msgTypes,
+	}.Build()
+	File_questions_proto = out.File
+	file_questions_proto_goTypes = nil
+	file_questions_proto_depIdxs = nil
+}
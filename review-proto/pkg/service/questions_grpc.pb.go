@@ -0,0 +1,463 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v3.12.4
+// source: questions.proto
+
+package service
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	QuestionService_GetQuestion_FullMethodName            = "/proto.QuestionService/GetQuestion"
+	QuestionService_CreateQuestion_FullMethodName         = "/proto.QuestionService/CreateQuestion"
+	QuestionService_UpdateQuestion_FullMethodName         = "/proto.QuestionService/UpdateQuestion"
+	QuestionService_DeleteQuestion_FullMethodName         = "/proto.QuestionService/DeleteQuestion"
+	QuestionService_LikeQuestion_FullMethodName           = "/proto.QuestionService/LikeQuestion"
+	QuestionService_AnswerQuestion_FullMethodName         = "/proto.QuestionService/AnswerQuestion"
+	QuestionService_GetQuestionsForProduct_FullMethodName = "/proto.QuestionService/GetQuestionsForProduct"
+	QuestionService_SearchQuestions_FullMethodName        = "/proto.QuestionService/SearchQuestions"
+	QuestionService_GetQuestionsByUser_FullMethodName     = "/proto.QuestionService/GetQuestionsByUser"
+	QuestionService_MergeGuest_FullMethodName             = "/proto.QuestionService/MergeGuest"
+)
+
+// QuestionServiceClient is the client API for QuestionService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type QuestionServiceClient interface {
+	GetQuestion(ctx context.Context, in *GetQuestionRequest, opts ...grpc.CallOption) (*Question, error)
+	CreateQuestion(ctx context.Context, in *CreateQuestionRequest, opts ...grpc.CallOption) (*Question, error)
+	UpdateQuestion(ctx context.Context, in *UpdateQuestionRequest, opts ...grpc.CallOption) (*Question, error)
+	DeleteQuestion(ctx context.Context, in *DeleteQuestionRequest, opts ...grpc.CallOption) (*DeleteQuestionResponse, error)
+	LikeQuestion(ctx context.Context, in *LikeQuestionRequest, opts ...grpc.CallOption) (*LikeQuestionResponse, error)
+	AnswerQuestion(ctx context.Context, in *AnswerQuestionRequest, opts ...grpc.CallOption) (*Question, error)
+	GetQuestionsForProduct(ctx context.Context, in *GetQuestionsRequest, opts ...grpc.CallOption) (*QuestionListResponse, error)
+	SearchQuestions(ctx context.Context, in *SearchQuestionsRequest, opts ...grpc.CallOption) (*SearchQuestionsResponse, error)
+	GetQuestionsByUser(ctx context.Context, in *GetQuestionsByUserRequest, opts ...grpc.CallOption) (*UserQuestionListResponse, error)
+	MergeGuest(ctx context.Context, in *MergeGuestRequest, opts ...grpc.CallOption) (*MergeGuestResponse, error)
+}
+
+type questionServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewQuestionServiceClient(cc grpc.ClientConnInterface) QuestionServiceClient {
+	return &questionServiceClient{cc}
+}
+
+func (c *questionServiceClient) GetQuestion(ctx context.Context, in *GetQuestionRequest, opts ...grpc.CallOption) (*Question, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Question)
+	err := c.cc.Invoke(ctx, QuestionService_GetQuestion_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *questionServiceClient) CreateQuestion(ctx context.Context, in *CreateQuestionRequest, opts ...grpc.CallOption) (*Question, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Question)
+	err := c.cc.Invoke(ctx, QuestionService_CreateQuestion_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *questionServiceClient) UpdateQuestion(ctx context.Context, in *UpdateQuestionRequest, opts ...grpc.CallOption) (*Question, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Question)
+	err := c.cc.Invoke(ctx, QuestionService_UpdateQuestion_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *questionServiceClient) DeleteQuestion(ctx context.Context, in *DeleteQuestionRequest, opts ...grpc.CallOption) (*DeleteQuestionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteQuestionResponse)
+	err := c.cc.Invoke(ctx, QuestionService_DeleteQuestion_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *questionServiceClient) LikeQuestion(ctx context.Context, in *LikeQuestionRequest, opts ...grpc.CallOption) (*LikeQuestionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LikeQuestionResponse)
+	err := c.cc.Invoke(ctx, QuestionService_LikeQuestion_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *questionServiceClient) AnswerQuestion(ctx context.Context, in *AnswerQuestionRequest, opts ...grpc.CallOption) (*Question, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Question)
+	err := c.cc.Invoke(ctx, QuestionService_AnswerQuestion_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *questionServiceClient) GetQuestionsForProduct(ctx context.Context, in *GetQuestionsRequest, opts ...grpc.CallOption) (*QuestionListResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(QuestionListResponse)
+	err := c.cc.Invoke(ctx, QuestionService_GetQuestionsForProduct_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *questionServiceClient) SearchQuestions(ctx context.Context, in *SearchQuestionsRequest, opts ...grpc.CallOption) (*SearchQuestionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchQuestionsResponse)
+	err := c.cc.Invoke(ctx, QuestionService_SearchQuestions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *questionServiceClient) GetQuestionsByUser(ctx context.Context, in *GetQuestionsByUserRequest, opts ...grpc.CallOption) (*UserQuestionListResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserQuestionListResponse)
+	err := c.cc.Invoke(ctx, QuestionService_GetQuestionsByUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *questionServiceClient) MergeGuest(ctx context.Context, in *MergeGuestRequest, opts ...grpc.CallOption) (*MergeGuestResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MergeGuestResponse)
+	err := c.cc.Invoke(ctx, QuestionService_MergeGuest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// QuestionServiceServer is the server API for QuestionService service.
+// All implementations must embed UnimplementedQuestionServiceServer
+// for forward compatibility.
+type QuestionServiceServer interface {
+	GetQuestion(context.Context, *GetQuestionRequest) (*Question, error)
+	CreateQuestion(context.Context, *CreateQuestionRequest) (*Question, error)
+	UpdateQuestion(context.Context, *UpdateQuestionRequest) (*Question, error)
+	DeleteQuestion(context.Context, *DeleteQuestionRequest) (*DeleteQuestionResponse, error)
+	LikeQuestion(context.Context, *LikeQuestionRequest) (*LikeQuestionResponse, error)
+	AnswerQuestion(context.Context, *AnswerQuestionRequest) (*Question, error)
+	GetQuestionsForProduct(context.Context, *GetQuestionsRequest) (*QuestionListResponse, error)
+	SearchQuestions(context.Context, *SearchQuestionsRequest) (*SearchQuestionsResponse, error)
+	GetQuestionsByUser(context.Context, *GetQuestionsByUserRequest) (*UserQuestionListResponse, error)
+	MergeGuest(context.Context, *MergeGuestRequest) (*MergeGuestResponse, error)
+	mustEmbedUnimplementedQuestionServiceServer()
+}
+
+// UnimplementedQuestionServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedQuestionServiceServer struct{}
+
+func (UnimplementedQuestionServiceServer) GetQuestion(context.Context, *GetQuestionRequest) (*Question, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetQuestion not implemented")
+}
+func (UnimplementedQuestionServiceServer) CreateQuestion(context.Context, *CreateQuestionRequest) (*Question, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateQuestion not implemented")
+}
+func (UnimplementedQuestionServiceServer) UpdateQuestion(context.Context, *UpdateQuestionRequest) (*Question, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateQuestion not implemented")
+}
+func (UnimplementedQuestionServiceServer) DeleteQuestion(context.Context, *DeleteQuestionRequest) (*DeleteQuestionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteQuestion not implemented")
+}
+func (UnimplementedQuestionServiceServer) LikeQuestion(context.Context, *LikeQuestionRequest) (*LikeQuestionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LikeQuestion not implemented")
+}
+func (UnimplementedQuestionServiceServer) AnswerQuestion(context.Context, *AnswerQuestionRequest) (*Question, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AnswerQuestion not implemented")
+}
+func (UnimplementedQuestionServiceServer) GetQuestionsForProduct(context.Context, *GetQuestionsRequest) (*QuestionListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetQuestionsForProduct not implemented")
+}
+func (UnimplementedQuestionServiceServer) SearchQuestions(context.Context, *SearchQuestionsRequest) (*SearchQuestionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchQuestions not implemented")
+}
+func (UnimplementedQuestionServiceServer) GetQuestionsByUser(context.Context, *GetQuestionsByUserRequest) (*UserQuestionListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetQuestionsByUser not implemented")
+}
+func (UnimplementedQuestionServiceServer) MergeGuest(context.Context, *MergeGuestRequest) (*MergeGuestResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MergeGuest not implemented")
+}
+func (UnimplementedQuestionServiceServer) mustEmbedUnimplementedQuestionServiceServer() {}
+func (UnimplementedQuestionServiceServer) testEmbeddedByValue()                         {}
+
+// UnsafeQuestionServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to QuestionServiceServer will
+// result in compilation errors.
+type UnsafeQuestionServiceServer interface {
+	mustEmbedUnimplementedQuestionServiceServer()
+}
+
+func RegisterQuestionServiceServer(s grpc.ServiceRegistrar, srv QuestionServiceServer) {
+	// If the following call pancis, it indicates UnimplementedQuestionServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&QuestionService_ServiceDesc, srv)
+}
+
+func _QuestionService_GetQuestion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetQuestionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuestionServiceServer).GetQuestion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QuestionService_GetQuestion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuestionServiceServer).GetQuestion(ctx, req.(*GetQuestionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QuestionService_CreateQuestion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateQuestionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuestionServiceServer).CreateQuestion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QuestionService_CreateQuestion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuestionServiceServer).CreateQuestion(ctx, req.(*CreateQuestionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QuestionService_UpdateQuestion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateQuestionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuestionServiceServer).UpdateQuestion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QuestionService_UpdateQuestion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuestionServiceServer).UpdateQuestion(ctx, req.(*UpdateQuestionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QuestionService_DeleteQuestion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteQuestionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuestionServiceServer).DeleteQuestion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QuestionService_DeleteQuestion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuestionServiceServer).DeleteQuestion(ctx, req.(*DeleteQuestionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QuestionService_LikeQuestion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LikeQuestionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuestionServiceServer).LikeQuestion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QuestionService_LikeQuestion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuestionServiceServer).LikeQuestion(ctx, req.(*LikeQuestionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QuestionService_AnswerQuestion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AnswerQuestionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuestionServiceServer).AnswerQuestion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QuestionService_AnswerQuestion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuestionServiceServer).AnswerQuestion(ctx, req.(*AnswerQuestionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QuestionService_GetQuestionsForProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetQuestionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuestionServiceServer).GetQuestionsForProduct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QuestionService_GetQuestionsForProduct_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuestionServiceServer).GetQuestionsForProduct(ctx, req.(*GetQuestionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QuestionService_SearchQuestions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchQuestionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuestionServiceServer).SearchQuestions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QuestionService_SearchQuestions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuestionServiceServer).SearchQuestions(ctx, req.(*SearchQuestionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QuestionService_GetQuestionsByUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetQuestionsByUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuestionServiceServer).GetQuestionsByUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QuestionService_GetQuestionsByUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuestionServiceServer).GetQuestionsByUser(ctx, req.(*GetQuestionsByUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QuestionService_MergeGuest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MergeGuestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuestionServiceServer).MergeGuest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QuestionService_MergeGuest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuestionServiceServer).MergeGuest(ctx, req.(*MergeGuestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// QuestionService_ServiceDesc is the grpc.ServiceDesc for QuestionService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var QuestionService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "proto.QuestionService",
+	HandlerType: (*QuestionServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetQuestion",
+			Handler:    _QuestionService_GetQuestion_Handler,
+		},
+		{
+			MethodName: "CreateQuestion",
+			Handler:    _QuestionService_CreateQuestion_Handler,
+		},
+		{
+			MethodName: "UpdateQuestion",
+			Handler:    _QuestionService_UpdateQuestion_Handler,
+		},
+		{
+			MethodName: "DeleteQuestion",
+			Handler:    _QuestionService_DeleteQuestion_Handler,
+		},
+		{
+			MethodName: "LikeQuestion",
+			Handler:    _QuestionService_LikeQuestion_Handler,
+		},
+		{
+			MethodName: "AnswerQuestion",
+			Handler:    _QuestionService_AnswerQuestion_Handler,
+		},
+		{
+			MethodName: "GetQuestionsForProduct",
+			Handler:    _QuestionService_GetQuestionsForProduct_Handler,
+		},
+		{
+			MethodName: "SearchQuestions",
+			Handler:    _QuestionService_SearchQuestions_Handler,
+		},
+		{
+			MethodName: "GetQuestionsByUser",
+			Handler:    _QuestionService_GetQuestionsByUser_Handler,
+		},
+		{
+			MethodName: "MergeGuest",
+			Handler:    _QuestionService_MergeGuest_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "questions.proto",
+}
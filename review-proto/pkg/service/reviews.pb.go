@@ -0,0 +1,1493 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        v3.12.4
+// source: reviews.proto
+
+package service
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetReviewRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReviewId      uint32                 `protobuf:"varint,1,opt,name=review_id,json=reviewId,proto3" json:"review_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReviewRequest) Reset() {
+	*x = GetReviewRequest{}
+	mi := &file_reviews_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReviewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReviewRequest) ProtoMessage() {}
+
+func (x *GetReviewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reviews_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReviewRequest.ProtoReflect.Descriptor instead.
+func (*GetReviewRequest) Descriptor() ([]byte, []int) {
+	return file_reviews_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetReviewRequest) GetReviewId() uint32 {
+	if x != nil {
+		return x.ReviewId
+	}
+	return 0
+}
+
+type CreateReviewRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ProductId uint32                 `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	// Types that are valid to be assigned to Author:
+	//
+	//	*CreateReviewRequest_UserId
+	//	*CreateReviewRequest_GuestId
+	Author isCreateReviewRequest_Author `protobuf_oneof:"author"`
+	// от 1 до 5
+	Rating        int32  `protobuf:"varint,4,opt,name=rating,proto3" json:"rating,omitempty"`
+	Comment       string `protobuf:"bytes,5,opt,name=comment,proto3" json:"comment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateReviewRequest) Reset() {
+	*x = CreateReviewRequest{}
+	mi := &file_reviews_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateReviewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateReviewRequest) ProtoMessage() {}
+
+func (x *CreateReviewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reviews_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateReviewRequest.ProtoReflect.Descriptor instead.
+func (*CreateReviewRequest) Descriptor() ([]byte, []int) {
+	return file_reviews_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateReviewRequest) GetProductId() uint32 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *CreateReviewRequest) GetAuthor() isCreateReviewRequest_Author {
+	if x != nil {
+		return x.Author
+	}
+	return nil
+}
+
+func (x *CreateReviewRequest) GetUserId() uint32 {
+	if x != nil {
+		if x, ok := x.Author.(*CreateReviewRequest_UserId); ok {
+			return x.UserId
+		}
+	}
+	return 0
+}
+
+func (x *CreateReviewRequest) GetGuestId() []byte {
+	if x != nil {
+		if x, ok := x.Author.(*CreateReviewRequest_GuestId); ok {
+			return x.GuestId
+		}
+	}
+	return nil
+}
+
+func (x *CreateReviewRequest) GetRating() int32 {
+	if x != nil {
+		return x.Rating
+	}
+	return 0
+}
+
+func (x *CreateReviewRequest) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+type isCreateReviewRequest_Author interface {
+	isCreateReviewRequest_Author()
+}
+
+type CreateReviewRequest_UserId struct {
+	UserId uint32 `protobuf:"varint,2,opt,name=user_id,json=userId,proto3,oneof"`
+}
+
+type CreateReviewRequest_GuestId struct {
+	GuestId []byte `protobuf:"bytes,3,opt,name=guest_id,json=guestId,proto3,oneof"`
+}
+
+func (*CreateReviewRequest_UserId) isCreateReviewRequest_Author() {}
+
+func (*CreateReviewRequest_GuestId) isCreateReviewRequest_Author() {}
+
+type UpdateReviewRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	ReviewId uint32                 `protobuf:"varint,1,opt,name=review_id,json=reviewId,proto3" json:"review_id,omitempty"`
+	// если автор задан, отзыв должен принадлежать ему; без автора — правка модератором
+	//
+	// Types that are valid to be assigned to Author:
+	//
+	//	*UpdateReviewRequest_UserId
+	//	*UpdateReviewRequest_GuestId
+	Author isUpdateReviewRequest_Author `protobuf_oneof:"author"`
+	// 0 — оценка не меняется
+	Rating int32 `protobuf:"varint,4,opt,name=rating,proto3" json:"rating,omitempty"`
+	// пустая строка — комментарий не меняется
+	Comment       string `protobuf:"bytes,5,opt,name=comment,proto3" json:"comment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateReviewRequest) Reset() {
+	*x = UpdateReviewRequest{}
+	mi := &file_reviews_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateReviewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateReviewRequest) ProtoMessage() {}
+
+func (x *UpdateReviewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reviews_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateReviewRequest.ProtoReflect.Descriptor instead.
+func (*UpdateReviewRequest) Descriptor() ([]byte, []int) {
+	return file_reviews_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *UpdateReviewRequest) GetReviewId() uint32 {
+	if x != nil {
+		return x.ReviewId
+	}
+	return 0
+}
+
+func (x *UpdateReviewRequest) GetAuthor() isUpdateReviewRequest_Author {
+	if x != nil {
+		return x.Author
+	}
+	return nil
+}
+
+func (x *UpdateReviewRequest) GetUserId() uint32 {
+	if x != nil {
+		if x, ok := x.Author.(*UpdateReviewRequest_UserId); ok {
+			return x.UserId
+		}
+	}
+	return 0
+}
+
+func (x *UpdateReviewRequest) GetGuestId() []byte {
+	if x != nil {
+		if x, ok := x.Author.(*UpdateReviewRequest_GuestId); ok {
+			return x.GuestId
+		}
+	}
+	return nil
+}
+
+func (x *UpdateReviewRequest) GetRating() int32 {
+	if x != nil {
+		return x.Rating
+	}
+	return 0
+}
+
+func (x *UpdateReviewRequest) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+type isUpdateReviewRequest_Author interface {
+	isUpdateReviewRequest_Author()
+}
+
+type UpdateReviewRequest_UserId struct {
+	UserId uint32 `protobuf:"varint,2,opt,name=user_id,json=userId,proto3,oneof"`
+}
+
+type UpdateReviewRequest_GuestId struct {
+	GuestId []byte `protobuf:"bytes,3,opt,name=guest_id,json=guestId,proto3,oneof"`
+}
+
+func (*UpdateReviewRequest_UserId) isUpdateReviewRequest_Author() {}
+
+func (*UpdateReviewRequest_GuestId) isUpdateReviewRequest_Author() {}
+
+type DeleteReviewRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReviewId      uint32                 `protobuf:"varint,1,opt,name=review_id,json=reviewId,proto3" json:"review_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteReviewRequest) Reset() {
+	*x = DeleteReviewRequest{}
+	mi := &file_reviews_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteReviewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteReviewRequest) ProtoMessage() {}
+
+func (x *DeleteReviewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reviews_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteReviewRequest.ProtoReflect.Descriptor instead.
+func (*DeleteReviewRequest) Descriptor() ([]byte, []int) {
+	return file_reviews_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *DeleteReviewRequest) GetReviewId() uint32 {
+	if x != nil {
+		return x.ReviewId
+	}
+	return 0
+}
+
+type DeleteReviewResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteReviewResponse) Reset() {
+	*x = DeleteReviewResponse{}
+	mi := &file_reviews_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteReviewResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteReviewResponse) ProtoMessage() {}
+
+func (x *DeleteReviewResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_reviews_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteReviewResponse.ProtoReflect.Descriptor instead.
+func (*DeleteReviewResponse) Descriptor() ([]byte, []int) {
+	return file_reviews_proto_rawDescGZIP(), []int{4}
+}
+
+type LikeReviewRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	ReviewId uint32                 `protobuf:"varint,1,opt,name=review_id,json=reviewId,proto3" json:"review_id,omitempty"`
+	UserId   uint32                 `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// true — снять лайк
+	Remove        bool `protobuf:"varint,3,opt,name=remove,proto3" json:"remove,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LikeReviewRequest) Reset() {
+	*x = LikeReviewRequest{}
+	mi := &file_reviews_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LikeReviewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LikeReviewRequest) ProtoMessage() {}
+
+func (x *LikeReviewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reviews_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LikeReviewRequest.ProtoReflect.Descriptor instead.
+func (*LikeReviewRequest) Descriptor() ([]byte, []int) {
+	return file_reviews_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *LikeReviewRequest) GetReviewId() uint32 {
+	if x != nil {
+		return x.ReviewId
+	}
+	return 0
+}
+
+func (x *LikeReviewRequest) GetUserId() uint32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *LikeReviewRequest) GetRemove() bool {
+	if x != nil {
+		return x.Remove
+	}
+	return false
+}
+
+type LikeReviewResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	LikesCount    int32                  `protobuf:"varint,1,opt,name=likes_count,json=likesCount,proto3" json:"likes_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LikeReviewResponse) Reset() {
+	*x = LikeReviewResponse{}
+	mi := &file_reviews_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LikeReviewResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LikeReviewResponse) ProtoMessage() {}
+
+func (x *LikeReviewResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_reviews_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LikeReviewResponse.ProtoReflect.Descriptor instead.
+func (*LikeReviewResponse) Descriptor() ([]byte, []int) {
+	return file_reviews_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *LikeReviewResponse) GetLikesCount() int32 {
+	if x != nil {
+		return x.LikesCount
+	}
+	return 0
+}
+
+type GetReviewsRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ProductId uint32                 `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Limit     int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset    int32                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	// "newest" (по умолчанию) или "helpful" — по нижней границе Уилсона голосов полезности
+	Sort          string `protobuf:"bytes,4,opt,name=sort,proto3" json:"sort,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReviewsRequest) Reset() {
+	*x = GetReviewsRequest{}
+	mi := &file_reviews_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReviewsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReviewsRequest) ProtoMessage() {}
+
+func (x *GetReviewsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reviews_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReviewsRequest.ProtoReflect.Descriptor instead.
+func (*GetReviewsRequest) Descriptor() ([]byte, []int) {
+	return file_reviews_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetReviewsRequest) GetProductId() uint32 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *GetReviewsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetReviewsRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *GetReviewsRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+type ReviewListResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reviews       []*Review              `protobuf:"bytes,1,rep,name=reviews,proto3" json:"reviews,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReviewListResponse) Reset() {
+	*x = ReviewListResponse{}
+	mi := &file_reviews_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReviewListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReviewListResponse) ProtoMessage() {}
+
+func (x *ReviewListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_reviews_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReviewListResponse.ProtoReflect.Descriptor instead.
+func (*ReviewListResponse) Descriptor() ([]byte, []int) {
+	return file_reviews_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ReviewListResponse) GetReviews() []*Review {
+	if x != nil {
+		return x.Reviews
+	}
+	return nil
+}
+
+type SearchReviewsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     uint32                 `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Query         string                 `protobuf:"bytes,2,opt,name=query,proto3" json:"query,omitempty"`
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int32                  `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchReviewsRequest) Reset() {
+	*x = SearchReviewsRequest{}
+	mi := &file_reviews_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchReviewsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchReviewsRequest) ProtoMessage() {}
+
+func (x *SearchReviewsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reviews_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchReviewsRequest.ProtoReflect.Descriptor instead.
+func (*SearchReviewsRequest) Descriptor() ([]byte, []int) {
+	return file_reviews_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *SearchReviewsRequest) GetProductId() uint32 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *SearchReviewsRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchReviewsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *SearchReviewsRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type ReviewSearchHit struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Review        *Review                `protobuf:"bytes,1,opt,name=review,proto3" json:"review,omitempty"`
+	Rank          float32                `protobuf:"fixed32,2,opt,name=rank,proto3" json:"rank,omitempty"`
+	Snippet       string                 `protobuf:"bytes,3,opt,name=snippet,proto3" json:"snippet,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReviewSearchHit) Reset() {
+	*x = ReviewSearchHit{}
+	mi := &file_reviews_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReviewSearchHit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReviewSearchHit) ProtoMessage() {}
+
+func (x *ReviewSearchHit) ProtoReflect() protoreflect.Message {
+	mi := &file_reviews_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReviewSearchHit.ProtoReflect.Descriptor instead.
+func (*ReviewSearchHit) Descriptor() ([]byte, []int) {
+	return file_reviews_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ReviewSearchHit) GetReview() *Review {
+	if x != nil {
+		return x.Review
+	}
+	return nil
+}
+
+func (x *ReviewSearchHit) GetRank() float32 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+func (x *ReviewSearchHit) GetSnippet() string {
+	if x != nil {
+		return x.Snippet
+	}
+	return ""
+}
+
+type SearchReviewsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hits          []*ReviewSearchHit     `protobuf:"bytes,1,rep,name=hits,proto3" json:"hits,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchReviewsResponse) Reset() {
+	*x = SearchReviewsResponse{}
+	mi := &file_reviews_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchReviewsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchReviewsResponse) ProtoMessage() {}
+
+func (x *SearchReviewsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_reviews_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchReviewsResponse.ProtoReflect.Descriptor instead.
+func (*SearchReviewsResponse) Descriptor() ([]byte, []int) {
+	return file_reviews_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SearchReviewsResponse) GetHits() []*ReviewSearchHit {
+	if x != nil {
+		return x.Hits
+	}
+	return nil
+}
+
+type GetReviewHighlightsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     uint32                 `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReviewHighlightsRequest) Reset() {
+	*x = GetReviewHighlightsRequest{}
+	mi := &file_reviews_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReviewHighlightsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReviewHighlightsRequest) ProtoMessage() {}
+
+func (x *GetReviewHighlightsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reviews_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReviewHighlightsRequest.ProtoReflect.Descriptor instead.
+func (*GetReviewHighlightsRequest) Descriptor() ([]byte, []int) {
+	return file_reviews_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetReviewHighlightsRequest) GetProductId() uint32 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+type ReviewHighlightsResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	TopPositive    *Review                `protobuf:"bytes,1,opt,name=top_positive,json=topPositive,proto3" json:"top_positive,omitempty"`
+	TopCritical    *Review                `protobuf:"bytes,2,opt,name=top_critical,json=topCritical,proto3" json:"top_critical,omitempty"`
+	PositivePinned bool                   `protobuf:"varint,3,opt,name=positive_pinned,json=positivePinned,proto3" json:"positive_pinned,omitempty"`
+	CriticalPinned bool                   `protobuf:"varint,4,opt,name=critical_pinned,json=criticalPinned,proto3" json:"critical_pinned,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ReviewHighlightsResponse) Reset() {
+	*x = ReviewHighlightsResponse{}
+	mi := &file_reviews_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReviewHighlightsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReviewHighlightsResponse) ProtoMessage() {}
+
+func (x *ReviewHighlightsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_reviews_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReviewHighlightsResponse.ProtoReflect.Descriptor instead.
+func (*ReviewHighlightsResponse) Descriptor() ([]byte, []int) {
+	return file_reviews_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ReviewHighlightsResponse) GetTopPositive() *Review {
+	if x != nil {
+		return x.TopPositive
+	}
+	return nil
+}
+
+func (x *ReviewHighlightsResponse) GetTopCritical() *Review {
+	if x != nil {
+		return x.TopCritical
+	}
+	return nil
+}
+
+func (x *ReviewHighlightsResponse) GetPositivePinned() bool {
+	if x != nil {
+		return x.PositivePinned
+	}
+	return false
+}
+
+func (x *ReviewHighlightsResponse) GetCriticalPinned() bool {
+	if x != nil {
+		return x.CriticalPinned
+	}
+	return false
+}
+
+type GetRatingSummariesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductIds    []uint32               `protobuf:"varint,1,rep,packed,name=product_ids,json=productIds,proto3" json:"product_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRatingSummariesRequest) Reset() {
+	*x = GetRatingSummariesRequest{}
+	mi := &file_reviews_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRatingSummariesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRatingSummariesRequest) ProtoMessage() {}
+
+func (x *GetRatingSummariesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reviews_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRatingSummariesRequest.ProtoReflect.Descriptor instead.
+func (*GetRatingSummariesRequest) Descriptor() ([]byte, []int) {
+	return file_reviews_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetRatingSummariesRequest) GetProductIds() []uint32 {
+	if x != nil {
+		return x.ProductIds
+	}
+	return nil
+}
+
+type RatingSummary struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ProductId uint32                 `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Count     int64                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	Average   float64                `protobuf:"fixed64,3,opt,name=average,proto3" json:"average,omitempty"`
+	// histogram[i] — количество отзывов с оценкой i+1
+	Histogram     []int64 `protobuf:"varint,4,rep,packed,name=histogram,proto3" json:"histogram,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RatingSummary) Reset() {
+	*x = RatingSummary{}
+	mi := &file_reviews_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RatingSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RatingSummary) ProtoMessage() {}
+
+func (x *RatingSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_reviews_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RatingSummary.ProtoReflect.Descriptor instead.
+func (*RatingSummary) Descriptor() ([]byte, []int) {
+	return file_reviews_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *RatingSummary) GetProductId() uint32 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *RatingSummary) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *RatingSummary) GetAverage() float64 {
+	if x != nil {
+		return x.Average
+	}
+	return 0
+}
+
+func (x *RatingSummary) GetHistogram() []int64 {
+	if x != nil {
+		return x.Histogram
+	}
+	return nil
+}
+
+type RatingSummariesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Summaries     []*RatingSummary       `protobuf:"bytes,1,rep,name=summaries,proto3" json:"summaries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RatingSummariesResponse) Reset() {
+	*x = RatingSummariesResponse{}
+	mi := &file_reviews_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RatingSummariesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RatingSummariesResponse) ProtoMessage() {}
+
+func (x *RatingSummariesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_reviews_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RatingSummariesResponse.ProtoReflect.Descriptor instead.
+func (*RatingSummariesResponse) Descriptor() ([]byte, []int) {
+	return file_reviews_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *RatingSummariesResponse) GetSummaries() []*RatingSummary {
+	if x != nil {
+		return x.Summaries
+	}
+	return nil
+}
+
+type GetReviewsByUserRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId uint32                 `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Limit  int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset int32                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	// пользователь, запрашивающий список; удалённые отзывы видны только автору
+	ViewerId      uint32 `protobuf:"varint,4,opt,name=viewer_id,json=viewerId,proto3" json:"viewer_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReviewsByUserRequest) Reset() {
+	*x = GetReviewsByUserRequest{}
+	mi := &file_reviews_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReviewsByUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReviewsByUserRequest) ProtoMessage() {}
+
+func (x *GetReviewsByUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reviews_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReviewsByUserRequest.ProtoReflect.Descriptor instead.
+func (*GetReviewsByUserRequest) Descriptor() ([]byte, []int) {
+	return file_reviews_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetReviewsByUserRequest) GetUserId() uint32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *GetReviewsByUserRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetReviewsByUserRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *GetReviewsByUserRequest) GetViewerId() uint32 {
+	if x != nil {
+		return x.ViewerId
+	}
+	return 0
+}
+
+type UserReview struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Review        *Review                `protobuf:"bytes,1,opt,name=review,proto3" json:"review,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserReview) Reset() {
+	*x = UserReview{}
+	mi := &file_reviews_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserReview) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserReview) ProtoMessage() {}
+
+func (x *UserReview) ProtoReflect() protoreflect.Message {
+	mi := &file_reviews_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserReview.ProtoReflect.Descriptor instead.
+func (*UserReview) Descriptor() ([]byte, []int) {
+	return file_reviews_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *UserReview) GetReview() *Review {
+	if x != nil {
+		return x.Review
+	}
+	return nil
+}
+
+func (x *UserReview) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type UserReviewListResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reviews       []*UserReview          `protobuf:"bytes,1,rep,name=reviews,proto3" json:"reviews,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserReviewListResponse) Reset() {
+	*x = UserReviewListResponse{}
+	mi := &file_reviews_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserReviewListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserReviewListResponse) ProtoMessage() {}
+
+func (x *UserReviewListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_reviews_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserReviewListResponse.ProtoReflect.Descriptor instead.
+func (*UserReviewListResponse) Descriptor() ([]byte, []int) {
+	return file_reviews_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *UserReviewListResponse) GetReviews() []*UserReview {
+	if x != nil {
+		return x.Reviews
+	}
+	return nil
+}
+
+type ClaimGuestReviewsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GuestId       []byte                 `protobuf:"bytes,1,opt,name=guest_id,json=guestId,proto3" json:"guest_id,omitempty"`
+	UserId        uint32                 `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClaimGuestReviewsRequest) Reset() {
+	*x = ClaimGuestReviewsRequest{}
+	mi := &file_reviews_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClaimGuestReviewsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClaimGuestReviewsRequest) ProtoMessage() {}
+
+func (x *ClaimGuestReviewsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reviews_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClaimGuestReviewsRequest.ProtoReflect.Descriptor instead.
+func (*ClaimGuestReviewsRequest) Descriptor() ([]byte, []int) {
+	return file_reviews_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ClaimGuestReviewsRequest) GetGuestId() []byte {
+	if x != nil {
+		return x.GuestId
+	}
+	return nil
+}
+
+func (x *ClaimGuestReviewsRequest) GetUserId() uint32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type ClaimGuestReviewsResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ReviewsClaimed int64                  `protobuf:"varint,1,opt,name=reviews_claimed,json=reviewsClaimed,proto3" json:"reviews_claimed,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ClaimGuestReviewsResponse) Reset() {
+	*x = ClaimGuestReviewsResponse{}
+	mi := &file_reviews_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClaimGuestReviewsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClaimGuestReviewsResponse) ProtoMessage() {}
+
+func (x *ClaimGuestReviewsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_reviews_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClaimGuestReviewsResponse.ProtoReflect.Descriptor instead.
+func (*ClaimGuestReviewsResponse) Descriptor() ([]byte, []int) {
+	return file_reviews_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ClaimGuestReviewsResponse) GetReviewsClaimed() int64 {
+	if x != nil {
+		return x.ReviewsClaimed
+	}
+	return 0
+}
+
+var File_reviews_proto protoreflect.FileDescriptor
+
+const file_reviews_proto_rawDesc = "" +
+	"\n" +
+	"\rreviews.proto\x12\x05proto\x1a\fcommon.proto\"/\n" +
+	"\x10GetReviewRequest\x12\x1b\n" +
+	"\treview_id\x18\x01 \x01(\rR\breviewId\"\xa8\x01\n" +
+	"\x13CreateReviewRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\rR\tproductId\x12\x19\n" +
+	"\auser_id\x18\x02 \x01(\rH\x00R\x06userId\x12\x1b\n" +
+	"\bguest_id\x18\x03 \x01(\fH\x00R\aguestId\x12\x16\n" +
+	"\x06rating\x18\x04 \x01(\x05R\x06rating\x12\x18\n" +
+	"\acomment\x18\x05 \x01(\tR\acommentB\b\n" +
+	"\x06author\"\xa6\x01\n" +
+	"\x13UpdateReviewRequest\x12\x1b\n" +
+	"\treview_id\x18\x01 \x01(\rR\breviewId\x12\x19\n" +
+	"\auser_id\x18\x02 \x01(\rH\x00R\x06userId\x12\x1b\n" +
+	"\bguest_id\x18\x03 \x01(\fH\x00R\aguestId\x12\x16\n" +
+	"\x06rating\x18\x04 \x01(\x05R\x06rating\x12\x18\n" +
+	"\acomment\x18\x05 \x01(\tR\acommentB\b\n" +
+	"\x06author\"2\n" +
+	"\x13DeleteReviewRequest\x12\x1b\n" +
+	"\treview_id\x18\x01 \x01(\rR\breviewId\"\x16\n" +
+	"\x14DeleteReviewResponse\"a\n" +
+	"\x11LikeReviewRequest\x12\x1b\n" +
+	"\treview_id\x18\x01 \x01(\rR\breviewId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\rR\x06userId\x12\x16\n" +
+	"\x06remove\x18\x03 \x01(\bR\x06remove\"5\n" +
+	"\x12LikeReviewResponse\x12\x1f\n" +
+	"\vlikes_count\x18\x01 \x01(\x05R\n" +
+	"likesCount\"t\n" +
+	"\x11GetReviewsRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\rR\tproductId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x03 \x01(\x05R\x06offset\x12\x12\n" +
+	"\x04sort\x18\x04 \x01(\tR\x04sort\"=\n" +
+	"\x12ReviewListResponse\x12'\n" +
+	"\areviews\x18\x01 \x03(\v2\r.proto.ReviewR\areviews\"y\n" +
+	"\x14SearchReviewsRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\rR\tproductId\x12\x14\n" +
+	"\x05query\x18\x02 \x01(\tR\x05query\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x04 \x01(\x05R\x06offset\"f\n" +
+	"\x0fReviewSearchHit\x12%\n" +
+	"\x06review\x18\x01 \x01(\v2\r.proto.ReviewR\x06review\x12\x12\n" +
+	"\x04rank\x18\x02 \x01(\x02R\x04rank\x12\x18\n" +
+	"\asnippet\x18\x03 \x01(\tR\asnippet\"C\n" +
+	"\x15SearchReviewsResponse\x12*\n" +
+	"\x04hits\x18\x01 \x03(\v2\x16.proto.ReviewSearchHitR\x04hits\";\n" +
+	"\x1aGetReviewHighlightsRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\rR\tproductId\"\xd0\x01\n" +
+	"\x18ReviewHighlightsResponse\x120\n" +
+	"\ftop_positive\x18\x01 \x01(\v2\r.proto.ReviewR\vtopPositive\x120\n" +
+	"\ftop_critical\x18\x02 \x01(\v2\r.proto.ReviewR\vtopCritical\x12'\n" +
+	"\x0fpositive_pinned\x18\x03 \x01(\bR\x0epositivePinned\x12'\n" +
+	"\x0fcritical_pinned\x18\x04 \x01(\bR\x0ecriticalPinned\"<\n" +
+	"\x19GetRatingSummariesRequest\x12\x1f\n" +
+	"\vproduct_ids\x18\x01 \x03(\rR\n" +
+	"productIds\"|\n" +
+	"\rRatingSummary\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\rR\tproductId\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x03R\x05count\x12\x18\n" +
+	"\aaverage\x18\x03 \x01(\x01R\aaverage\x12\x1c\n" +
+	"\thistogram\x18\x04 \x03(\x03R\thistogram\"M\n" +
+	"\x17RatingSummariesResponse\x122\n" +
+	"\tsummaries\x18\x01 \x03(\v2\x14.proto.RatingSummaryR\tsummaries\"}\n" +
+	"\x17GetReviewsByUserRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\rR\x06userId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x03 \x01(\x05R\x06offset\x12\x1b\n" +
+	"\tviewer_id\x18\x04 \x01(\rR\bviewerId\"K\n" +
+	"\n" +
+	"UserReview\x12%\n" +
+	"\x06review\x18\x01 \x01(\v2\r.proto.ReviewR\x06review\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\"E\n" +
+	"\x16UserReviewListResponse\x12+\n" +
+	"\areviews\x18\x01 \x03(\v2\x11.proto.UserReviewR\areviews\"N\n" +
+	"\x18ClaimGuestReviewsRequest\x12\x19\n" +
+	"\bguest_id\x18\x01 \x01(\fR\aguestId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\rR\x06userId\"D\n" +
+	"\x19ClaimGuestReviewsResponse\x12'\n" +
+	"\x0freviews_claimed\x18\x01 \x01(\x03R\x0ereviewsClaimed2\xbd\x06\n" +
+	"\rReviewService\x123\n" +
+	"\tGetReview\x12\x17.proto.GetReviewRequest\x1a\r.proto.Review\x129\n" +
+	"\fCreateReview\x12\x1a.proto.CreateReviewRequest\x1a\r.proto.Review\x129\n" +
+	"\fUpdateReview\x12\x1a.proto.UpdateReviewRequest\x1a\r.proto.Review\x12G\n" +
+	"\fDeleteReview\x12\x1a.proto.DeleteReviewRequest\x1a\x1b.proto.DeleteReviewResponse\x12A\n" +
+	"\n" +
+	"LikeReview\x12\x18.proto.LikeReviewRequest\x1a\x19.proto.LikeReviewResponse\x12K\n" +
+	"\x14GetReviewsForProduct\x12\x18.proto.GetReviewsRequest\x1a\x19.proto.ReviewListResponse\x12J\n" +
+	"\rSearchReviews\x12\x1b.proto.SearchReviewsRequest\x1a\x1c.proto.SearchReviewsResponse\x12Y\n" +
+	"\x13GetReviewHighlights\x12!.proto.GetReviewHighlightsRequest\x1a\x1f.proto.ReviewHighlightsResponse\x12V\n" +
+	"\x12GetRatingSummaries\x12 .proto.GetRatingSummariesRequest\x1a\x1e.proto.RatingSummariesResponse\x12Q\n" +
+	"\x10GetReviewsByUser\x12\x1e.proto.GetReviewsByUserRequest\x1a\x1d.proto.UserReviewListResponse\x12V\n" +
+	"\x11ClaimGuestReviews\x12\x1f.proto.ClaimGuestReviewsRequest\x1a .proto.ClaimGuestReviewsResponseB\x0fZ\r./pkg/serviceb\x06proto3"
+
+var (
+	file_reviews_proto_rawDescOnce sync.Once
+	file_reviews_proto_rawDescData []byte
+)
+
+func file_reviews_proto_rawDescGZIP() []byte {
+	file_reviews_proto_rawDescOnce.Do(func() {
+		file_reviews_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_reviews_proto_rawDesc), len(file_reviews_proto_rawDesc)))
+	})
+	return file_reviews_proto_rawDescData
+}
+
+var file_reviews_proto_msgTypes = make([]protoimpl.MessageInfo, 22)
+var file_reviews_proto_goTypes = []any{
+	(*GetReviewRequest)(nil),           // 0: proto.GetReviewRequest
+	(*CreateReviewRequest)(nil),        // 1: proto.CreateReviewRequest
+	(*UpdateReviewRequest)(nil),        // 2: proto.UpdateReviewRequest
+	(*DeleteReviewRequest)(nil),        // 3: proto.DeleteReviewRequest
+	(*DeleteReviewResponse)(nil),       // 4: proto.DeleteReviewResponse
+	(*LikeReviewRequest)(nil),          // 5: proto.LikeReviewRequest
+	(*LikeReviewResponse)(nil),         // 6: proto.LikeReviewResponse
+	(*GetReviewsRequest)(nil),          // 7: proto.GetReviewsRequest
+	(*ReviewListResponse)(nil),         // 8: proto.ReviewListResponse
+	(*SearchReviewsRequest)(nil),       // 9: proto.SearchReviewsRequest
+	(*ReviewSearchHit)(nil),            // 10: proto.ReviewSearchHit
+	(*SearchReviewsResponse)(nil),      // 11: proto.SearchReviewsResponse
+	(*GetReviewHighlightsRequest)(nil), // 12: proto.GetReviewHighlightsRequest
+	(*ReviewHighlightsResponse)(nil),   // 13: proto.ReviewHighlightsResponse
+	(*GetRatingSummariesRequest)(nil),  // 14: proto.GetRatingSummariesRequest
+	(*RatingSummary)(nil),              // 15: proto.RatingSummary
+	(*RatingSummariesResponse)(nil),    // 16: proto.RatingSummariesResponse
+	(*GetReviewsByUserRequest)(nil),    // 17: proto.GetReviewsByUserRequest
+	(*UserReview)(nil),                 // 18: proto.UserReview
+	(*UserReviewListResponse)(nil),     // 19: proto.UserReviewListResponse
+	(*ClaimGuestReviewsRequest)(nil),   // 20: proto.ClaimGuestReviewsRequest
+	(*ClaimGuestReviewsResponse)(nil),  // 21: proto.ClaimGuestReviewsResponse
+	(*Review)(nil),                     // 22: proto.Review
+}
+var file_reviews_proto_depIdxs = []int32{
+	22, // 0: proto.ReviewListResponse.reviews:type_name -> proto.Review
+	22, // 1: proto.ReviewSearchHit.review:type_name -> proto.Review
+	10, // 2: proto.SearchReviewsResponse.hits:type_name -> proto.ReviewSearchHit
+	22, // 3: proto.ReviewHighlightsResponse.top_positive:type_name -> proto.Review
+	22, // 4: proto.ReviewHighlightsResponse.top_critical:type_name -> proto.Review
+	15, // 5: proto.RatingSummariesResponse.summaries:type_name -> proto.RatingSummary
+	22, // 6: proto.UserReview.review:type_name -> proto.Review
+	18, // 7: proto.UserReviewListResponse.reviews:type_name -> proto.UserReview
+	0,  // 8: proto.ReviewService.GetReview:input_type -> proto.GetReviewRequest
+	1,  // 9: proto.ReviewService.CreateReview:input_type -> proto.CreateReviewRequest
+	2,  // 10: proto.ReviewService.UpdateReview:input_type -> proto.UpdateReviewRequest
+	3,  // 11: proto.ReviewService.DeleteReview:input_type -> proto.DeleteReviewRequest
+	5,  // 12: proto.ReviewService.LikeReview:input_type -> proto.LikeReviewRequest
+	7,  // 13: proto.ReviewService.GetReviewsForProduct:input_type -> proto.GetReviewsRequest
+	9,  // 14: proto.ReviewService.SearchReviews:input_type -> proto.SearchReviewsRequest
+	12, // 15: proto.ReviewService.GetReviewHighlights:input_type -> proto.GetReviewHighlightsRequest
+	14, // 16: proto.ReviewService.GetRatingSummaries:input_type -> proto.GetRatingSummariesRequest
+	17, // 17: proto.ReviewService.GetReviewsByUser:input_type -> proto.GetReviewsByUserRequest
+	20, // 18: proto.ReviewService.ClaimGuestReviews:input_type -> proto.ClaimGuestReviewsRequest
+	22, // 19: proto.ReviewService.GetReview:output_type -> proto.Review
+	22, // 20: proto.ReviewService.CreateReview:output_type -> proto.Review
+	22, // 21: proto.ReviewService.UpdateReview:output_type -> proto.Review
+	4,  // 22: proto.ReviewService.DeleteReview:output_type -> proto.DeleteReviewResponse
+	6,  // 23: proto.ReviewService.LikeReview:output_type -> proto.LikeReviewResponse
+	8,  // 24: proto.ReviewService.GetReviewsForProduct:output_type -> proto.ReviewListResponse
+	11, // 25: proto.ReviewService.SearchReviews:output_type -> proto.SearchReviewsResponse
+	13, // 26: proto.ReviewService.GetReviewHighlights:output_type -> proto.ReviewHighlightsResponse
+	16, // 27: proto.ReviewService.GetRatingSummaries:output_type -> proto.RatingSummariesResponse
+	19, // 28: proto.ReviewService.GetReviewsByUser:output_type -> proto.UserReviewListResponse
+	21, // 29: proto.ReviewService.ClaimGuestReviews:output_type -> proto.ClaimGuestReviewsResponse
+	19, // [19:30] is the sub-list for method output_type
+	8,  // [8:19] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
+}
+
+func init() { file_reviews_proto_init() }
+func file_reviews_proto_init() {
+	if File_reviews_proto != nil {
+		return
+	}
+	file_common_proto_init()
+	file_reviews_proto_msgTypes[1].OneofWrappers = []any{
+		(*CreateReviewRequest_UserId)(nil),
+		(*CreateReviewRequest_GuestId)(nil),
+	}
+	file_reviews_proto_msgTypes[2].OneofWrappers = []any{
+		(*UpdateReviewRequest_UserId)(nil),
+		(*UpdateReviewRequest_GuestId)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_reviews_proto_rawDesc), len(file_reviews_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   22,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_reviews_proto_goTypes,
+		DependencyIndexes: file_reviews_proto_depIdxs,
+		MessageInfos:      file_reviews_proto_msgTypes,
+	}.Build()
+	File_reviews_proto = out.File
+	file_reviews_proto_goTypes = nil
+	file_reviews_proto_depIdxs = nil
+}